@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/sequences"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// runFollowUp executa o subcomando "followup": detecta aceitações e envia as
+// etapas vencidas das sequências configuradas na interface web
+//
+//	crawler followup [--max-messages 20]
+func runFollowUp(args []string) {
+	fs := flag.NewFlagSet("followup", flag.ExitOnError)
+	maxMessages := fs.Int("max-messages", 20, "Máximo de mensagens enviadas na execução")
+	maxConnections := fs.Int("max-connections", 200, "Conexões recentes lidas para detectar aceitações")
+	headless := fs.Bool("headless", true, "Executar em modo headless")
+	fs.Parse(args)
+
+	email := os.Getenv("LINKEDIN_EMAIL")
+	password := os.Getenv("LINKEDIN_PASSWORD")
+	if email == "" || password == "" {
+		log.Fatal("LINKEDIN_EMAIL e LINKEDIN_PASSWORD devem estar definidos (env/.env)")
+	}
+
+	seqs, err := sequences.New()
+	if err != nil {
+		log.Fatalf("Erro ao carregar sequências: %v", err)
+	}
+
+	opts := sequences.RunOptions{
+		MaxMessages:    *maxMessages,
+		MaxConnections: *maxConnections,
+		Headless:       *headless,
+	}
+
+	store := openStore()
+	defer store.Close()
+
+	summary, err := sequences.Run(seqs, store, storage.NewMessageLog(), opts,
+		crawler.Creds{Email: email, Password: password}, func(line string) {
+			log.Println(line)
+		})
+	if err != nil {
+		log.Fatalf("Erro nos follow-ups: %v", err)
+	}
+
+	log.Printf("✅ Follow-ups concluídos: %s", summary)
+}
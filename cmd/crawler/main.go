@@ -8,8 +8,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/export"
+	"github.com/your-org/linkedin-visible-crawler/internal/orchestrator"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
 )
 
 func main() {
@@ -20,13 +24,55 @@ func main() {
 		}
 	}
 
+	// Subcomandos de manutenção
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "withdraw":
+			runWithdraw(os.Args[2:])
+			return
+		case "sync":
+			runSync(os.Args[2:])
+			return
+		case "followup":
+			runFollowUp(os.Args[2:])
+			return
+		case "push":
+			runPush(os.Args[2:])
+			return
+		case "migrate":
+			runMigrate(os.Args[2:])
+			return
+		case "subject-export":
+			runSubjectExport(os.Args[2:])
+			return
+		case "erase":
+			runErase(os.Args[2:])
+			return
+		case "purge":
+			runPurge(os.Args[2:])
+			return
+		}
+	}
+
 	// Flags
 	query := flag.String("query", "", "Query de busca (pode ser repetida)")
-	queriesFile := flag.String("queries-file", "", "Arquivo com queries (uma por linha)")
+	queriesFile := flag.String("queries-file", "", "Arquivo com queries (uma por linha: palavras-chave ou URL de busca)")
+	profilesFile := flag.String("profiles-file", "", "Arquivo com URLs de perfil /in/ (uma por linha); ativa o modo lista de perfis")
+	company := flag.String("company", "", "URL ou slug da empresa; ativa o modo aba Pessoas da empresa")
+	companyKeywords := flag.String("company-keywords", "", "Filtro de cargo/palavra-chave na aba Pessoas")
+	companyLocation := flag.String("company-location", "", "Filtro de localização na aba Pessoas")
 	headless := flag.Bool("headless", true, "Executar em modo headless")
 	maxCards := flag.Int("max-cards", 60, "Máximo de cards para ler")
 	maxConnects := flag.Int("max-connects", 3, "Máximo de convites por página")
-	csvOut := flag.String("csv-out", "", "Arquivo CSV de saída (opcional)")
+	maxPages := flag.Int("max-pages", 1, "Máximo de páginas de resultados por query")
+	maxInvites := flag.Int("max-invites", 0, "Máximo de convites na execução (0 = sem limite)")
+	out := flag.String("out", "", "Arquivo de saída dos contatos (padrão: linkedin_visible_<data>.<formato>)")
+	csvOut := flag.String("csv-out", "", "Obsoleto: use --out")
+	format := flag.String("format", "csv", "Formato da exportação: "+strings.Join(export.Names(), ", "))
+	columns := flag.String("columns", "", "Colunas exportadas, separadas por vírgula (padrão: "+strings.Join(export.DefaultContactColumns, ",")+")")
+	lang := flag.String("lang", export.LangPT, "Idioma do cabeçalho: pt, en ou key")
+	crmProfile := flag.String("profile", "", "Perfil de importação de CRM ("+strings.Join(export.ProfileNames(), ", ")+"); substitui --columns e --lang")
+	scoringFile := flag.String("scoring", crawler.ScoringFile, "Arquivo JSON com as regras de pontuação de leads (ignorado se não existir)")
 	flag.Parse()
 
 	// Credenciais
@@ -46,30 +92,147 @@ func main() {
 		if err != nil {
 			log.Fatalf("Erro ao ler arquivo de queries: %v", err)
 		}
-		for _, line := range strings.Split(string(content), "\n") {
-			if s := strings.TrimSpace(line); s != "" {
-				queries = append(queries, s)
+		parsed, err := crawler.ParseQueries(string(content))
+		if err != nil {
+			log.Fatalf("Erro no arquivo de queries: %v", err)
+		}
+		queries = append(queries, parsed...)
+	}
+
+	// Lista direta de perfis
+	var profiles []string
+	if *profilesFile != "" {
+		content, err := os.ReadFile(*profilesFile)
+		if err != nil {
+			log.Fatalf("Erro ao ler arquivo de perfis: %v", err)
+		}
+		parsed, err := crawler.ParseProfileURLs(string(content))
+		if err != nil {
+			log.Fatalf("Erro no arquivo de perfis: %v", err)
+		}
+		profiles = parsed
+	}
+
+	// Um modo por execução: combinar fontes descartaria uma delas em silêncio
+	sources := 0
+	for _, given := range []bool{len(queries) > 0, *profilesFile != "", *company != ""} {
+		if given {
+			sources++
+		}
+	}
+	if sources > 1 {
+		log.Fatal("Use apenas uma fonte por execução: --query/--queries-file, --profiles-file ou --company")
+	}
+
+	store := openStore()
+	defer store.Close()
+
+	// Limites de convites da conta (data/limits.json), com reserva de vaga
+	// compartilhada com o servidor e outras execuções (data/quota.json)
+	limits, err := storage.NewLimits()
+	if err != nil {
+		log.Fatalf("Erro ao carregar limites de convites: %v", err)
+	}
+	weeklyCounter, err := storage.NewWeeklyCounter(store, limits)
+	if err != nil {
+		log.Fatalf("Erro ao carregar contador semanal: %v", err)
+	}
+	quota := storage.NewQuota(weeklyCounter)
+
+	// Supressão: perfis que já receberam convite não são visitados nem convidados de novo
+	invited, err := store.InvitedURLs()
+	if err != nil {
+		log.Fatalf("Erro ao carregar convites anteriores: %v", err)
+	}
+	if len(profiles) > 0 {
+		var pending []string
+		for _, p := range profiles {
+			if !invited[strings.ToLower(crawler.NormalizeProfileURL(p))] {
+				pending = append(pending, p)
 			}
 		}
+		if skipped := len(profiles) - len(pending); skipped > 0 {
+			log.Printf("%d perfis da lista já receberam convite e foram pulados", skipped)
+		}
+		if len(pending) == 0 {
+			log.Println("Todos os perfis da lista já receberam convite; nada a fazer")
+			return
+		}
+		profiles = pending
+	}
+
+	mode := crawler.ModeSearch
+	var companySlug string
+	switch {
+	case *company != "":
+		slug, err := crawler.ParseCompany(*company)
+		if err != nil {
+			log.Fatalf("Empresa inválida: %v", err)
+		}
+		mode, companySlug = crawler.ModeCompany, slug
+	case len(profiles) > 0:
+		mode = crawler.ModeProfiles
 	}
-	if len(queries) == 0 {
-		log.Fatal("Nenhuma query especificada. Use --query ou --queries-file")
+
+	if len(queries) == 0 && len(profiles) == 0 && companySlug == "" {
+		log.Fatal("Nenhuma query especificada. Use --query, --queries-file, --profiles-file ou --company")
 	}
 
 	// Config nova (RunConfig)
 	cfg := crawler.RunConfig{
+		Mode:               mode,
 		MaxCardsRead:       *maxCards,
 		MaxConnectsPerPage: *maxConnects,
+		MaxInvites:         *maxInvites,
 		Queries:            queries,
+		MaxPages:           *maxPages,
+		Profiles:           profiles,
+		Company:            companySlug,
+		CompanyKeywords:    *companyKeywords,
+		CompanyLocation:    *companyLocation,
 		Headless:           *headless,
 	}
 
-	if *csvOut == "" {
-		*csvOut = fmt.Sprintf("linkedin_visible_%s.csv", time.Now().Format("20060102_150405"))
+	scoring, err := crawler.LoadScoringModel(*scoringFile)
+	if err != nil {
+		log.Fatalf("Erro no modelo de pontuação: %v", err)
+	}
+	if scoring != nil {
+		cfg.Scoring = scoring
+		log.Printf("Pontuação de leads: %d regras (mínimo %.1f)", len(scoring.Rules), scoring.MinScore)
 	}
 
-	log.Printf("Iniciando crawler: %d queries | headless=%v | maxCards=%d | maxConnects=%d",
-		len(queries), cfg.Headless, cfg.MaxCardsRead, cfg.MaxConnectsPerPage)
+	exportFormat, err := export.Lookup(*format)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	exportOpts := export.Options{Columns: export.ParseColumns(*columns), Lang: *lang, Profile: *crmProfile}
+	if _, err := export.Contacts(nil, exportOpts); err != nil {
+		log.Fatalf("Exportação inválida: %v", err)
+	}
+	if *out == "" {
+		*out = *csvOut
+	}
+	if *out == "" {
+		*out = fmt.Sprintf("linkedin_visible_%s.%s", time.Now().Format("20060102_150405"), exportFormat.Ext)
+	}
+
+	// Rótulo da execução no histórico (como no painel, marcado como CLI)
+	var label string
+	switch cfg.Mode {
+	case crawler.ModeCompany:
+		label = "empresa " + companySlug
+		log.Printf("Iniciando crawler (empresa): %s | keywords=%q | location=%q | maxCards=%d | maxConnects=%d",
+			companySlug, cfg.CompanyKeywords, cfg.CompanyLocation, cfg.MaxCardsRead, cfg.MaxConnectsPerPage)
+	case crawler.ModeProfiles:
+		label = fmt.Sprintf("%d perfis", len(profiles))
+		log.Printf("Iniciando crawler (lista de perfis): %d perfis | headless=%v | maxInvites=%d",
+			len(profiles), cfg.Headless, cfg.MaxInvites)
+	default:
+		label = fmt.Sprintf("%d queries", len(queries))
+		log.Printf("Iniciando crawler: %d queries (%d URLs) | headless=%v | maxCards=%d | maxConnects=%d | maxPages=%d",
+			len(queries), crawler.CountSearchURLs(queries), cfg.Headless, cfg.MaxCardsRead, cfg.MaxConnectsPerPage, cfg.MaxPages)
+	}
 
 	creds := crawler.Creds{Email: email, Password: password}
 
@@ -80,33 +243,99 @@ func main() {
 	callbacks := crawler.Callbacks{
 		OnCaptured: func(c crawler.Contact) {
 			capturedAll = append(capturedAll, c)
-			log.Printf("📇 Capturado: %s | %s | %s | %s", c.Name, c.Title, c.Company, c.LinkedIn)
+			log.Printf("📇 Capturado: %s | %s | %s | %s | pontuação %.1f", c.Name, c.Title, c.Company, c.LinkedIn, c.Score)
 		},
-		OnInviteSent: func(c crawler.Contact) {
+		OnInviteSent: func(c crawler.Contact) error {
+			// Mesmo sem registro o convite foi enviado: conta no limite e não é
+			// repetido; com o erro o motor mantém a vaga ocupada (Keep)
+			invite := crawler.NewInviteRecord(email, c)
+			invited[strings.ToLower(crawler.NormalizeProfileURL(c.LinkedIn))] = true
 			invitesTotal++
+			weeklyCounter.Add(invite)
+			if err := store.AppendInvite(invite); err != nil {
+				return fmt.Errorf("erro ao salvar convite: %v", err)
+			}
 			log.Printf("🤝 Convite enviado: %s | %s | %s", c.Name, c.Title, c.LinkedIn)
+			return nil
 		},
 		OnLog: func(line string) {
 			log.Println(line)
 		},
+		CanInvite: func(c crawler.Contact) (bool, string) {
+			if invited[strings.ToLower(crawler.NormalizeProfileURL(c.LinkedIn))] {
+				return false, "convite já enviado anteriormente"
+			}
+			usage := weeklyCounter.Usage(email)
+			if !usage.CanSend() {
+				return false, usage.Reason()
+			}
+			return cfg.Scoring.Admit(c.Score, usage.Remaining())
+		},
+		ReserveInvite: func(c crawler.Contact) (crawler.InviteSlot, string) {
+			slot, usage, err := quota.Reserve(email)
+			if err != nil {
+				return nil, "erro ao reservar convite: " + err.Error()
+			}
+			if slot == nil {
+				return nil, usage.Reason()
+			}
+			return slot, ""
+		},
 	}
 
 	// Executa o engine (login + 2FA aguardado de forma robusta + queries)
+	// Registro inicial: execuções interrompidas ficam no histórico como
+	// "running" com a configuração usada
+	config := cfg
+	run := storage.RunRecord{
+		ID:        uuid.New().String(),
+		Account:   email,
+		Label:     label + " (CLI)",
+		Mode:      cfg.Mode,
+		Status:    string(orchestrator.StatusRunning),
+		StartedAt: time.Now(),
+		Config:    &config,
+	}
+	if err := store.SaveRun(run); err != nil {
+		log.Printf("Aviso: erro ao registrar execução: %v", err)
+	}
+
 	engine := crawler.NewEngine()
-	if err := engine.Run(cfg, creds, callbacks); err != nil {
-		log.Fatalf("Erro no crawler: %v", err)
+	runErr := engine.Run(cfg, creds, callbacks)
+
+	// Histórico: status, erro, contadores por query e avisos da execução
+	stats := engine.Stats()
+	run.Status = string(orchestrator.StatusDone)
+	if runErr != nil {
+		run.Status = string(orchestrator.StatusFailed)
+		run.Error = runErr.Error()
+	}
+	run.FinishedAt = time.Now()
+	run.Captured = len(capturedAll)
+	run.Invites = invitesTotal
+	run.Queries = stats.Queries
+	run.Warnings = stats.Warnings
+	if err := store.SaveRun(run); err != nil {
+		log.Printf("Aviso: erro ao registrar execução: %v", err)
+	}
+	if runErr != nil {
+		log.Fatalf("Erro no crawler: %v", runErr)
 	}
 
-	// Dedup e salvar CSV
+	// Dedup e exportar
 	unique := crawler.RemoveDup(capturedAll)
-	if err := crawler.SaveCSV(*csvOut, unique); err != nil {
-		log.Fatalf("Erro ao salvar CSV: %v", err)
+	table, err := export.Contacts(unique, exportOpts)
+	if err != nil {
+		log.Fatalf("Erro ao exportar contatos: %v", err)
+	}
+	if err := export.WriteFile(*out, exportFormat, table); err != nil {
+		log.Fatalf("Erro ao salvar %s: %v", exportFormat.Name, err)
 	}
 
 	log.Printf("\n=== RESUMO ===")
 	log.Printf("Total capturados: %d", len(capturedAll))
 	log.Printf("Únicos: %d", len(unique))
 	log.Printf("Convites enviados: %d", invitesTotal)
-	log.Printf("CSV salvo em: %s", *csvOut)
+	log.Printf("Contatos (%s) salvos em: %s", exportFormat.Name, *out)
 	log.Println("✅ Crawler concluído com sucesso")
 }
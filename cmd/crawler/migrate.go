@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"math"
+	"os"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// openStore abre o armazenamento configurado (STORAGE_BACKEND/SQLITE_PATH)
+func openStore() storage.Store {
+	store, err := storage.OpenFromEnv()
+	if err != nil {
+		log.Fatalf("Erro ao abrir armazenamento: %v", err)
+	}
+	return store
+}
+
+// runMigrate executa o subcomando "migrate": importa os CSVs e o histórico de
+// execuções existentes para o banco SQLite (uma única vez; depois use
+// STORAGE_BACKEND=sqlite)
+//
+//	crawler migrate [--invites data/invites.csv] [--captures data/captures.csv] [--runs data/runs.jsonl] [--db data/crawler.db]
+func runMigrate(args []string) {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	invitesFile := fs.String("invites", "data/invites.csv", "CSV de convites a importar")
+	capturesFile := fs.String("captures", "data/captures.csv", "CSV de capturas a importar (ignorado se não existir)")
+	runsFile := fs.String("runs", "data/runs.jsonl", "Histórico de execuções a importar (ignorado se não existir)")
+	dbPath := fs.String("db", "", "Banco SQLite de destino (padrão: SQLITE_PATH ou "+storage.DefaultSQLitePath+")")
+	force := fs.Bool("force", false, "Importar mesmo se o banco já tiver convites")
+	fs.Parse(args)
+
+	if *dbPath == "" {
+		*dbPath = os.Getenv("SQLITE_PATH")
+	}
+	if *dbPath == "" {
+		*dbPath = storage.DefaultSQLitePath
+	}
+
+	if _, err := os.Stat(*invitesFile); err != nil {
+		log.Fatalf("Arquivo de convites não encontrado: %s", *invitesFile)
+	}
+
+	db, err := storage.NewSQLiteStore(*dbPath)
+	if err != nil {
+		log.Fatalf("Erro ao abrir banco: %v", err)
+	}
+	defer db.Close()
+
+	existing, err := db.GetTotalCount()
+	if err != nil {
+		log.Fatalf("Erro ao consultar banco: %v", err)
+	}
+	if existing > 0 && !*force {
+		log.Fatalf("%s já contém %d convites; use --force para importar mesmo assim", *dbPath, existing)
+	}
+
+	invites, _, err := storage.NewInviteStorageAt(*invitesFile).ListInvites(0, math.MaxInt32)
+	if err != nil {
+		log.Fatalf("Erro ao ler %s: %v", *invitesFile, err)
+	}
+	if err := db.ImportInvites(invites); err != nil {
+		log.Fatalf("Erro ao importar convites: %v", err)
+	}
+	log.Printf("✅ %d convites importados de %s", len(invites), *invitesFile)
+
+	if _, err := os.Stat(*capturesFile); err == nil {
+		captures, err := storage.NewCaptureLogAt(*capturesFile).List()
+		if err != nil {
+			log.Fatalf("Erro ao ler %s: %v", *capturesFile, err)
+		}
+		if err := db.ImportCaptures(captures); err != nil {
+			log.Fatalf("Erro ao importar capturas: %v", err)
+		}
+		log.Printf("✅ %d capturas importadas de %s", len(captures), *capturesFile)
+	}
+
+	if _, err := os.Stat(*runsFile); err == nil {
+		runs, err := storage.NewRunLogAt(*runsFile).List(0)
+		if err != nil {
+			log.Fatalf("Erro ao ler %s: %v", *runsFile, err)
+		}
+		// List devolve da mais recente para a mais antiga; importar na ordem original
+		for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+			runs[i], runs[j] = runs[j], runs[i]
+		}
+		if err := db.ImportRuns(runs); err != nil {
+			log.Fatalf("Erro ao importar execuções: %v", err)
+		}
+		log.Printf("✅ %d execuções importadas de %s", len(runs), *runsFile)
+	}
+
+	log.Printf("Banco pronto em %s — defina STORAGE_BACKEND=sqlite para usá-lo", *dbPath)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/privacy"
+	"github.com/your-org/linkedin-visible-crawler/internal/sequences"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// openPrivacy abre armazenamento, mensagens e sequências para os subcomandos
+// de privacidade. A função retornada fecha o armazenamento.
+func openPrivacy() (*privacy.Manager, func()) {
+	store := openStore()
+	seqs, err := sequences.New()
+	if err != nil {
+		store.Close()
+		log.Fatalf("Erro ao carregar sequências: %v", err)
+	}
+	return privacy.New(store, storage.NewMessageLog(), seqs), func() { store.Close() }
+}
+
+// subjectFlags flags --url e --name comuns aos subcomandos por pessoa
+func subjectFlags(fs *flag.FlagSet) (*string, *string) {
+	url := fs.String("url", "", "URL do perfil (https://www.linkedin.com/in/...)")
+	name := fs.String("name", "", "Nome completo (identifica também homônimos; prefira --url)")
+	return url, name
+}
+
+// runSubjectExport executa o subcomando "subject-export": grava em JSON tudo
+// o que está registrado sobre uma pessoa (pedido de acesso)
+//
+//	crawler subject-export --url https://www.linkedin.com/in/fulano [--out fulano.json]
+func runSubjectExport(args []string) {
+	fs := flag.NewFlagSet("subject-export", flag.ExitOnError)
+	url, name := subjectFlags(fs)
+	out := fs.String("out", "", "Arquivo de saída (padrão: saída padrão)")
+	fs.Parse(args)
+
+	mgr, closeStore := openPrivacy()
+	defer closeStore()
+
+	report, err := mgr.Export(privacy.Subject{URL: *url, Name: *name})
+	if err != nil {
+		log.Fatalf("Erro ao exportar dados: %v", err)
+	}
+	body, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Fatalf("Erro ao serializar dados: %v", err)
+	}
+
+	if *out == "" {
+		fmt.Println(string(body))
+		return
+	}
+	if err := os.WriteFile(*out, append(body, '\n'), 0600); err != nil {
+		log.Fatalf("Erro ao gravar %s: %v", *out, err)
+	}
+	log.Printf("✅ Dados exportados para %s %v", *out, report.Counts)
+}
+
+// runErase executa o subcomando "erase": apaga os registros de uma pessoa de
+// todas as origens e registra a remoção em data/erasures.jsonl
+//
+//	crawler erase --url https://www.linkedin.com/in/fulano [--name "Fulano de Tal"]
+func runErase(args []string) {
+	fs := flag.NewFlagSet("erase", flag.ExitOnError)
+	url, name := subjectFlags(fs)
+	fs.Parse(args)
+
+	mgr, closeStore := openPrivacy()
+	defer closeStore()
+
+	record, err := mgr.Erase(privacy.Subject{URL: *url, Name: *name}, "cli")
+	if err != nil {
+		log.Fatalf("Erro ao apagar dados: %v", err)
+	}
+	log.Printf("✅ %d registros apagados %v (auditoria %s)", record.Total(), record.Removed, record.ID)
+}
+
+// runPurge executa o subcomando "purge": remove registros com mais de N dias
+// (padrão: RETENTION_DAYS)
+//
+//	crawler purge --days 365
+func runPurge(args []string) {
+	fs := flag.NewFlagSet("purge", flag.ExitOnError)
+	days := fs.Int("days", 0, "Remover registros com mais de N dias (padrão: RETENTION_DAYS)")
+	fs.Parse(args)
+
+	if *days == 0 {
+		*days, _ = strconv.Atoi(os.Getenv("RETENTION_DAYS"))
+	}
+	if *days <= 0 {
+		log.Fatal("Informe --days ou defina RETENTION_DAYS")
+	}
+
+	mgr, closeStore := openPrivacy()
+	defer closeStore()
+
+	record, err := mgr.Purge(*days, "cli")
+	if err != nil {
+		log.Fatalf("Erro na retenção: %v", err)
+	}
+	log.Printf("✅ %d registros com mais de %d dias removidos %v", record.Total(), *days, record.Removed)
+}
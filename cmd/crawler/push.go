@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/export"
+	"github.com/your-org/linkedin-visible-crawler/internal/push"
+)
+
+// runPush executa o subcomando "push": reenvia ao endpoint de data/push.json
+// os convites registrados no período (útil para carga inicial e para testar
+// a integração contra um servidor local)
+//
+//	crawler push [--since 24h] [--config data/push.json] [--dry-run]
+func runPush(args []string) {
+	fs := flag.NewFlagSet("push", flag.ExitOnError)
+	since := fs.Duration("since", 24*time.Hour, "Convites registrados neste período (0 = todos)")
+	configFile := fs.String("config", push.ConfigFile, "Arquivo de configuração do envio")
+	dryRun := fs.Bool("dry-run", false, "Apenas imprime os lotes, sem enviar")
+	fs.Parse(args)
+
+	cfg, err := push.LoadConfig(*configFile)
+	if err != nil {
+		log.Fatalf("Erro na configuração de envio: %v", err)
+	}
+	if cfg == nil {
+		log.Fatalf("Arquivo %s não encontrado", *configFile)
+	}
+	pusher, err := push.New(*cfg)
+	if err != nil {
+		log.Fatalf("Erro na configuração de envio: %v", err)
+	}
+	pusher.OnLog(func(line string) { log.Println(line) })
+
+	store := openStore()
+	defer store.Close()
+
+	invites, _, err := store.ListInvites(0, math.MaxInt32)
+	if err != nil {
+		log.Fatalf("Erro ao carregar convites: %v", err)
+	}
+	var records []export.Record
+	for _, invite := range invites {
+		if *since > 0 && time.Since(invite.Timestamp) > *since {
+			continue
+		}
+		records = append(records, pusher.Map(invite))
+	}
+	log.Printf("%d convites para %s", len(records), cfg.Describe())
+
+	if *dryRun {
+		for start := 0; start < len(records); start += cfg.BatchSize {
+			end := start + cfg.BatchSize
+			if end > len(records) {
+				end = len(records)
+			}
+			body, _ := pusher.Body(records[start:end])
+			fmt.Println(string(body))
+		}
+		return
+	}
+
+	sent, err := pusher.Send(records)
+	if err != nil {
+		log.Fatalf("Envio concluído com falhas: %d de %d enviados (%v)", sent, len(records), err)
+	}
+	log.Printf("✅ %d convites enviados", sent)
+}
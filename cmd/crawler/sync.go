@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/maintenance"
+)
+
+// runSync executa o subcomando "sync": atualiza o status dos convites
+// (pendente, aceito, expirado) a partir das conexões e convites enviados
+//
+//	crawler sync [--max-connections 300]
+func runSync(args []string) {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	maxConnections := fs.Int("max-connections", 200, "Conexões recentes a ler")
+	headless := fs.Bool("headless", true, "Executar em modo headless")
+	fs.Parse(args)
+
+	email := os.Getenv("LINKEDIN_EMAIL")
+	password := os.Getenv("LINKEDIN_PASSWORD")
+	if email == "" || password == "" {
+		log.Fatal("LINKEDIN_EMAIL e LINKEDIN_PASSWORD devem estar definidos (env/.env)")
+	}
+
+	opts := maintenance.SyncOptions{
+		MaxConnections: *maxConnections,
+		Headless:       *headless,
+	}
+
+	store := openStore()
+	defer store.Close()
+
+	summary, err := maintenance.SyncStatuses(store, opts, crawler.Creds{Email: email, Password: password}, func(line string) {
+		log.Println(line)
+	})
+	if err != nil {
+		log.Fatalf("Erro ao sincronizar convites: %v", err)
+	}
+
+	log.Printf("✅ Sincronização concluída: %s", summary)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/maintenance"
+)
+
+// runWithdraw executa o subcomando "withdraw": retira convites pendentes antigos
+//
+//	crawler withdraw --older-than 21d [--query vendas] [--company boticario]
+func runWithdraw(args []string) {
+	fs := flag.NewFlagSet("withdraw", flag.ExitOnError)
+	olderThan := fs.String("older-than", "21d", "Idade mínima do convite pendente (ex.: 21d, 3w)")
+	query := fs.String("query", "", "Somente convites registrados com query contendo este texto")
+	company := fs.String("company", "", "Somente convites para esta empresa")
+	maxWithdrawals := fs.Int("max", 0, "Máximo de convites retirados (0 = sem limite)")
+	headless := fs.Bool("headless", true, "Executar em modo headless")
+	fs.Parse(args)
+
+	age, err := maintenance.ParseAge(*olderThan)
+	if err != nil {
+		log.Fatalf("--older-than inválido: %v", err)
+	}
+
+	email := os.Getenv("LINKEDIN_EMAIL")
+	password := os.Getenv("LINKEDIN_PASSWORD")
+	if email == "" || password == "" {
+		log.Fatal("LINKEDIN_EMAIL e LINKEDIN_PASSWORD devem estar definidos (env/.env)")
+	}
+
+	opts := maintenance.WithdrawOptions{
+		OlderThan:      age,
+		Query:          *query,
+		Company:        *company,
+		MaxWithdrawals: *maxWithdrawals,
+		Headless:       *headless,
+	}
+
+	store := openStore()
+	defer store.Close()
+
+	n, err := maintenance.Withdraw(store, opts, crawler.Creds{Email: email, Password: password}, func(line string) {
+		log.Println(line)
+	})
+	if err != nil {
+		log.Fatalf("Erro ao retirar convites: %v", err)
+	}
+
+	log.Printf("✅ %d convites retirados", n)
+}
@@ -3,13 +3,22 @@ package main
 import (
 	"log"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"github.com/your-org/linkedin-visible-crawler/internal/http"
+	"github.com/your-org/linkedin-visible-crawler/internal/orchestrator"
+	"github.com/your-org/linkedin-visible-crawler/internal/privacy"
+	"github.com/your-org/linkedin-visible-crawler/internal/push"
+	"github.com/your-org/linkedin-visible-crawler/internal/scheduler"
+	"github.com/your-org/linkedin-visible-crawler/internal/sequences"
 	"github.com/your-org/linkedin-visible-crawler/internal/storage"
 	"github.com/your-org/linkedin-visible-crawler/internal/ui"
+	"github.com/your-org/linkedin-visible-crawler/internal/webhooks"
 )
 
 func main() {
@@ -36,19 +45,110 @@ func main() {
 	sseBroker.Start()
 	log.Println("✅ SSE Broker iniciado")
 
-	// Storage
-	inviteStorage := storage.NewInviteStorage()
-	weeklyCounter := storage.NewWeeklyCounter(inviteStorage)
-	log.Println("✅ Storage inicializado")
+	// Storage (STORAGE_BACKEND=csv|sqlite)
+	backend := os.Getenv("STORAGE_BACKEND")
+	if backend == "" {
+		backend = storage.BackendCSV
+	}
+	store, err := storage.Open(backend, os.Getenv("SQLITE_PATH"))
+	if err != nil {
+		log.Fatalf("❌ Erro ao abrir armazenamento: %v", err)
+	}
+	defer store.Close()
+	limits, err := storage.NewLimits()
+	if err != nil {
+		log.Fatalf("❌ Erro ao carregar limites de convites: %v", err)
+	}
+	weeklyCounter, err := storage.NewWeeklyCounter(store, limits)
+	if err != nil {
+		log.Fatalf("❌ Erro ao carregar contador semanal: %v", err)
+	}
+	quota := storage.NewQuota(weeklyCounter)
+	querySets := storage.NewQuerySets()
+	messageLog := storage.NewMessageLog()
+	log.Printf("✅ Storage inicializado (%s)", backend)
 
 	// Session Store
 	sessionStore := http.NewSessionStore()
 	log.Println("✅ Session Store inicializado")
 
+	// Orquestrador (limite de processos do Chrome no host)
+	maxChrome, _ := strconv.Atoi(os.Getenv("MAX_CHROME"))
+	if maxChrome <= 0 {
+		maxChrome = 2
+	}
+	orch := orchestrator.New(maxChrome)
+	orch.OnChange(sseBroker.PublishJobs)
+	log.Printf("✅ Orquestrador inicializado (máx. %d navegadores)", maxChrome)
+
+	// Agendador
+	sched, err := scheduler.New(weeklyCounter)
+	if err != nil {
+		log.Fatalf("❌ Erro ao carregar agendamentos: %v", err)
+	}
+
+	// Sequências de follow-up
+	seqs, err := sequences.New()
+	if err != nil {
+		log.Fatalf("❌ Erro ao carregar sequências: %v", err)
+	}
+
+	// Webhooks de eventos (data/webhooks.json)
+	hooks, err := webhooks.New()
+	if err != nil {
+		log.Fatalf("❌ Erro ao carregar webhooks: %v", err)
+	}
+
+	// Privacidade: pedidos de acesso/remoção e retenção (RETENTION_DAYS, 0 = desativada)
+	priv := privacy.New(store, messageLog, seqs)
+	retentionDays, _ := strconv.Atoi(os.Getenv("RETENTION_DAYS"))
+	priv.StartRetention(retentionDays, func(line string) {
+		log.Println(line)
+		sseBroker.PublishLog(line)
+	})
+	if retentionDays > 0 {
+		log.Printf("✅ Retenção de dados: %d dias", retentionDays)
+	}
+
 	// Handlers
-	handlers := http.NewHandlers(templates, sseBroker, inviteStorage, weeklyCounter, sessionStore)
+	handlers := http.NewHandlers(templates, sseBroker, store, weeklyCounter, quota, sessionStore, orch, sched, querySets, seqs, messageLog, hooks, priv)
 	log.Println("✅ Handlers inicializados")
 
+	// Envio de convites para CRM/endpoint HTTP (opcional)
+	pushCfg, err := push.LoadConfig(push.ConfigFile)
+	if err != nil {
+		log.Fatalf("❌ Erro na configuração de envio de convites: %v", err)
+	}
+	if pushCfg != nil && pushCfg.Enabled {
+		pusher, err := push.New(*pushCfg)
+		if err != nil {
+			log.Fatalf("❌ Erro na configuração de envio de convites: %v", err)
+		}
+		pusher.OnLog(sseBroker.PublishLog)
+		pusher.Start()
+		handlers.SetPusher(pusher)
+		log.Printf("✅ Envio de convites ativo: %s", pushCfg.Describe())
+
+		// Ao encerrar (Ctrl+C ou SIGTERM), a fila é enviada; o que não for
+		// entregue vai para data/push_failed.jsonl
+		go func() {
+			sig := make(chan os.Signal, 1)
+			signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+			<-sig
+			log.Println("🛑 Encerrando: enviando convites na fila...")
+			pusher.Stop()
+			os.Exit(0)
+		}()
+	}
+
+	sched.SetLauncher(handlers.LaunchScheduled)
+	sched.OnLog(sseBroker.PublishLog)
+	sched.OnLimitReached(func(account string, count int) {
+		handlers.EmitLimitReached(account, count, "schedule")
+	})
+	sched.Start()
+	log.Println("✅ Agendador iniciado")
+
 	// Configurar Gin
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
@@ -74,9 +174,63 @@ func main() {
 	// Execução do crawler
 	router.POST("/run", handlers.RunCrawler)
 
-	// Listagem e exportação de convites
+	// Painel de execuções
+	router.GET("/jobs", handlers.ListJobs)
+
+	// Histórico e relatórios de execuções
+	router.GET("/runs", handlers.ListRuns)
+	router.GET("/runs/:id/report", handlers.RunReport)
+	router.GET("/runs/:id/report.json", handlers.RunReportJSON)
+
+	// Agendamentos
+	router.GET("/schedules", handlers.ListSchedules)
+	router.POST("/schedules", handlers.CreateSchedule)
+	router.POST("/schedules/:id/pause", handlers.PauseSchedule)
+	router.POST("/schedules/:id/resume", handlers.ResumeSchedule)
+	router.DELETE("/schedules/:id", handlers.DeleteSchedule)
+
+	// Pontuação de leads
+	router.GET("/scoring", handlers.GetScoring)
+	router.POST("/scoring", handlers.SaveScoring)
+
+	// Sequências de follow-up pós-aceitação
+	router.GET("/sequences", handlers.ListSequences)
+	router.POST("/sequences", handlers.CreateSequence)
+	router.POST("/sequences/run", handlers.RunFollowUps)
+	router.POST("/sequences/:id/pause", handlers.PauseSequence)
+	router.POST("/sequences/:id/resume", handlers.ResumeSequence)
+	router.DELETE("/sequences/:id", handlers.DeleteSequence)
+
+	// Limites de convites por conta
+	router.GET("/limits", handlers.ListLimits)
+	router.POST("/limits", handlers.SaveLimits)
+	router.DELETE("/limits/:account", handlers.DeleteLimits)
+
+	// Webhooks
+	router.GET("/webhooks", handlers.ListWebhooks)
+	router.POST("/webhooks", handlers.CreateWebhook)
+	router.POST("/webhooks/:id/pause", handlers.PauseWebhook)
+	router.POST("/webhooks/:id/resume", handlers.ResumeWebhook)
+	router.DELETE("/webhooks/:id", handlers.DeleteWebhook)
+
+	// Privacidade
+	router.GET("/privacy", handlers.ListPrivacy)
+	router.GET("/privacy/export", handlers.ExportSubject)
+	router.POST("/privacy/erase", handlers.EraseSubject)
+	router.POST("/privacy/purge", handlers.PurgeRetention)
+
+	// Manutenção de convites pendentes
+	router.POST("/maintenance/withdraw", handlers.WithdrawInvites)
+	router.POST("/maintenance/sync", handlers.SyncInvites)
+
+	// Listagem e exportação de convites e contatos
 	router.GET("/invites", handlers.ListInvites)
-	router.GET("/export/invites.csv", handlers.ExportInvitesCSV)
+	router.GET("/contacts", handlers.ListContacts)
+	router.GET("/export/:file", handlers.Export) // invites.csv, contacts.xlsx, ...
+
+	// Analytics do funil
+	router.GET("/analytics", handlers.Analytics)
+	router.GET("/analytics/:file", handlers.ExportAnalyticsCSV)
 
 	// Métricas
 	router.GET("/metrics", handlers.GetMetrics)
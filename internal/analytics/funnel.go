@@ -0,0 +1,371 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+// Dimensões de agrupamento do funil
+const (
+	DimQuery    = "query"
+	DimAccount  = "account"
+	DimCompany  = "company"
+	DimTitle    = "title"
+	DimLocation = "location"
+	DimCountry  = "country"
+	DimRegion   = "region"
+	DimWeekday  = "weekday"
+	DimHour     = "hour"
+)
+
+// Dimensions ordem e título das tabelas do relatório
+var Dimensions = []struct {
+	ID    string
+	Title string
+}{
+	{DimQuery, "Por query"},
+	{DimAccount, "Por conta"},
+	{DimCompany, "Por empresa"},
+	{DimTitle, "Por palavra do cargo"},
+	{DimLocation, "Por localização"},
+	{DimCountry, "Por país"},
+	{DimRegion, "Por estado/região"},
+	{DimWeekday, "Por dia da semana"},
+	{DimHour, "Por hora do dia"},
+}
+
+// maxRows linhas por tabela (as de maior volume)
+const maxRows = 50
+
+// weekdays nomes dos dias da semana na ordem de time.Weekday
+var weekdays = []string{"Domingo", "Segunda", "Terça", "Quarta", "Quinta", "Sexta", "Sábado"}
+
+// titleStopwords palavras ignoradas ao quebrar cargos em palavras-chave
+var titleStopwords = map[string]bool{
+	"de": true, "da": true, "do": true, "das": true, "dos": true, "em": true, "na": true, "no": true,
+	"e": true, "a": true, "o": true, "para": true, "com": true, "and": true, "of": true, "at": true,
+	"the": true, "for": true, "in": true, "on": true, "&": true, "-": true, "|": true, "·": true,
+}
+
+// Range intervalo de datas do relatório (zero = sem limite)
+type Range struct {
+	From time.Time
+	To   time.Time
+}
+
+// Contains indica se t está dentro do intervalo
+func (r Range) Contains(t time.Time) bool {
+	if !r.From.IsZero() && t.Before(r.From) {
+		return false
+	}
+	if !r.To.IsZero() && !t.Before(r.To) {
+		return false
+	}
+	return true
+}
+
+// Row contagens do funil de um grupo
+type Row struct {
+	Key      string
+	Captured int
+	Invited  int
+	Accepted int
+	Replied  int
+}
+
+// InviteRate convidados / capturados (%)
+func (r Row) InviteRate() float64 { return rate(r.Invited, r.Captured) }
+
+// AcceptRate aceitos / convidados (%)
+func (r Row) AcceptRate() float64 { return rate(r.Accepted, r.Invited) }
+
+// ReplyRate responderam / aceitos (%)
+func (r Row) ReplyRate() float64 { return rate(r.Replied, r.Accepted) }
+
+func rate(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(d)
+}
+
+// Table tabela do funil agrupada por uma dimensão
+type Table struct {
+	ID    string
+	Title string
+	Rows  []Row
+}
+
+// Report funil completo no intervalo
+type Report struct {
+	Range  Range
+	Totals Row
+	Tables []Table
+}
+
+// Table retorna a tabela da dimensão
+func (r Report) Table(id string) (Table, bool) {
+	for _, t := range r.Tables {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return Table{}, false
+}
+
+// contact estágio do funil de um perfil para uma conta
+type contact struct {
+	account  string
+	at       time.Time // primeiro evento (captura ou convite)
+	query    string
+	company  string
+	title    string
+	location string
+	country  string
+	region   string // "SP, BR"
+	captured bool
+	invited  bool
+	accepted bool
+	replied  bool
+}
+
+// Build monta o funil (capturados → convidados → aceitos → responderam) a
+// partir das capturas, convites e mensagens. Cada perfil conta uma vez por
+// conta; o intervalo filtra pela data do primeiro evento do perfil. Dia da
+// semana e hora usam o fuso de cada conta (zone nil = fuso do servidor).
+func Build(captures []crawler.CaptureRecord, invites []crawler.InviteRecord, messages []crawler.MessageRecord, r Range, zone func(account string) *time.Location) Report {
+	zones := map[string]*time.Location{}
+	zoneOf := func(account string) *time.Location {
+		loc, ok := zones[account]
+		if !ok {
+			loc = time.Local
+			if zone != nil {
+				loc = zone(account)
+			}
+			zones[account] = loc
+		}
+		return loc
+	}
+
+	contacts := map[string]*contact{}
+	get := func(account, url string, at time.Time) *contact {
+		key := strings.ToLower(account) + "|" + strings.ToLower(crawler.NormalizeProfileURL(url))
+		c, ok := contacts[key]
+		if !ok {
+			c = &contact{account: strings.ToLower(account), at: at}
+			contacts[key] = c
+		}
+		if at.Before(c.at) {
+			c.at = at
+		}
+		return c
+	}
+
+	for _, capture := range captures {
+		if capture.LinkedIn == "" {
+			continue
+		}
+		c := get(capture.UserEmail, capture.LinkedIn, capture.Timestamp)
+		c.captured = true
+		fill(c, capture.Query, capture.Company, capture.Title, capture.Location)
+		fillPlace(c, capture.Region, capture.Country)
+	}
+
+	for _, invite := range invites {
+		if invite.LinkedInURL == "" {
+			continue
+		}
+		c := get(invite.UserEmail, invite.LinkedInURL, invite.Timestamp)
+		c.captured = true // todo convidado foi capturado
+		c.invited = true
+		if invite.Status == crawler.InviteStatusAccepted {
+			c.accepted = true
+		}
+		// O convite é a fonte mais confiável dos atributos do perfil
+		c.query, c.company, c.title, c.location = "", "", "", ""
+		fill(c, invite.Query, invite.Company, invite.ProfileTitle, invite.Location)
+		fillPlace(c, invite.Region, invite.Country)
+	}
+
+	for _, msg := range messages {
+		if msg.Status != crawler.MessageStatusReplied {
+			continue
+		}
+		key := strings.ToLower(msg.UserEmail) + "|" + strings.ToLower(crawler.NormalizeProfileURL(msg.LinkedInURL))
+		if c, ok := contacts[key]; ok {
+			c.replied = true
+			c.accepted = true
+		}
+	}
+
+	groups := map[string]map[string]*Row{}
+	for _, d := range Dimensions {
+		groups[d.ID] = map[string]*Row{}
+	}
+
+	report := Report{Range: r}
+	for _, c := range contacts {
+		if !r.Contains(c.at) {
+			continue
+		}
+		add(&report.Totals, c)
+
+		at := c.at.In(zoneOf(c.account))
+		keys := map[string][]string{
+			DimQuery:    {orNone(c.query)},
+			DimAccount:  {orNone(c.account)},
+			DimCompany:  {orNone(c.company)},
+			DimTitle:    TitleKeywords(c.title),
+			DimLocation: {orNone(c.location)},
+			DimCountry:  {orNone(c.country)},
+			DimRegion:   {orNone(c.region)},
+			DimWeekday:  {weekdays[at.Weekday()]},
+			DimHour:     {fmt.Sprintf("%02dh", at.Hour())},
+		}
+		for dim, values := range keys {
+			for _, v := range values {
+				row, ok := groups[dim][v]
+				if !ok {
+					row = &Row{Key: v}
+					groups[dim][v] = row
+				}
+				add(row, c)
+			}
+		}
+	}
+
+	for _, d := range Dimensions {
+		rows := make([]Row, 0, len(groups[d.ID]))
+		for _, row := range groups[d.ID] {
+			rows = append(rows, *row)
+		}
+		sortRows(d.ID, rows)
+		if len(rows) > maxRows && d.ID != DimWeekday && d.ID != DimHour {
+			rows = rows[:maxRows]
+		}
+		report.Tables = append(report.Tables, Table{ID: d.ID, Title: d.Title, Rows: rows})
+	}
+	return report
+}
+
+// TitleKeywords quebra o cargo em palavras-chave (minúsculas, sem stopwords)
+func TitleKeywords(title string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return r == ' ' || r == ',' || r == '|' || r == '/' || r == '(' || r == ')' || r == '·'
+	}) {
+		word = strings.Trim(word, ".:;!?\"'")
+		if len([]rune(word)) < 2 || titleStopwords[word] || seen[word] {
+			continue
+		}
+		seen[word] = true
+		out = append(out, word)
+	}
+	if len(out) == 0 {
+		return []string{orNone("")}
+	}
+	return out
+}
+
+// WriteCSV grava a tabela em CSV (uma linha por grupo, com taxas)
+func (t Table) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{t.ID, "captured", "invited", "accepted", "replied", "invite_rate", "accept_rate", "reply_rate"})
+	for _, row := range t.Rows {
+		writer.Write([]string{
+			row.Key,
+			fmt.Sprint(row.Captured),
+			fmt.Sprint(row.Invited),
+			fmt.Sprint(row.Accepted),
+			fmt.Sprint(row.Replied),
+			fmt.Sprintf("%.1f", row.InviteRate()),
+			fmt.Sprintf("%.1f", row.AcceptRate()),
+			fmt.Sprintf("%.1f", row.ReplyRate()),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// fill preenche atributos ainda vazios do contato
+func fill(c *contact, query, company, title, location string) {
+	if c.query == "" {
+		c.query = query
+	}
+	if c.company == "" {
+		c.company = company
+	}
+	if c.title == "" {
+		c.title = title
+	}
+	if c.location == "" {
+		c.location = location
+	}
+}
+
+// fillPlace preenche país e estado/região ainda vazios do contato
+func fillPlace(c *contact, region, country string) {
+	if c.country == "" {
+		c.country = country
+	}
+	if c.region == "" && region != "" {
+		c.region = region
+		if country != "" {
+			c.region += ", " + country
+		}
+	}
+}
+
+// add soma o estágio do contato à linha
+func add(row *Row, c *contact) {
+	if c.captured {
+		row.Captured++
+	}
+	if c.invited {
+		row.Invited++
+	}
+	if c.accepted {
+		row.Accepted++
+	}
+	if c.replied {
+		row.Replied++
+	}
+}
+
+// sortRows ordena dias/horas cronologicamente e demais dimensões por volume
+func sortRows(dim string, rows []Row) {
+	switch dim {
+	case DimWeekday:
+		order := map[string]int{}
+		for i, name := range weekdays {
+			order[name] = i
+		}
+		sort.Slice(rows, func(i, j int) bool { return order[rows[i].Key] < order[rows[j].Key] })
+	case DimHour:
+		sort.Slice(rows, func(i, j int) bool { return rows[i].Key < rows[j].Key })
+	default:
+		sort.Slice(rows, func(i, j int) bool {
+			if rows[i].Captured != rows[j].Captured {
+				return rows[i].Captured > rows[j].Captured
+			}
+			if rows[i].Invited != rows[j].Invited {
+				return rows[i].Invited > rows[j].Invited
+			}
+			return rows[i].Key < rows[j].Key
+		})
+	}
+}
+
+func orNone(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "(sem valor)"
+	}
+	return s
+}
@@ -0,0 +1,101 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+func TestBuild(t *testing.T) {
+	utc := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2026, month, day, hour, min, 0, 0, time.UTC)
+	}
+	const (
+		contaSP    = "sp@empresa.com"
+		contaTokyo = "tokyo@empresa.com"
+		ana        = "https://www.linkedin.com/in/ana"
+		bruno      = "https://www.linkedin.com/in/bruno"
+		carla      = "https://www.linkedin.com/in/carla"
+	)
+
+	captures := []crawler.CaptureRecord{
+		// domingo 23h em São Paulo (segunda 02h UTC)
+		{Timestamp: utc(10, 5, 2, 0), UserEmail: contaSP, Contact: crawler.Contact{
+			LinkedIn: ana, Query: "cto", Company: "Acme", Title: "CTO", Region: "SP", Country: "BR"}},
+		// terça 09h em São Paulo
+		{Timestamp: utc(10, 6, 12, 0), UserEmail: "SP@Empresa.com", Contact: crawler.Contact{
+			LinkedIn: bruno, Query: "vendas", Company: "Beta", Title: "Gerente de Vendas"}},
+		// fora do intervalo
+		{Timestamp: utc(9, 1, 12, 0), UserEmail: contaSP, Contact: crawler.Contact{LinkedIn: carla, Query: "cto"}},
+	}
+	invites := []crawler.InviteRecord{
+		{Timestamp: utc(10, 5, 3, 0), UserEmail: contaSP, LinkedInURL: ana + "/", Query: "cto", Company: "Acme",
+			ProfileTitle: "CTO", Region: "SP", Country: "BR", Status: crawler.InviteStatusAccepted},
+		// terça 09h30 em Tóquio; o mesmo perfil conta de novo para outra conta
+		{Timestamp: utc(10, 6, 0, 30), UserEmail: contaTokyo, LinkedInURL: ana, Query: "cto", Company: "Acme",
+			ProfileTitle: "CTO", Status: crawler.InviteStatusPending},
+	}
+	messages := []crawler.MessageRecord{
+		{Timestamp: utc(10, 8, 10, 0), UserEmail: contaSP, LinkedInURL: ana, Status: crawler.MessageStatusReplied},
+	}
+
+	zones := map[string]string{contaSP: "America/Sao_Paulo", contaTokyo: "Asia/Tokyo"}
+	zone := func(account string) *time.Location {
+		loc, err := time.LoadLocation(zones[account])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return loc
+	}
+	report := Build(captures, invites, messages, Range{From: utc(10, 1, 0, 0), To: utc(11, 1, 0, 0)}, zone)
+
+	if want := (Row{Captured: 3, Invited: 2, Accepted: 1, Replied: 1}); report.Totals != want {
+		t.Errorf("Totals = %+v, esperado %+v", report.Totals, want)
+	}
+
+	tests := []struct {
+		dim  string
+		key  string
+		want Row
+	}{
+		{DimQuery, "cto", Row{Captured: 2, Invited: 2, Accepted: 1, Replied: 1}},
+		{DimQuery, "vendas", Row{Captured: 1}},
+		{DimAccount, contaSP, Row{Captured: 2, Invited: 1, Accepted: 1, Replied: 1}},
+		{DimAccount, contaTokyo, Row{Captured: 1, Invited: 1}},
+		{DimCompany, "Acme", Row{Captured: 2, Invited: 2, Accepted: 1, Replied: 1}},
+		{DimTitle, "gerente", Row{Captured: 1}},
+		{DimTitle, "vendas", Row{Captured: 1}},
+		{DimCountry, "BR", Row{Captured: 1, Invited: 1, Accepted: 1, Replied: 1}},
+		{DimRegion, "SP, BR", Row{Captured: 1, Invited: 1, Accepted: 1, Replied: 1}},
+		{DimRegion, "(sem valor)", Row{Captured: 2, Invited: 1}},
+		{DimWeekday, "Domingo", Row{Captured: 1, Invited: 1, Accepted: 1, Replied: 1}},
+		{DimWeekday, "Terça", Row{Captured: 2, Invited: 1}},
+		{DimHour, "23h", Row{Captured: 1, Invited: 1, Accepted: 1, Replied: 1}},
+		{DimHour, "09h", Row{Captured: 2, Invited: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dim+"/"+tt.key, func(t *testing.T) {
+			table, ok := report.Table(tt.dim)
+			if !ok {
+				t.Fatalf("tabela %s ausente", tt.dim)
+			}
+			for _, row := range table.Rows {
+				if row.Key == tt.key {
+					tt.want.Key = tt.key
+					if row != tt.want {
+						t.Errorf("%s[%s] = %+v, esperado %+v", tt.dim, tt.key, row, tt.want)
+					}
+					return
+				}
+			}
+			t.Errorf("%s sem linha %q (linhas: %+v)", tt.dim, tt.key, table.Rows)
+		})
+	}
+
+	if table, _ := report.Table(DimWeekday); len(table.Rows) != 2 {
+		t.Errorf("dias da semana = %+v, esperado só Domingo e Terça", table.Rows)
+	}
+}
@@ -0,0 +1,222 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// maxShowMoreStalls quantas rodadas sem novos cards encerram a rolagem
+const maxShowMoreStalls = 3
+
+// ParseCompany extrai o slug da empresa de uma URL (linkedin.com/company/<slug>/...)
+// ou aceita o próprio slug
+func ParseCompany(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("empresa não informada")
+	}
+
+	if !strings.Contains(input, "/") {
+		return strings.ToLower(input), nil
+	}
+
+	raw := input
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("URL de empresa inválida: %v", err)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != "company" || parts[1] == "" {
+		return "", fmt.Errorf("URL não é uma página de empresa do LinkedIn: %s", input)
+	}
+	return strings.ToLower(parts[1]), nil
+}
+
+// CompanyPeopleURL monta a URL da aba Pessoas com o filtro de cargo/palavra-chave
+func CompanyPeopleURL(slug, keywords string) string {
+	peopleURL := "https://www.linkedin.com/company/" + url.PathEscape(slug) + "/people/"
+	if keywords = strings.TrimSpace(keywords); keywords != "" {
+		peopleURL += "?keywords=" + url.QueryEscape(keywords)
+	}
+	return peopleURL
+}
+
+// runCompany abre a aba Pessoas da empresa, aplica os filtros, carrega
+// resultados com "Exibir mais resultados" até o limite e captura/conecta
+func (e *Engine) runCompany(ctx context.Context, cfg RunConfig, callbacks Callbacks) error {
+	slug, err := ParseCompany(cfg.Company)
+	if err != nil {
+		return err
+	}
+
+	peopleURL := CompanyPeopleURL(slug, cfg.CompanyKeywords)
+	callbacks.OnLog(fmt.Sprintf("=== Empresa: %s ===", slug))
+	callbacks.OnLog(fmt.Sprintf("Abrindo aba Pessoas: %s", peopleURL))
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(peopleURL)); err != nil {
+		return err
+	}
+	if err := chromedp.Run(ctx, chromedp.WaitReady("main")); err != nil {
+		return err
+	}
+	time.Sleep(2 * time.Second)
+
+	// Nome de exibição da empresa (cabeçalho da página); fallback para o slug
+	companyName := slug
+	var header string
+	if err := chromedp.Run(ctx, chromedp.Evaluate(`(document.querySelector('main h1') || {innerText: ''}).innerText.trim()`, &header)); err == nil && header != "" {
+		companyName = header
+	}
+
+	if cfg.CompanyLocation != "" {
+		if e.applyCompanyLocation(ctx, cfg.CompanyLocation) {
+			callbacks.OnLog(fmt.Sprintf("Filtro de localização aplicado: %s", cfg.CompanyLocation))
+			time.Sleep(2 * time.Second)
+		} else {
+			e.warn(callbacks, fmt.Sprintf("Aviso: localização '%s' não encontrada nos filtros da aba Pessoas", cfg.CompanyLocation))
+		}
+	}
+
+	limit := cfg.MaxCardsRead
+	if limit <= 0 {
+		limit = 60
+	}
+	count := e.loadMoreResults(ctx, SelCompanyPeopleCard, limit, callbacks.OnLog)
+	callbacks.OnLog(fmt.Sprintf("Encontrados %d perfis na aba Pessoas", count))
+	e.stats.cards(slug, min(count, limit))
+
+	contacts, invitesSent, err := e.captureCompanyPeople(ctx, slug, companyName, limit, cfg, callbacks)
+	if err != nil {
+		e.stats.fail(slug, err)
+		return err
+	}
+
+	callbacks.OnLog(fmt.Sprintf("Capturados %d perfis de '%s'", len(contacts), slug))
+	callbacks.OnLog(fmt.Sprintf("Convites enviados: %d", invitesSent))
+	return nil
+}
+
+// applyCompanyLocation clica no filtro "Onde moram"/"Where they live" cujo texto
+// contém a localização informada
+func (e *Engine) applyCompanyLocation(ctx context.Context, location string) bool {
+	var applied bool
+	err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(() => {
+			const wanted = "%s".toLowerCase();
+			const buttons = document.querySelectorAll('main button');
+			for (const btn of buttons) {
+				const text = btn.innerText.toLowerCase();
+				if (text.includes(wanted) && /\d/.test(text)) {
+					btn.click();
+					return true;
+				}
+			}
+			return false;
+		})()
+	`, jsString(location)), &applied))
+	return err == nil && applied
+}
+
+// loadMoreResults rola a página e clica em "Exibir mais resultados" até
+// atingir o limite de cards (cardSelector) ou parar de carregar novos
+func (e *Engine) loadMoreResults(ctx context.Context, cardSelector string, limit int, logf func(string)) int {
+	count, stalls := 0, 0
+	for stalls < maxShowMoreStalls {
+		var current int
+		if err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`document.querySelectorAll('%s').length`, cardSelector), &current)); err != nil {
+			logf(fmt.Sprintf("Erro ao contar perfis: %v", err))
+			return count
+		}
+		if current >= limit {
+			return current
+		}
+		if current > count {
+			count, stalls = current, 0
+		} else {
+			stalls++
+		}
+
+		var clicked bool
+		_ = chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`
+			(() => {
+				window.scrollTo(0, document.body.scrollHeight);
+				for (const btn of document.querySelectorAll('main button')) {
+					if (/%s/i.test(btn.innerText.trim())) {
+						btn.click();
+						return true;
+					}
+				}
+				return false;
+			})()
+		`, strings.Join(RxShowMoreLabels, "|")), &clicked))
+
+		time.Sleep(time.Duration(1200+time.Now().UnixNano()%800) * time.Millisecond)
+	}
+	return count
+}
+
+// captureCompanyPeople captura e pontua os cards carregados e conecta os de
+// maior pontuação pelos botões dos cards
+func (e *Engine) captureCompanyPeople(ctx context.Context, slug, companyName string, limit int, cfg RunConfig, callbacks Callbacks) ([]Contact, int, error) {
+	var result []map[string]interface{}
+	err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(() => {
+			const cards = document.querySelectorAll('%s');
+			const results = [];
+			for (let i = 0; i < cards.length && results.length < %d; i++) {
+				const card = cards[i];
+				const link = card.querySelector('a[href*="/in/"]');
+				if (!link) continue; // membros fora da rede aparecem sem link
+
+				card.setAttribute('data-sel', 'company-card-' + i);
+				const title = card.querySelector('%s');
+				const sub = card.querySelector('%s');
+				results.push({
+					index: i,
+					name: (title ? title.innerText : link.innerText).trim(),
+					headline: sub ? sub.innerText.trim() : '',
+					linkedin_url: link.href,
+					text: card.innerText
+				});
+			}
+			return results;
+		})()
+	`, SelCompanyPeopleCard, limit, SelCompanyCardTitle, SelCompanyCardSub), &result))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var contacts []Contact
+	var selectors []string
+	for _, raw := range result {
+		contact := Contact{
+			Name:     getString(raw, "name"),
+			Title:    ParseHeadline(getString(raw, "headline"), "").Title,
+			Company:  companyName,
+			Headline: getString(raw, "headline"),
+			LinkedIn: NormalizeProfileURL(getString(raw, "linkedin_url")),
+			Query:    slug,
+			Source:   ModeCompany,
+			Degree:   ParseDegree(getString(raw, "text")),
+			Mutual:   ParseMutual(getString(raw, "text")),
+		}
+		contact.applyName()
+		contact.Score, contact.ScoreRules = cfg.Scoring.Score(contact)
+
+		contacts = append(contacts, contact)
+		selectors = append(selectors, fmt.Sprintf("company-card-%d", int(raw["index"].(float64))))
+		e.capture(callbacks, contact)
+	}
+
+	invitesSent := e.connectRanked(ctx, contacts, selectors, cfg, callbacks)
+	return contacts, invitesSent, nil
+}
@@ -13,6 +13,9 @@ import (
 type Engine struct {
 	ctx    context.Context
 	cancel context.CancelFunc
+
+	invitesSent int           // convites enviados na execução (para RunConfig.MaxInvites)
+	stats       statsRecorder // contadores por query e avisos (relatório da execução)
 }
 
 // NewEngine cria nova instância do motor
@@ -28,9 +31,55 @@ func NewEngine() *Engine {
 func (e *Engine) Run(cfg RunConfig, creds Creds, callbacks Callbacks) error {
 	defer e.cancel()
 
+	taskCtx, closeSession, err := e.startSession(cfg.Headless, creds, callbacks.OnLog)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
+
+	switch cfg.Mode {
+	case ModeProfiles:
+		e.runProfiles(taskCtx, cfg, callbacks)
+	case ModeCompany:
+		if err := e.runCompany(taskCtx, cfg, callbacks); err != nil {
+			return err
+		}
+	default:
+		e.runSearch(taskCtx, cfg, callbacks)
+	}
+
+	return nil
+}
+
+// runSearch processa cada query de busca
+func (e *Engine) runSearch(ctx context.Context, cfg RunConfig, callbacks Callbacks) {
+	for i, query := range cfg.Queries {
+		if e.budgetExhausted(cfg) {
+			callbacks.OnLog(fmt.Sprintf("Limite de %d convites da execução atingido", cfg.MaxInvites))
+			return
+		}
+
+		callbacks.OnLog(fmt.Sprintf("=== Processando query %d/%d: %s ===", i+1, len(cfg.Queries), query))
+
+		if err := e.processQuery(ctx, query, cfg, callbacks); err != nil {
+			e.stats.fail(query, err)
+			e.warn(callbacks, fmt.Sprintf("Erro ao processar query '%s': %v", query, err))
+			continue
+		}
+	}
+}
+
+// budgetExhausted indica se o limite de convites da execução foi atingido
+func (e *Engine) budgetExhausted(cfg RunConfig) bool {
+	return cfg.MaxInvites > 0 && e.invitesSent >= cfg.MaxInvites
+}
+
+// startSession abre o Chrome, faz login, aguarda o 2FA manual e minimiza a
+// janela. Retorna o contexto da aba e a função que encerra o navegador.
+func (e *Engine) startSession(headless bool, creds Creds, logf func(string)) (context.Context, func(), error) {
 	// Configurar chromedp
 	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		chromedp.Flag("headless", cfg.Headless),
+		chromedp.Flag("headless", headless),
 		chromedp.Flag("disable-blink-features", "AutomationControlled"),
 		chromedp.Flag("disable-web-security", true),
 		chromedp.Flag("disable-features", "VizDisplayCompositor"),
@@ -43,47 +92,39 @@ func (e *Engine) Run(cfg RunConfig, creds Creds, callbacks Callbacks) error {
 		chromedp.UserAgent("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"),
 	)
 
-	allocCtx, cancel := chromedp.NewExecAllocator(e.ctx, opts...)
-	defer cancel()
-
-	taskCtx, cancel := chromedp.NewContext(allocCtx)
-	defer cancel()
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(e.ctx, opts...)
+	taskCtx, cancelTask := chromedp.NewContext(allocCtx)
+	closeSession := func() {
+		cancelTask()
+		cancelAlloc()
+	}
 
 	// Login no LinkedIn
-	if err := e.login(taskCtx, creds, callbacks); err != nil {
-		return err
+	if err := e.login(taskCtx, creds, logf); err != nil {
+		closeSession()
+		return nil, nil, err
 	}
 
 	// Aguardar 2FA manual
-	callbacks.OnLog("Aguardando 2FA manual... (8 segundos)")
+	logf("Aguardando 2FA manual... (8 segundos)")
 	time.Sleep(8 * time.Second)
 
 	// Minimizar/ocultar navegador após 2FA (apenas se não estiver em modo headless)
-	if !cfg.Headless {
-		callbacks.OnLog("Minimizando navegador para execução em background...")
+	if !headless {
+		logf("Minimizando navegador para execução em background...")
 		if err := e.minimizeBrowser(taskCtx); err != nil {
-			callbacks.OnLog("Aviso: não foi possível minimizar o navegador")
+			logf("Aviso: não foi possível minimizar o navegador")
 		}
 	} else {
-		callbacks.OnLog("Modo headless ativo - navegador já está oculto")
+		logf("Modo headless ativo - navegador já está oculto")
 	}
 
-	// Processar cada query
-	for i, query := range cfg.Queries {
-		callbacks.OnLog(fmt.Sprintf("=== Processando query %d/%d: %s ===", i+1, len(cfg.Queries), query))
-
-		if err := e.processQuery(taskCtx, query, cfg, callbacks); err != nil {
-			callbacks.OnLog(fmt.Sprintf("Erro ao processar query '%s': %v", query, err))
-			continue
-		}
-	}
-
-	return nil
+	return taskCtx, closeSession, nil
 }
 
 // login realiza login no LinkedIn
-func (e *Engine) login(ctx context.Context, creds Creds, callbacks Callbacks) error {
-	callbacks.OnLog("Fazendo login no LinkedIn...")
+func (e *Engine) login(ctx context.Context, creds Creds, logf func(string)) error {
+	logf("Fazendo login no LinkedIn...")
 
 	// Navegar para página de login
 	if err := chromedp.Run(ctx, chromedp.Navigate("https://www.linkedin.com/login")); err != nil {
@@ -111,23 +152,57 @@ func (e *Engine) login(ctx context.Context, creds Creds, callbacks Callbacks) er
 	// Aguardar redirecionamento
 	time.Sleep(3 * time.Second)
 
-	callbacks.OnLog("Login realizado com sucesso")
+	logf("Login realizado com sucesso")
 	return nil
 }
 
-// processQuery processa uma query específica
+// processQuery processa uma query específica, página a página
 func (e *Engine) processQuery(ctx context.Context, query string, cfg RunConfig, callbacks Callbacks) error {
-	callbacks.OnLog(fmt.Sprintf("Abrindo busca: %s", query))
+	maxPages := cfg.MaxPages
+	if maxPages <= 0 {
+		maxPages = 1
+	}
+
+	totalContacts, totalInvites := 0, 0
+	for page := 1; page <= maxPages; page++ {
+		contacts, invitesSent, err := e.processPage(ctx, query, page, cfg, callbacks)
+		if err != nil {
+			return err
+		}
+		totalContacts += len(contacts)
+		totalInvites += invitesSent
 
-	// Navegar para busca
-	searchURL := "https://www.linkedin.com/search/results/people/?keywords=" + strings.ReplaceAll(query, " ", "+") + "&origin=CLUSTER_EXPANSION"
+		if len(contacts) == 0 {
+			if page > 1 {
+				callbacks.OnLog(fmt.Sprintf("Página %d sem resultados, encerrando paginação", page))
+			}
+			break
+		}
+	}
+
+	callbacks.OnLog(fmt.Sprintf("Capturados %d perfis para '%s'", totalContacts, query))
+	callbacks.OnLog(fmt.Sprintf("Convites enviados: %d", totalInvites))
+
+	return nil
+}
+
+// processPage abre uma página de resultados da query e captura/conecta os perfis
+func (e *Engine) processPage(ctx context.Context, query string, page int, cfg RunConfig, callbacks Callbacks) ([]Contact, int, error) {
+	if page == 1 {
+		callbacks.OnLog(fmt.Sprintf("Abrindo busca: %s", query))
+	} else {
+		callbacks.OnLog(fmt.Sprintf("Abrindo página %d da busca", page))
+	}
+
+	// Navegar para busca (palavras-chave ou URL completa, com paginação)
+	searchURL := BuildSearchURL(query, page)
 	if err := chromedp.Run(ctx, chromedp.Navigate(searchURL)); err != nil {
-		return err
+		return nil, 0, err
 	}
 
 	// Aguardar página carregar
 	if err := chromedp.Run(ctx, chromedp.WaitReady("main")); err != nil {
-		return err
+		return nil, 0, err
 	}
 
 	// Fazer scrolls leves para destravar lazy-load
@@ -146,21 +221,13 @@ func (e *Engine) processQuery(ctx context.Context, query string, cfg RunConfig,
 	// Contar perfis visíveis
 	count, err := e.countVisibleProfiles(ctx)
 	if err != nil {
-		callbacks.OnLog(fmt.Sprintf("Erro ao contar perfis: %v", err))
+		e.warn(callbacks, fmt.Sprintf("Erro ao contar perfis: %v", err))
 	} else {
 		callbacks.OnLog(fmt.Sprintf("Encontrados %d perfis visíveis", count))
 	}
 
 	// Capturar e conectar
-	contacts, invitesSent, err := e.captureAndConnect(ctx, cfg, callbacks)
-	if err != nil {
-		return err
-	}
-
-	callbacks.OnLog(fmt.Sprintf("Capturados %d perfis para '%s'", len(contacts), query))
-	callbacks.OnLog(fmt.Sprintf("Convites enviados: %d", invitesSent))
-
-	return nil
+	return e.captureAndConnect(ctx, query, cfg, callbacks)
 }
 
 // countVisibleProfiles conta perfis visíveis na página
@@ -173,7 +240,7 @@ func (e *Engine) countVisibleProfiles(ctx context.Context) (int, error) {
 }
 
 // captureAndConnect captura perfis e tenta conectar
-func (e *Engine) captureAndConnect(ctx context.Context, cfg RunConfig, callbacks Callbacks) ([]Contact, int, error) {
+func (e *Engine) captureAndConnect(ctx context.Context, query string, cfg RunConfig, callbacks Callbacks) ([]Contact, int, error) {
 	var contacts []Contact
 	invitesSent := 0
 
@@ -185,11 +252,13 @@ func (e *Engine) captureAndConnect(ctx context.Context, cfg RunConfig, callbacks
 			const results = [];
 			let count = 0;
 			
-			for (const card of cards) {
+			for (let i = 0; i < cards.length; i++) {
+				const card = cards[i];
 				if (count >= `+fmt.Sprintf("%d", cfg.MaxCardsRead)+`) break;
 				
 				const link = card.querySelector('a[href*="/in/"]');
 				if (!link) continue;
+				card.setAttribute('data-sel', 'search-card-' + i);
 				
 				const name = link.innerText.replace(/Ver perfil de\\s*/i, '').replace(/\\s*Ver perfil.*$/i, '').trim();
 				const url = link.href;
@@ -208,57 +277,24 @@ func (e *Engine) captureAndConnect(ctx context.Context, cfg RunConfig, callbacks
 					}
 				}
 				
-				let title = relevantTexts[0] || '';
-				let company = relevantTexts[1] || '';
-				
-				// Heurística para separar título e empresa
-				if (title.includes('|') || title.includes('-')) {
-					const parts = title.split(/[|-]/);
-					title = parts[0].trim();
-					company = parts.slice(1).join(' ').trim();
-				} else if (title.toLowerCase().includes('grupo') || title.toLowerCase().includes('boticário') ||
-						   title.toLowerCase().includes('company') || title.toLowerCase().includes('corp') ||
-						   title.toLowerCase().includes('ltda') || title.toLowerCase().includes('s.a.')) {
-					if (title.split(' ').length >= 3) {
-						const words = title.split(' ');
-						title = words.slice(0, 2).join(' ');
-						company = words.slice(2).join(' ');
-					}
-				}
-				
-				// Extrair localização
-				let location = '';
-				for (const text of relevantTexts) {
-					if (text.match(/,\\s*[A-Z]{2}\\b|Brasil|Brazil|SP|RJ|CE|PE|PR|SC|RS|MG|BA|DF|GO|ES|AM|PA/i) &&
-						!text.includes('status') && !text.includes('off-line') && !text.includes('online') &&
-						!text.includes('O status está') && !text.includes('Ver perfil') && 
-						!text.includes('Conectar') && !text.includes('Connect')) {
-						location = text;
+				// Headline e resumo ("Atual: Cargo na Empresa") brutos; cargo e
+				// empresa são separados em Go por ParseHeadline
+				const headline = relevantTexts[0] || '';
+				let summary = '';
+				for (const line of card.innerText.split('\n')) {
+					if (/^(atual|current)\s*:/i.test(line.trim())) {
+						summary = line.trim();
 						break;
 					}
 				}
 				
-				// Fallback para localização
-				if (!location) {
-					const allText = card.innerText;
-					const lines = allText.split('\\n');
-					for (const line of lines) {
-						if (line.match(/,\\s*[A-Z]{2}\\b|Brasil|Brazil|SP|RJ|CE|PE|PR|SC|RS|MG|BA|DF|GO|ES|AM|PA/i) &&
-							!line.includes('status') && !line.includes('off-line') && !line.includes('online') &&
-							!line.includes('Ver perfil') && !line.includes('Conectar') && !line.includes('Connect')) {
-							location = line.trim();
-							break;
-						}
-					}
-				}
-				
 				results.push({
 					name: name,
-					title: title,
-					company: company,
-					location: location,
+					headline: headline,
+					summary: summary,
 					linkedin_url: url,
-					card: card
+					index: i,
+					text: card.innerText
 				});
 				
 				count++;
@@ -272,82 +308,119 @@ func (e *Engine) captureAndConnect(ctx context.Context, cfg RunConfig, callbacks
 		return contacts, invitesSent, err
 	}
 
-	// Processar cada perfil capturado
+	// Capturar e pontuar cada perfil
+	e.stats.cards(query, min(len(result), cfg.MaxCardsRead))
+	var selectors []string
 	for i, profile := range result {
 		if i >= cfg.MaxCardsRead {
 			break
 		}
 
+		headline := ParseHeadline(getString(profile, "headline"), getString(profile, "summary"))
+		location, _ := PickLocation(getString(profile, "text"), getString(profile, "name"), getString(profile, "headline"), getString(profile, "summary"))
 		contact := Contact{
-			Name:     profile["name"].(string),
-			Title:    profile["title"].(string),
-			Company:  profile["company"].(string),
-			Location: profile["location"].(string),
-			LinkedIn: profile["linkedin_url"].(string),
+			Name:     getString(profile, "name"),
+			Title:    headline.Title,
+			Company:  headline.Company,
+			Headline: getString(profile, "headline"),
+			Location: location,
+			LinkedIn: getString(profile, "linkedin_url"),
+			Query:    query,
+			Source:   ModeSearch,
+			Degree:   ParseDegree(getString(profile, "text")),
+			Mutual:   ParseMutual(getString(profile, "text")),
 		}
+		contact.normalize()
+		contact.Score, contact.ScoreRules = cfg.Scoring.Score(contact)
 
 		contacts = append(contacts, contact)
-		callbacks.OnCaptured(contact)
+		selectors = append(selectors, fmt.Sprintf("search-card-%d", int(profile["index"].(float64))))
+		e.capture(callbacks, contact)
+	}
+
+	// Conectar os de maior pontuação (limitado por página e pela execução)
+	invitesSent = e.connectRanked(ctx, contacts, selectors, cfg, callbacks)
+
+	return contacts, invitesSent, nil
+}
+
+// connectRanked gasta o orçamento da página (MaxConnectsPerPage) e da
+// execução nos contatos de maior pontuação, clicando em Conectar dentro do
+// card marcado com o data-sel correspondente
+func (e *Engine) connectRanked(ctx context.Context, contacts []Contact, selectors []string, cfg RunConfig, callbacks Callbacks) int {
+	invitesSent := 0
+	for _, i := range RankByScore(contacts) {
+		if invitesSent >= cfg.MaxConnectsPerPage || e.budgetExhausted(cfg) {
+			break
+		}
+
+		contact := contacts[i]
+		if cfg.Scoring != nil && contact.Score < cfg.Scoring.MinScore {
+			e.stats.attempt(contact.Query, AttemptSkipped)
+			callbacks.OnLog(fmt.Sprintf("Pulando %s: pontuação %.1f abaixo do mínimo %.1f", contact.Name, contact.Score, cfg.Scoring.MinScore))
+			continue
+		}
+		if ok, reason := callbacks.canInvite(contact); !ok {
+			e.stats.attempt(contact.Query, AttemptSkipped)
+			callbacks.OnLog(fmt.Sprintf("Pulando %s: %s", contact.Name, reason))
+			continue
+		}
+		slot, reason := callbacks.reserveInvite(contact)
+		if slot == nil {
+			e.stats.attempt(contact.Query, AttemptLimit)
+			callbacks.OnLog(fmt.Sprintf("Pulando %s: %s", contact.Name, reason))
+			continue
+		}
 
-		// Tentar conectar (limitado por página)
-		if invitesSent < cfg.MaxConnectsPerPage {
+		if cfg.Scoring != nil {
+			callbacks.OnLog(fmt.Sprintf("Tentando conectar com %s (%s) - pontuação %.1f", contact.Company, contact.Name, contact.Score))
+		} else {
 			callbacks.OnLog(fmt.Sprintf("Tentando conectar com %s (%s)", contact.Company, contact.Name))
+		}
 
-			if e.tryConnect(ctx, profile["card"], callbacks) {
-				invitesSent++
-				callbacks.OnInviteSent(contact)
-			}
+		if !e.clickCardConnect(ctx, selectors[i], callbacks) {
+			e.finishSlot(callbacks, slot, false)
+			e.stats.attempt(contact.Query, AttemptUnavailable)
+			callbacks.OnLog(fmt.Sprintf("Botão Conectar não disponível para %s", contact.Name))
+			continue
+		}
+		if e.confirmInviteModal(ctx, callbacks) {
+			invitesSent++
+			e.invitesSent++
+			e.stats.attempt(contact.Query, AttemptSent)
+			e.inviteSent(callbacks, slot, contact)
+		} else {
+			e.stats.attempt(contact.Query, AttemptFailed)
+			e.finishSlot(callbacks, slot, false)
 		}
-	}
 
-	return contacts, invitesSent, nil
+		// Jitter entre convites
+		time.Sleep(time.Duration(500+time.Now().UnixNano()%1000) * time.Millisecond)
+	}
+	return invitesSent
 }
 
-// tryConnect tenta enviar convite para um perfil
-func (e *Engine) tryConnect(ctx context.Context, card interface{}, callbacks Callbacks) bool {
-	// JavaScript para tentar conectar
-	var success bool
-	err := chromedp.Run(ctx, chromedp.Evaluate(`
+// clickCardConnect clica no botão Conectar dentro do card [data-sel=sel]
+func (e *Engine) clickCardConnect(ctx context.Context, sel string, callbacks Callbacks) bool {
+	var clicked bool
+	err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`
 		(() => {
-			try {
-				// Buscar botões de conectar na página
-				const connectBtns = document.querySelectorAll('button, a');
-				let found = false;
-				
-				for (const btn of connectBtns) {
-					const btnText = btn.innerText.trim();
-					if (/^conectar$|^connect$/i.test(btnText)) {
-						// Clicar no botão conectar
-						btn.click();
-						found = true;
-						break;
-					}
-				}
-				
-				if (found) {
-					// Aguardar modal e clicar em enviar
-					setTimeout(() => {
-						const sendBtn = document.querySelector('button, a');
-						if (sendBtn && /^enviar$|^send$|^enviar agora$/i.test(sendBtn.innerText.trim())) {
-							sendBtn.click();
-						}
-					}, 1000);
+			const card = document.querySelector('[data-sel="%s"]');
+			if (!card) return false;
+			for (const btn of card.querySelectorAll('button')) {
+				if (/%s/i.test(btn.innerText.trim())) {
+					btn.click();
+					return true;
 				}
-				
-				// Simular sucesso para teste (em produção seria mais robusto)
-				return found;
-			} catch (e) {
-				return false;
 			}
+			return false;
 		})()
-	`, &success))
-
+	`, sel, strings.Join(RxConnectLabels, "|")), &clicked))
 	if err != nil {
-		callbacks.OnLog(fmt.Sprintf("Erro ao tentar conectar: %v", err))
+		e.warn(callbacks, fmt.Sprintf("Erro ao tentar conectar: %v", err))
 		return false
 	}
-
-	return success
+	return clicked
 }
 
 // minimizeBrowser minimiza/oculta o navegador após o 2FA
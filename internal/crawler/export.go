@@ -1,10 +1,6 @@
 package crawler
 
-import (
-	"encoding/csv"
-	"os"
-	"strings"
-)
+import "strings"
 
 func NormalizeProfileURL(raw string) string {
 	if raw == "" {
@@ -33,21 +29,3 @@ func RemoveDup(cs []Contact) []Contact {
 	}
 	return out
 }
-
-func SaveCSV(path string, cs []Contact) error {
-	f, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-	w := csv.NewWriter(f)
-	defer w.Flush()
-	_ = w.Write([]string{"Nome", "Cargo", "Empresa", "Localização", "LinkedIn"})
-	for _, c := range cs {
-		_ = w.Write([]string{c.Name, c.Title, c.Company, c.Location, c.LinkedIn})
-	}
-	return nil
-}
-
-
-
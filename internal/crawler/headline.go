@@ -0,0 +1,164 @@
+package crawler
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Role papel (cargo e empresa) citado em uma headline
+type Role struct {
+	Title   string
+	Company string
+	Former  bool // papel anterior ("ex-", "former", "Anterior:")
+}
+
+// Headline cargo e empresa atuais extraídos da headline/subtítulo do perfil
+type Headline struct {
+	Title   string
+	Company string
+	Roles   []Role // todos os papéis encontrados, na ordem da headline
+}
+
+var (
+	// separadores entre papéis; hífen só conta com espaços ("Co-Founder" fica inteiro)
+	rxHeadlineSep = regexp.MustCompile(`\s*(?:\||·|•|;|\s[-–—]\s)\s*`)
+	// separadores que também ligam cargo e empresa ("Diretor · Ambev", "CFO - Magazine Luiza")
+	rxTitleCompanySep = regexp.MustCompile(`^\s*(?:·|\s[-–—]\s)\s*$`)
+	// conectores cargo → empresa, do mais para o menos confiável
+	rxCompanyAt = regexp.MustCompile(`(?i)\s+at\s+|\s*@\s*`)
+	rxCompanyNa = regexp.MustCompile(`(?i)\s+(?:na|no)\s+`)
+	// papéis anteriores
+	rxFormerPrefix = regexp.MustCompile(`(?i)^(?:ex[-\s.]\s*|former\s+|anterior:\s*|past:\s*|previously\s+)`)
+	rxFormerParen  = regexp.MustCompile(`(?i)\(\s*(?:ex[-\s.]|former\s+)\s*([^)]*)\)`)
+	// rótulos do resumo do card de busca
+	rxCurrentPrefix = regexp.MustCompile(`(?i)^(?:atual|current)\s*:\s*`)
+)
+
+// ParseHeadline separa cargo e empresa da headline do perfil. Reconhece
+// "Cargo na Empresa", "Title at Company", "Cargo @ Empresa", "Cargo · Empresa",
+// "Cargo - Empresa", papéis separados por "|", "·", "•", ";" ou " - ", e
+// papéis anteriores ("ex-", "former", "(ex-Empresa)"). O subtítulo (ex.: "Atual: Cargo na Empresa" do card de
+// busca) é usado quando a headline não traz a empresa.
+func ParseHeadline(headline, subtitle string) Headline {
+	h := Headline{Roles: parseRoles(headline)}
+	h.Title, h.Company = currentRole(h.Roles)
+
+	if h.Company == "" && strings.TrimSpace(subtitle) != "" {
+		sub := parseRoles(rxCurrentPrefix.ReplaceAllString(strings.TrimSpace(subtitle), ""))
+		if title, company := currentRole(sub); company != "" {
+			h.Company = company
+			if h.Title == "" {
+				h.Title = title
+			}
+			h.Roles = append(h.Roles, sub...)
+		}
+	}
+	return h
+}
+
+// currentRole escolhe o papel atual: o primeiro não anterior com empresa ou,
+// na falta dele, o primeiro não anterior
+func currentRole(roles []Role) (string, string) {
+	for _, r := range roles {
+		if !r.Former && r.Company != "" {
+			return r.Title, r.Company
+		}
+	}
+	for _, r := range roles {
+		if !r.Former && r.Title != "" {
+			return r.Title, ""
+		}
+	}
+	return "", ""
+}
+
+// parseRoles quebra a headline em papéis
+func parseRoles(text string) []Role {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return nil
+	}
+
+	var roles []Role
+
+	// "(ex-Empresa)" entre parênteses vira papel anterior
+	for _, m := range rxFormerParen.FindAllStringSubmatch(text, -1) {
+		if role := parseRole(m[1], true); role != (Role{}) {
+			roles = append(roles, role)
+		}
+	}
+	text = rxFormerParen.ReplaceAllString(text, "")
+
+	// Segmentos e, para cada um, se ele se liga ao seguinte por "·" ou " - "
+	var segments []string
+	var joined []bool
+	start := 0
+	for _, loc := range rxHeadlineSep.FindAllStringIndex(text, -1) {
+		segments = append(segments, text[start:loc[0]])
+		joined = append(joined, rxTitleCompanySep.MatchString(text[loc[0]:loc[1]]))
+		start = loc[1]
+	}
+	segments = append(segments, text[start:])
+	joined = append(joined, false)
+
+	var parsed []Role
+	for i := 0; i < len(segments); i++ {
+		role := parseSegment(segments[i])
+
+		// Exatamente dois segmentos simples ligados por "·" ou " - " são
+		// cargo e empresa; sequências maiores ("CEO · Mentor · Palestrante")
+		// continuam papéis separados
+		if joined[i] && !(i > 0 && joined[i-1]) && !joined[i+1] {
+			next := parseSegment(segments[i+1])
+			if role.Title != "" && role.Company == "" && !role.Former &&
+				next.Title != "" && next.Company == "" && !next.Former {
+				parsed = append(parsed, Role{Title: role.Title, Company: next.Title})
+				i++
+				continue
+			}
+		}
+
+		if role != (Role{}) {
+			parsed = append(parsed, role)
+		}
+	}
+	return append(parsed, roles...)
+}
+
+// parseSegment interpreta um segmento da headline, com o prefixo de papel anterior
+func parseSegment(segment string) Role {
+	former := false
+	if loc := rxFormerPrefix.FindStringIndex(segment); loc != nil {
+		former = true
+		segment = segment[loc[1]:]
+	}
+	return parseRole(segment, former)
+}
+
+// parseRole separa cargo e empresa de um segmento. Em papéis anteriores sem
+// conector ("ex-Google") o texto é a empresa.
+func parseRole(segment string, former bool) Role {
+	segment = strings.Trim(strings.TrimSpace(segment), ",.:")
+	if segment == "" {
+		return Role{}
+	}
+
+	for _, rx := range []*regexp.Regexp{rxCompanyAt, rxCompanyNa} {
+		locs := rx.FindAllStringIndex(segment, -1)
+		if len(locs) == 0 {
+			continue
+		}
+		last := locs[len(locs)-1]
+		title := strings.TrimSpace(segment[:last[0]])
+		company := strings.Trim(strings.TrimSpace(segment[last[1]:]), ",.")
+		if title == "" || company == "" {
+			continue
+		}
+		return Role{Title: title, Company: company, Former: former}
+	}
+
+	if former {
+		return Role{Company: segment, Former: true}
+	}
+	return Role{Title: segment}
+}
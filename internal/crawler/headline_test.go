@@ -0,0 +1,88 @@
+package crawler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHeadline(t *testing.T) {
+	tests := []struct {
+		name     string
+		headline string
+		subtitle string
+		title    string
+		company  string
+	}{
+		{"cargo na empresa", "Gerente de Vendas na Natura", "", "Gerente de Vendas", "Natura"},
+		{"cargo no empresa", "Engenheiro de Software no Nubank", "", "Engenheiro de Software", "Nubank"},
+		{"title at company", "Senior Product Manager at Spotify", "", "Senior Product Manager", "Spotify"},
+		{"arroba", "CTO @ Pipefy", "", "CTO", "Pipefy"},
+		{"arroba sem espaço", "Founder@Acme", "", "Founder", "Acme"},
+		{"hífen no cargo", "Co-Founder at Acme", "", "Co-Founder", "Acme"},
+		{"hífen no cargo sem empresa", "Co-Founder & CEO", "", "Co-Founder & CEO", ""},
+		{"sócio-fundador", "Sócio-fundador na XPTO Consultoria", "", "Sócio-fundador", "XPTO Consultoria"},
+		{"empresa com grupo", "Analista de Marketing no Grupo Boticário", "", "Analista de Marketing", "Grupo Boticário"},
+		{"pipe", "Head of Growth na Conta Azul | Mentor", "", "Head of Growth", "Conta Azul"},
+		{"ponto médio", "Diretor Comercial · Ambev", "", "Diretor Comercial", "Ambev"},
+		{"separador hífen com espaços", "CFO - Magazine Luiza", "", "CFO", "Magazine Luiza"},
+		{"travessão", "Head de Vendas — Stone", "", "Head de Vendas", "Stone"},
+		{"ponto médio e pipe", "Diretor Comercial · Ambev | Mentor", "", "Diretor Comercial", "Ambev"},
+		{"papéis com ponto médio", "CEO · Mentor · Palestrante", "", "CEO", ""},
+		{"ponto médio com conector", "Ex-Google · Product Manager at Nubank", "", "Product Manager", "Nubank"},
+		{"papel com empresa depois", "Investidor anjo | CEO na Acme", "", "CEO", "Acme"},
+		{"múltiplos papéis", "CEO na Acme | Professor na FGV", "", "CEO", "Acme"},
+		{"ex prefixo", "Ex-Google | Product Manager na Nubank", "", "Product Manager", "Nubank"},
+		{"ex com cargo", "ex-CTO at Foo | Advisor at Bar", "", "Advisor", "Bar"},
+		{"ex entre parênteses", "Head de Dados na Stone (ex-iFood)", "", "Head de Dados", "Stone"},
+		{"former", "Former VP Sales at Oracle", "", "", ""},
+		{"último conector", "Coordenadora na área comercial na Vivo", "", "Coordenadora na área comercial", "Vivo"},
+		{"sem empresa usa subtítulo", "Ajudo empresas a vender mais", "Atual: Consultor na Vendas Já", "Ajudo empresas a vender mais", "Vendas Já"},
+		{"subtítulo em inglês", "Data Scientist", "Current: Data Scientist at Itaú", "Data Scientist", "Itaú"},
+		{"subtítulo anterior ignorado", "Data Scientist", "Past: Analyst at Itaú", "Data Scientist", ""},
+		{"espaços extras", "  Analista   de  RH   na   Vale  ", "", "Analista de RH", "Vale"},
+		{"vazio", "", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseHeadline(tt.headline, tt.subtitle)
+			if got.Title != tt.title || got.Company != tt.company {
+				t.Errorf("ParseHeadline(%q, %q) = (%q, %q), esperado (%q, %q)",
+					tt.headline, tt.subtitle, got.Title, got.Company, tt.title, tt.company)
+			}
+		})
+	}
+}
+
+func TestParseHeadlineRoles(t *testing.T) {
+	tests := []struct {
+		headline string
+		roles    []Role
+	}{
+		{
+			"CEO na Acme | Professor na FGV",
+			[]Role{{Title: "CEO", Company: "Acme"}, {Title: "Professor", Company: "FGV"}},
+		},
+		{
+			"Ex-Google · Product Manager at Nubank",
+			[]Role{{Company: "Google", Former: true}, {Title: "Product Manager", Company: "Nubank"}},
+		},
+		{
+			"Head de Dados na Stone (ex-iFood)",
+			[]Role{{Title: "Head de Dados", Company: "Stone"}, {Company: "iFood", Former: true}},
+		},
+		{
+			"ex-CTO at Foo; Advisor",
+			[]Role{{Title: "CTO", Company: "Foo", Former: true}, {Title: "Advisor"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.headline, func(t *testing.T) {
+			got := ParseHeadline(tt.headline, "").Roles
+			if !reflect.DeepEqual(got, tt.roles) {
+				t.Errorf("ParseHeadline(%q).Roles = %+v, esperado %+v", tt.headline, got, tt.roles)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package crawler
+
+import (
+	_ "embed"
+	"regexp"
+	"strings"
+)
+
+//go:embed gazetteer.txt
+var gazetteerData string
+
+// Location localização estruturada extraída do texto exibido no perfil
+type Location struct {
+	City    string `json:"city,omitempty"`
+	Region  string `json:"region,omitempty"`  // UF/estado (código quando existe, ex.: SP, TX)
+	Country string `json:"country,omitempty"` // código ISO 3166-1 alfa-2 (BR, PT, US)
+}
+
+// Resolved indica se algum campo foi reconhecido
+func (l Location) Resolved() bool {
+	return l.Country != "" || l.Region != ""
+}
+
+// place entrada do gazetteer
+type place struct {
+	country string
+	region  string
+}
+
+// gazetteer índices por nome normalizado (foldText); cada nome pode ter
+// várias entradas, na ordem do arquivo
+type gazetteer struct {
+	countries map[string][]place
+	regions   map[string][]place
+	cities    map[string][]place
+}
+
+var geo = loadGazetteer(gazetteerData)
+
+// Sufixos e prefixos de áreas metropolitanas ("São Paulo e Região", "Greater Lisbon")
+var (
+	rxMetroSuffix = regexp.MustCompile(`(?i)\s+(?:e\s+regi[aã]o|and\s+region|region|bay\s+area|metropolitan\s+area|metro\s+area|area|[aá]rea\s+metropolitana)$`)
+	rxMetroPrefix = regexp.MustCompile(`(?i)^(?:greater|grande|regi[aã]o\s+metropolitana\s+de|regi[aã]o\s+de|[aá]rea\s+metropolitana\s+de|metropolitan\s+area\s+of|[aá]rea\s+de)\s+`)
+	rxRemote      = regexp.MustCompile(`(?i)^(?:remote|remoto|remota|home\s*office)$`)
+)
+
+// stateCountries países em que o LinkedIn mostra "Estado, País" para o estado
+// ("São Paulo, Brasil", "New York, United States"); nos demais "Nome, País" é a
+// cidade ("Lisbon, Portugal", "Madrid, Spain")
+var stateCountries = map[string]bool{"BR": true, "US": true, "CA": true, "AU": true}
+
+// loadGazetteer interpreta o arquivo do gazetteer (formato descrito no cabeçalho dele)
+func loadGazetteer(data string) *gazetteer {
+	g := &gazetteer{
+		countries: map[string][]place{},
+		regions:   map[string][]place{},
+		cities:    map[string][]place{},
+	}
+	add := func(index map[string][]place, p place, names ...string) {
+		for _, name := range names {
+			if key := foldText(strings.TrimSpace(name)); key != "" {
+				index[key] = append(index[key], p)
+			}
+		}
+	}
+
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "|")
+		aliases := strings.Split(fields[len(fields)-1], ";")
+		switch {
+		case fields[0] == "C" && len(fields) == 4:
+			// O código do país não vira apelido: conflita com siglas de UF (PA, PE, AM)
+			add(g.countries, place{country: fields[1]}, append(aliases, fields[2])...)
+		case fields[0] == "R" && len(fields) == 5:
+			add(g.regions, place{country: fields[1], region: fields[2]}, append(aliases, fields[2], fields[3])...)
+		case fields[0] == "T" && len(fields) == 5:
+			add(g.cities, place{country: fields[1], region: fields[2]}, append(aliases, fields[3])...)
+		}
+	}
+	return g
+}
+
+// lookup retorna a primeira entrada compatível com o país/região já conhecidos
+func lookup(index map[string][]place, name, country, region string) (place, bool) {
+	for _, p := range index[foldText(name)] {
+		if country != "" && p.country != country {
+			continue
+		}
+		if region != "" && p.region != "" && p.region != region {
+			continue
+		}
+		return p, true
+	}
+	return place{}, false
+}
+
+// lookupRegion procura a região; siglas ambíguas ("PA" = Pará ou
+// Pennsylvania) são resolvidas pela cidade informada antes dela
+func lookupRegion(name, city string, hasCity bool, country string) (place, bool) {
+	if hasCity {
+		for _, p := range geo.regions[foldText(name)] {
+			if country != "" && p.country != country {
+				continue
+			}
+			if _, ok := lookup(geo.cities, city, p.country, p.region); ok {
+				return p, true
+			}
+		}
+	}
+	return lookup(geo.regions, name, country, "")
+}
+
+// cleanLocationPart remove rótulos de área metropolitana e espaços extras
+func cleanLocationPart(part string) string {
+	part = strings.Join(strings.Fields(part), " ")
+	part = rxMetroSuffix.ReplaceAllString(part, "")
+	part = rxMetroPrefix.ReplaceAllString(part, "")
+	return strings.Trim(part, " .")
+}
+
+// NormalizeLocation converte o texto de localização do LinkedIn em cidade,
+// região e código do país usando o gazetteer embutido. Exemplos:
+// "São Paulo e Região" → São Paulo/SP/BR, "Greater Lisbon" → Lisbon/Lisboa/PT,
+// "Austin, Texas, United States" → Austin/TX/US. A cidade mantém a grafia do
+// texto (sem os rótulos de área metropolitana); partes não reconhecidas ficam
+// como cidade quando o restante foi identificado.
+func NormalizeLocation(raw string) Location {
+	var parts []string
+	for _, part := range strings.Split(raw, ",") {
+		if part = cleanLocationPart(part); part != "" && !rxRemote.MatchString(part) {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return Location{}
+	}
+
+	var loc Location
+	i := len(parts) - 1
+
+	// Último trecho: país
+	if p, ok := lookup(geo.countries, parts[i], "", ""); ok {
+		loc.Country = p.country
+		i--
+	}
+
+	// Trecho antes do país (ou último, se há cidade antes): estado/região.
+	// "São Paulo, Brasil" é o estado; "São Paulo, São Paulo, Brasil" e
+	// "Lisbon, Portugal" são a cidade.
+	cityFirst := i == 0 && loc.Country != "" && !stateCountries[loc.Country]
+	if _, ok := lookup(geo.cities, parts[0], loc.Country, ""); !ok {
+		cityFirst = false
+	}
+	if i >= 0 && (i > 0 || loc.Country != "") && !cityFirst {
+		if p, ok := lookupRegion(parts[i], parts[0], i > 0, loc.Country); ok {
+			loc.Region, loc.Country = p.region, p.country
+			i--
+		}
+	}
+
+	if i >= 0 {
+		city := parts[0]
+		if p, ok := lookup(geo.cities, city, loc.Country, loc.Region); ok {
+			loc.City = city
+			if loc.Region == "" {
+				loc.Region = p.region
+			}
+			loc.Country = p.country
+		} else if len(parts) == 1 {
+			// Texto único: região ou país ("Texas", "Brazil")
+			if p, ok := lookup(geo.regions, city, "", ""); ok {
+				loc.Region, loc.Country = p.region, p.country
+			} else if p, ok := lookup(geo.countries, city, "", ""); ok {
+				loc.Country = p.country
+			}
+		} else if loc.Resolved() {
+			loc.City = city
+		}
+	}
+	return loc
+}
+
+// applyLocation preenche cidade, região e país a partir de c.Location
+func (c *Contact) applyLocation() {
+	loc := NormalizeLocation(c.Location)
+	c.City, c.Region, c.Country = loc.City, loc.Region, loc.Country
+}
+
+// PickLocation escolhe, entre as linhas de texto de um card, a primeira
+// reconhecida como localização (ignorando as linhas em skip)
+func PickLocation(text string, skip ...string) (string, Location) {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || len(line) > 80 || containsLine(skip, line) {
+			continue
+		}
+		if loc := NormalizeLocation(line); loc.Resolved() {
+			return line, loc
+		}
+	}
+	return "", Location{}
+}
+
+func containsLine(lines []string, line string) bool {
+	for _, l := range lines {
+		if strings.TrimSpace(l) == line {
+			return true
+		}
+	}
+	return false
+}
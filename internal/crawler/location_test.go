@@ -0,0 +1,54 @@
+package crawler
+
+import "testing"
+
+func TestNormalizeLocation(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Location
+	}{
+		// Brasil
+		{"São Paulo e Região", Location{City: "São Paulo", Region: "SP", Country: "BR"}},
+		{"Rio de Janeiro e Região", Location{City: "Rio de Janeiro", Region: "RJ", Country: "BR"}},
+		{"São Paulo, São Paulo, Brasil", Location{City: "São Paulo", Region: "SP", Country: "BR"}},
+		{"São Paulo, Brasil", Location{Region: "SP", Country: "BR"}},
+		{"Belém, PA", Location{City: "Belém", Region: "PA", Country: "BR"}},
+		{"Brasil", Location{Country: "BR"}},
+		// Áreas metropolitanas
+		{"Greater Lisbon", Location{City: "Lisbon", Region: "Lisboa", Country: "PT"}},
+		{"London Area, United Kingdom", Location{City: "London", Country: "GB"}},
+		{"London Area", Location{City: "London", Country: "GB"}},
+		// Cidade, País fora dos países com estados
+		{"Lisbon, Portugal", Location{City: "Lisbon", Region: "Lisboa", Country: "PT"}},
+		{"Porto, Portugal", Location{City: "Porto", Region: "Porto", Country: "PT"}},
+		{"Lisboa, Lisboa, Portugal", Location{City: "Lisboa", Region: "Lisboa", Country: "PT"}},
+		// Estados Unidos
+		{"Austin, Texas, United States", Location{City: "Austin", Region: "TX", Country: "US"}},
+		{"Pittsburgh, PA", Location{City: "Pittsburgh", Region: "PA", Country: "US"}},
+		{"Pittsburgh, Pennsylvania, United States", Location{City: "Pittsburgh", Region: "PA", Country: "US"}},
+		{"Texas, United States", Location{Region: "TX", Country: "US"}},
+		{"Texas", Location{Region: "TX", Country: "US"}},
+		// Cidade fora do gazetteer fica como escrita
+		{"Smallville, Kansas, United States", Location{City: "Smallville", Region: "KS", Country: "US"}},
+		// Não reconhecidos
+		{"Remote", Location{}},
+		{"Algum lugar", Location{}},
+		{"", Location{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			if got := NormalizeLocation(tt.raw); got != tt.want {
+				t.Errorf("NormalizeLocation(%q) = %+v, esperado %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickLocation(t *testing.T) {
+	text := "Maria Souza\nDiretora Comercial na Ambev\nSão Paulo e Região\nConectar"
+	line, loc := PickLocation(text, "Maria Souza", "Diretora Comercial na Ambev")
+	if line != "São Paulo e Região" || loc.Region != "SP" || loc.Country != "BR" {
+		t.Errorf("PickLocation = (%q, %+v), esperado São Paulo e Região/SP/BR", line, loc)
+	}
+}
@@ -0,0 +1,157 @@
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Messenger envia mensagens e lê conversas numa sessão já autenticada
+type Messenger struct {
+	e    *Engine
+	ctx  context.Context
+	logf func(string)
+}
+
+// WithMessenger abre o navegador, faz login e executa fn com um Messenger
+// válido durante a chamada
+func (e *Engine) WithMessenger(headless bool, creds Creds, logf func(string), fn func(m *Messenger) error) error {
+	defer e.cancel()
+
+	ctx, closeSession, err := e.startSession(headless, creds, logf)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
+
+	return fn(&Messenger{e: e, ctx: ctx, logf: logf})
+}
+
+// RecentConnections lê as conexões mais recentes da conta
+func (m *Messenger) RecentConnections(limit int) ([]Connection, error) {
+	return m.e.listConnections(m.ctx, limit, time.Now(), m.logf)
+}
+
+// HasReplied abre a conversa com o perfil e indica se há mensagens da outra pessoa
+func (m *Messenger) HasReplied(profileURL string) (bool, error) {
+	if err := m.openConversation(profileURL); err != nil {
+		return false, err
+	}
+	defer m.closeConversation()
+
+	var replied bool
+	err := chromedp.Run(m.ctx, chromedp.Evaluate(fmt.Sprintf(`document.querySelectorAll('%s').length > 0`, SelMessageOther), &replied))
+	return replied, err
+}
+
+// Send abre a conversa com o perfil e envia o texto
+func (m *Messenger) Send(profileURL, text string) error {
+	if err := m.openConversation(profileURL); err != nil {
+		return err
+	}
+	defer m.closeConversation()
+
+	var typed bool
+	err := chromedp.Run(m.ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(() => {
+			const editor = document.querySelector('%s');
+			if (!editor) return false;
+			editor.focus();
+			document.execCommand('insertText', false, %s);
+			editor.dispatchEvent(new Event('input', {bubbles: true}));
+			return true;
+		})()
+	`, SelMessageEditor, jsLiteral(text)), &typed))
+	if err != nil {
+		return err
+	}
+	if !typed {
+		return fmt.Errorf("campo de mensagem não encontrado")
+	}
+
+	time.Sleep(700 * time.Millisecond)
+
+	var sent bool
+	err = chromedp.Run(m.ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(() => {
+			const btn = document.querySelector('%s');
+			if (!btn || btn.disabled) return false;
+			btn.click();
+			return true;
+		})()
+	`, SelMessageSend), &sent))
+	if err != nil {
+		return err
+	}
+	if !sent {
+		return fmt.Errorf("botão Enviar indisponível")
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	return nil
+}
+
+// openConversation navega até o perfil e clica em "Mensagem" no top card
+func (m *Messenger) openConversation(profileURL string) error {
+	profileURL = NormalizeProfileURL(profileURL)
+	if err := chromedp.Run(m.ctx, chromedp.Navigate(profileURL)); err != nil {
+		return err
+	}
+	if err := chromedp.Run(m.ctx, chromedp.WaitReady(SelProfileName)); err != nil {
+		return err
+	}
+	time.Sleep(1 * time.Second)
+
+	var clicked bool
+	err := chromedp.Run(m.ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(() => {
+			const card = document.querySelector('%s') || document;
+			for (const el of card.querySelectorAll('button, a')) {
+				const label = (el.getAttribute('aria-label') || '').trim();
+				const text = el.innerText.trim();
+				if (/%s/i.test(text) || /^(enviar mensagem|message) /i.test(label)) {
+					el.click();
+					return true;
+				}
+			}
+			return false;
+		})()
+	`, SelProfileTopCard, strings.Join(RxMessageLabels, "|")), &clicked))
+	if err != nil {
+		return err
+	}
+	if !clicked {
+		return fmt.Errorf("botão Mensagem não encontrado (perfil não é conexão?)")
+	}
+
+	waitCtx, cancel := context.WithTimeout(m.ctx, 15*time.Second)
+	defer cancel()
+	if err := chromedp.Run(waitCtx, chromedp.WaitVisible(SelMessageEditor)); err != nil {
+		return fmt.Errorf("janela de conversa não abriu: %v", err)
+	}
+	time.Sleep(1500 * time.Millisecond)
+	return nil
+}
+
+// closeConversation fecha a janela de conversa aberta (erros são ignorados)
+func (m *Messenger) closeConversation() {
+	_ = chromedp.Run(m.ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(() => {
+			const btn = document.querySelector('%s');
+			if (btn) btn.click();
+			return true;
+		})()
+	`, SelConversationExit), nil))
+	time.Sleep(500 * time.Millisecond)
+}
+
+// jsLiteral serializa o texto como literal de string JavaScript (aspas,
+// quebras de linha e barras escapadas)
+func jsLiteral(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,170 @@
+package crawler
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// PersonName nome de um perfil normalizado a partir do texto da página
+type PersonName struct {
+	Raw   string // texto original (auditoria)
+	Full  string // nome limpo
+	First string
+	Last  string // sobrenome, com partículas ("da Silva") e sufixos ("Alves Filho")
+}
+
+var (
+	// texto de acessibilidade duplicado no link do card
+	rxViewProfile   = regexp.MustCompile(`(?i)\s*(?:ver perfil de|view\s+.+?[’']s\s+profile|view profile|ver perfil)\b.*$`)
+	rxViewProfileOf = regexp.MustCompile(`(?i)^\s*(?:ver perfil de\s+(.+?)|view\s+(.+?)[’']s\s+profile)\s*$`)
+	// pronomes: "(ela/dela)", "(he/him)", "she/her/hers"
+	rxPronouns = regexp.MustCompile(`(?i)\(?\b(?:ela|ele|elu|he|she|they)\s*/\s*(?:dela|dele|delu|him|her|them|hers|theirs)(?:\s*/\s*\w+)?\b\)?`)
+	// conteúdo entre parênteses ou colchetes (apelidos, certificações)
+	rxBracketed = regexp.MustCompile(`[(\[][^)\]]*[)\]]`)
+)
+
+// namePrefixes tratamentos removidos do início do nome
+var namePrefixes = map[string]bool{
+	"dr": true, "dra": true, "prof": true, "profa": true, "eng": true, "mr": true, "mrs": true, "ms": true, "sr": true, "sra": true,
+}
+
+// nameCredentials certificações e títulos removidos do fim do nome
+var nameCredentials = map[string]bool{
+	"mba": true, "pmp": true, "phd": true, "msc": true, "mestre": true, "me": true, "cpa": true, "cfa": true,
+	"csm": true, "cspo": true, "psm": true, "pmi-acp": true, "cissp": true, "cisa": true, "cism": true,
+	"ccna": true, "itil": true, "cpc": true, "acc": true, "pcc": true, "mcc": true, "shrm-cp": true, "sphr": true,
+	"frm": true, "cga": true, "cma": true, "crc": true, "oab": true, "crea": true,
+}
+
+// nameParticles partículas que pertencem ao sobrenome seguinte
+var nameParticles = map[string]bool{
+	"da": true, "das": true, "de": true, "do": true, "dos": true, "e": true, "d'": true,
+	"van": true, "von": true, "der": true, "den": true, "del": true, "della": true, "di": true, "du": true, "la": true, "le": true,
+}
+
+// nameSuffixes sufixos que acompanham o sobrenome anterior
+var nameSuffixes = map[string]bool{
+	"filho": true, "filha": true, "neto": true, "neta": true, "sobrinho": true, "júnior": true, "junior": true, "jr": true, "segundo": true,
+}
+
+// ParseName limpa o nome exibido no LinkedIn (texto de acessibilidade
+// duplicado, emojis, pronomes, certificações como "MBA"/"PMP") e separa
+// primeiro nome e sobrenome, respeitando partículas ("da", "dos") e sufixos
+// ("Filho", "Júnior")
+func ParseName(raw string) PersonName {
+	name := PersonName{Raw: raw}
+
+	// Primeira linha que não é texto de acessibilidade; se só houver ele,
+	// o nome é tirado de "Ver perfil de Nome" / "View Nome’s profile"
+	text, fallback := "", ""
+	for _, line := range strings.Split(raw, "\n") {
+		if m := rxViewProfileOf.FindStringSubmatch(line); m != nil && fallback == "" {
+			fallback = m[1] + m[2]
+		}
+		if line = strings.TrimSpace(rxViewProfile.ReplaceAllString(line, "")); line != "" {
+			text = line
+			break
+		}
+	}
+	if text == "" {
+		text = fallback
+	}
+
+	// Tudo após a primeira vírgula são certificações ("Maria Silva, MBA, PMP")
+	if i := strings.Index(text, ","); i > 0 {
+		text = text[:i]
+	}
+	text = rxPronouns.ReplaceAllString(text, " ")
+	text = rxBracketed.ReplaceAllString(text, " ")
+
+	// Emojis e símbolos viram espaço
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.Is(unicode.Mn, r) || r == '\'' || r == '’' || r == '-' || r == '.' {
+			return r
+		}
+		return ' '
+	}, text)
+
+	tokens := strings.Fields(text)
+	for len(tokens) > 1 && namePrefixes[nameKey(tokens[0])] {
+		tokens = tokens[1:]
+	}
+	for len(tokens) > 1 && nameCredentials[nameKey(tokens[len(tokens)-1])] {
+		tokens = tokens[:len(tokens)-1]
+	}
+	tokens = dedupeTokens(tokens)
+	if len(tokens) == 0 {
+		return name
+	}
+
+	// Nomes todo em maiúsculas ou minúsculas viram "Nome da Silva"
+	joined := strings.Join(tokens, " ")
+	if joined == strings.ToUpper(joined) || joined == strings.ToLower(joined) {
+		for i, t := range tokens {
+			tokens[i] = capitalizeName(t, i > 0)
+		}
+	}
+
+	name.Full = strings.Join(tokens, " ")
+	name.First = tokens[0]
+	if len(tokens) > 1 {
+		start := len(tokens) - 1
+		if nameSuffixes[nameKey(tokens[start])] && start > 1 {
+			start--
+		}
+		for start > 1 && nameParticles[nameKey(tokens[start-1])] {
+			start--
+		}
+		name.Last = strings.Join(tokens[start:], " ")
+	}
+	return name
+}
+
+// nameKey forma de comparação de um token (minúsculas, sem pontos)
+func nameKey(token string) string {
+	return strings.Trim(strings.ToLower(token), ".")
+}
+
+// dedupeTokens remove a repetição exata do nome ("Ana Souza Ana Souza")
+func dedupeTokens(tokens []string) []string {
+	n := len(tokens)
+	if n%2 == 0 && n > 0 && strings.Join(tokens[:n/2], " ") == strings.Join(tokens[n/2:], " ") {
+		return tokens[:n/2]
+	}
+	return tokens
+}
+
+// capitalizeName aplica capitalização de nome próprio, mantendo partículas
+// em minúsculas fora da primeira posição
+func capitalizeName(token string, particleAllowed bool) string {
+	lower := strings.ToLower(token)
+	if particleAllowed && nameParticles[lower] {
+		return lower
+	}
+	runes := []rune(lower)
+	for i := range runes {
+		if i == 0 || runes[i-1] == '-' || runes[i-1] == '\'' || runes[i-1] == '’' {
+			runes[i] = unicode.ToUpper(runes[i])
+		}
+	}
+	return string(runes)
+}
+
+// applyName normaliza o nome do contato, guardando o texto original em RawName
+func (c *Contact) applyName() {
+	if c.RawName == "" {
+		c.RawName = c.Name
+	}
+	name := ParseName(c.RawName)
+	if name.Full != "" {
+		c.Name = name.Full
+	}
+	c.FirstName, c.LastName = name.First, name.Last
+}
+
+// normalize limpa o nome e estrutura a localização do contato capturado
+func (c *Contact) normalize() {
+	c.applyName()
+	c.applyLocation()
+}
@@ -0,0 +1,49 @@
+package crawler
+
+import "testing"
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		name  string
+		raw   string
+		full  string
+		first string
+		last  string
+	}{
+		{"simples", "Maria Souza", "Maria Souza", "Maria", "Souza"},
+		{"só primeiro nome", "Maria", "Maria", "Maria", ""},
+		{"partícula", "João da Silva", "João da Silva", "João", "da Silva"},
+		{"várias partículas", "Ana Maria dos Santos", "Ana Maria dos Santos", "Ana", "dos Santos"},
+		{"partícula estrangeira", "Ludwig van Beethoven", "Ludwig van Beethoven", "Ludwig", "van Beethoven"},
+		{"sufixo", "Pedro Alves Filho", "Pedro Alves Filho", "Pedro", "Alves Filho"},
+		{"sufixo com partícula", "Carlos de Souza Júnior", "Carlos de Souza Júnior", "Carlos", "de Souza Júnior"},
+		{"sufixo abreviado", "Paulo Costa Jr.", "Paulo Costa Jr.", "Paulo", "Costa Jr."},
+		{"emojis", "🚀 Ana Souza ✨", "Ana Souza", "Ana", "Souza"},
+		{"certificações após vírgula", "Maria Silva, MBA, PMP", "Maria Silva", "Maria", "Silva"},
+		{"certificações sem vírgula", "Maria Silva MBA PMP", "Maria Silva", "Maria", "Silva"},
+		{"certificação entre parênteses", "Bruno Lima (PMP)", "Bruno Lima", "Bruno", "Lima"},
+		{"pronomes", "Carla Dias (ela/dela)", "Carla Dias", "Carla", "Dias"},
+		{"pronomes em inglês", "Alex Kim he/him", "Alex Kim", "Alex", "Kim"},
+		{"tratamento", "Dra. Fernanda Rocha", "Fernanda Rocha", "Fernanda", "Rocha"},
+		{"maiúsculas", "JOSÉ DA SILVA", "José da Silva", "José", "da Silva"},
+		{"minúsculas", "ana-paula d'ávila", "Ana-Paula D'Ávila", "Ana-Paula", "D'Ávila"},
+		{"texto de acessibilidade", "Maria Souza\nVer perfil de Maria Souza", "Maria Souza", "Maria", "Souza"},
+		{"só acessibilidade", "Ver perfil de Maria Souza", "Maria Souza", "Maria", "Souza"},
+		{"acessibilidade em inglês", "View Maria Souza’s profile", "Maria Souza", "Maria", "Souza"},
+		{"nome duplicado", "Ana Souza Ana Souza", "Ana Souza", "Ana", "Souza"},
+		{"vazio", "", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseName(tt.raw)
+			if got.Full != tt.full || got.First != tt.first || got.Last != tt.last {
+				t.Errorf("ParseName(%q) = (%q, %q, %q), esperado (%q, %q, %q)",
+					tt.raw, got.Full, got.First, got.Last, tt.full, tt.first, tt.last)
+			}
+			if got.Raw != tt.raw {
+				t.Errorf("ParseName(%q).Raw = %q, esperado o texto original", tt.raw, got.Raw)
+			}
+		})
+	}
+}
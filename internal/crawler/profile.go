@@ -0,0 +1,311 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Resultados da tentativa de conexão a partir da página de perfil
+const (
+	connectSent     = "sent"
+	connectPending  = "pending"
+	connectNotFound = "not_found"
+	connectFailed   = "failed" // Conectar clicado, mas o modal não confirmou o envio
+)
+
+// IsProfileURL indica se a URL aponta para um perfil do LinkedIn (/in/)
+func IsProfileURL(raw string) bool {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	host := strings.ToLower(u.Host)
+	if host != "linkedin.com" && !strings.HasSuffix(host, ".linkedin.com") {
+		return false
+	}
+	return strings.HasPrefix(u.Path, "/in/") && len(strings.Trim(u.Path[4:], "/")) > 0
+}
+
+// ParseProfileURLs interpreta uma lista de URLs de perfil (uma por linha),
+// normalizando-as e removendo duplicadas. Linhas vazias e comentários (#)
+// são ignorados; linhas que não são perfis geram erro.
+func ParseProfileURLs(text string) ([]string, error) {
+	var profiles []string
+	var invalid []string
+	seen := map[string]bool{}
+
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if !IsProfileURL(line) {
+			invalid = append(invalid, fmt.Sprintf("linha %d", i+1))
+			continue
+		}
+
+		if !strings.Contains(line, "://") {
+			line = "https://" + line
+		}
+		normalized := NormalizeProfileURL(line)
+		if key := strings.ToLower(normalized); !seen[key] {
+			seen[key] = true
+			profiles = append(profiles, normalized)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return profiles, fmt.Errorf("linhas que não são URLs de perfil /in/ (%s)", strings.Join(invalid, ", "))
+	}
+	return profiles, nil
+}
+
+// runProfiles visita cada perfil da lista, captura o contato e tenta conectar
+func (e *Engine) runProfiles(ctx context.Context, cfg RunConfig, callbacks Callbacks) {
+	for i, profileURL := range cfg.Profiles {
+		if e.budgetExhausted(cfg) {
+			callbacks.OnLog(fmt.Sprintf("Limite de %d convites da execução atingido", cfg.MaxInvites))
+			return
+		}
+
+		callbacks.OnLog(fmt.Sprintf("=== Perfil %d/%d: %s ===", i+1, len(cfg.Profiles), profileURL))
+
+		e.stats.cards("", 1)
+		if err := e.processProfile(ctx, profileURL, cfg, callbacks); err != nil {
+			e.warn(callbacks, fmt.Sprintf("Erro ao processar perfil '%s': %v", profileURL, err))
+			continue
+		}
+
+		// Jitter entre perfis
+		time.Sleep(time.Duration(1500+time.Now().UnixNano()%1500) * time.Millisecond)
+	}
+}
+
+// processProfile abre a página do perfil, extrai e pontua o cabeçalho e envia
+// o convite
+func (e *Engine) processProfile(ctx context.Context, profileURL string, cfg RunConfig, callbacks Callbacks) error {
+	profileURL = NormalizeProfileURL(profileURL)
+	if err := chromedp.Run(ctx, chromedp.Navigate(profileURL)); err != nil {
+		return err
+	}
+	if err := chromedp.Run(ctx, chromedp.WaitReady(SelProfileName)); err != nil {
+		return err
+	}
+	time.Sleep(1 * time.Second)
+
+	contact, err := e.extractProfileHeader(ctx, profileURL)
+	if err != nil {
+		return err
+	}
+	if contact.Name == "" {
+		return fmt.Errorf("cabeçalho do perfil não encontrado")
+	}
+
+	contact.normalize()
+	contact.Score, contact.ScoreRules = cfg.Scoring.Score(contact)
+	e.capture(callbacks, contact)
+
+	if cfg.Scoring != nil && contact.Score < cfg.Scoring.MinScore {
+		e.stats.attempt(contact.Query, AttemptSkipped)
+		callbacks.OnLog(fmt.Sprintf("Pulando %s: pontuação %.1f abaixo do mínimo %.1f", contact.Name, contact.Score, cfg.Scoring.MinScore))
+		return nil
+	}
+	if ok, reason := callbacks.canInvite(contact); !ok {
+		e.stats.attempt(contact.Query, AttemptSkipped)
+		callbacks.OnLog(fmt.Sprintf("Pulando %s: %s", contact.Name, reason))
+		return nil
+	}
+	slot, reason := callbacks.reserveInvite(contact)
+	if slot == nil {
+		e.stats.attempt(contact.Query, AttemptLimit)
+		callbacks.OnLog(fmt.Sprintf("Pulando %s: %s", contact.Name, reason))
+		return nil
+	}
+
+	callbacks.OnLog(fmt.Sprintf("Tentando conectar com %s (%s)", contact.Company, contact.Name))
+
+	switch outcome := e.connectFromProfile(ctx, callbacks); outcome {
+	case connectSent:
+		e.invitesSent++
+		e.stats.attempt(contact.Query, AttemptSent)
+		e.inviteSent(callbacks, slot, contact)
+	case connectPending:
+		e.finishSlot(callbacks, slot, false)
+		e.stats.attempt(contact.Query, AttemptPending)
+		callbacks.OnLog(fmt.Sprintf("Convite para %s já está pendente", contact.Name))
+	case connectFailed:
+		e.finishSlot(callbacks, slot, false)
+		e.stats.attempt(contact.Query, AttemptFailed)
+		callbacks.OnLog(fmt.Sprintf("Convite para %s não foi confirmado no modal", contact.Name))
+	default:
+		e.finishSlot(callbacks, slot, false)
+		e.stats.attempt(contact.Query, AttemptUnavailable)
+		callbacks.OnLog(fmt.Sprintf("Botão Conectar não disponível para %s", contact.Name))
+	}
+
+	return nil
+}
+
+// extractProfileHeader extrai nome, headline, empresa atual e localização do top card
+func (e *Engine) extractProfileHeader(ctx context.Context, profileURL string) (Contact, error) {
+	var raw map[string]interface{}
+	err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(() => {
+			const card = document.querySelector('%s') || document;
+			const text = (sel) => {
+				const el = card.querySelector(sel);
+				return el ? el.innerText.trim() : '';
+			};
+
+			// Empresa atual vem do botão "Empresa atual: X. ..." do top card
+			let company = '';
+			const companyBtn = card.querySelector('button[aria-label*="Empresa atual" i], button[aria-label*="Current company" i]');
+			if (companyBtn) {
+				const label = companyBtn.getAttribute('aria-label') || '';
+				const m = label.match(/:\s*(.+?)\.\s/);
+				company = m ? m[1].trim() : companyBtn.innerText.trim();
+			}
+
+			return {
+				name: text('%s'),
+				headline: text('%s'),
+				company: company,
+				location: text('%s'),
+				text: card.innerText || ''
+			};
+		})()
+	`, SelProfileTopCard, SelProfileName, SelProfileHeadline, SelProfileLocation), &raw))
+	if err != nil {
+		return Contact{}, err
+	}
+
+	headline := ParseHeadline(getString(raw, "headline"), "")
+	company := getString(raw, "company")
+	if company == "" {
+		company = headline.Company
+	}
+
+	return Contact{
+		Name:     getString(raw, "name"),
+		Title:    headline.Title,
+		Company:  company,
+		Headline: getString(raw, "headline"),
+		Location: getString(raw, "location"),
+		LinkedIn: profileURL,
+		Source:   ModeProfiles,
+		Degree:   ParseDegree(getString(raw, "text")),
+		Mutual:   ParseMutual(getString(raw, "text")),
+	}, nil
+}
+
+// connectFromProfile usa o botão Conectar do perfil ou o menu Mais→Conectar
+// e confirma o envio no modal
+func (e *Engine) connectFromProfile(ctx context.Context, callbacks Callbacks) string {
+	var state string
+	err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(() => {
+			const card = document.querySelector('%s') || document;
+			const inviteAria = new RegExp('%s', 'i');
+			const buttons = Array.from(card.querySelectorAll('button'));
+
+			for (const btn of buttons) {
+				if (/%s/i.test(btn.innerText.trim())) return '%s';
+			}
+			for (const btn of buttons) {
+				const aria = btn.getAttribute('aria-label') || '';
+				if (inviteAria.test(aria) || /%s/i.test(btn.innerText.trim())) {
+					btn.click();
+					return 'clicked';
+				}
+			}
+			for (const btn of buttons) {
+				const label = (btn.getAttribute('aria-label') || btn.innerText).trim();
+				if (/%s/i.test(label)) {
+					btn.click();
+					return 'more';
+				}
+			}
+			return '%s';
+		})()
+	`, SelProfileTopCard, jsString(RxInviteAria), strings.Join(RxPendingLabels, "|"), connectPending,
+		strings.Join(RxConnectLabels, "|"), strings.Join(RxMoreLabels, "|"), connectNotFound), &state))
+	if err != nil {
+		callbacks.OnLog(fmt.Sprintf("Erro ao procurar Conectar: %v", err))
+		return connectNotFound
+	}
+
+	// Conectar escondido no menu "Mais"
+	if state == "more" {
+		time.Sleep(700 * time.Millisecond)
+		err = chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`
+			(() => {
+				const inviteAria = new RegExp('%s', 'i');
+				const items = document.querySelectorAll('.artdeco-dropdown__content [role="button"], div[role="menu"] [role="button"], div[role="menu"] [role="menuitem"]');
+				for (const item of items) {
+					const aria = item.getAttribute('aria-label') || '';
+					const text = item.innerText.trim();
+					if (/%s/i.test(text)) return '%s';
+					if (inviteAria.test(aria) || /%s/i.test(text)) {
+						item.click();
+						return 'clicked';
+					}
+				}
+				return '%s';
+			})()
+		`, jsString(RxInviteAria), strings.Join(RxPendingLabels, "|"), connectPending,
+			strings.Join(RxConnectLabels, "|"), connectNotFound), &state))
+		if err != nil {
+			callbacks.OnLog(fmt.Sprintf("Erro ao abrir menu Mais: %v", err))
+			return connectNotFound
+		}
+	}
+
+	if state != "clicked" {
+		return state
+	}
+
+	if !e.confirmInviteModal(ctx, callbacks) {
+		return connectFailed
+	}
+	return connectSent
+}
+
+// confirmInviteModal clica em "Enviar sem nota"/"Enviar" no modal de convite
+func (e *Engine) confirmInviteModal(ctx context.Context, callbacks Callbacks) bool {
+	time.Sleep(1 * time.Second)
+	var sent bool
+	err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(() => {
+			const buttons = document.querySelectorAll('div[role="dialog"] button');
+			for (const btn of buttons) {
+				const label = (btn.getAttribute('aria-label') || btn.innerText).trim();
+				if (/%s/i.test(label) || /%s/i.test(btn.innerText.trim())) {
+					btn.click();
+					return true;
+				}
+			}
+			return false;
+		})()
+	`, RxSendNoteLabel, RxSendNoteLabel), &sent))
+	if err != nil {
+		e.warn(callbacks, fmt.Sprintf("Erro ao confirmar convite: %v", err))
+		return false
+	}
+	if !sent {
+		e.warn(callbacks, "Modal de convite não encontrado")
+		return false
+	}
+
+	time.Sleep(1 * time.Second)
+	return true
+}
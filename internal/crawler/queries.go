@@ -0,0 +1,108 @@
+package crawler
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// searchPeoplePath caminho da busca de pessoas do LinkedIn
+const searchPeoplePath = "/search/results/people"
+
+// IsSearchURL indica se a query é uma URL completa de busca de pessoas do LinkedIn
+func IsSearchURL(query string) bool {
+	u, err := url.Parse(strings.TrimSpace(query))
+	if err != nil || u.Host == "" {
+		return false
+	}
+	host := strings.ToLower(u.Host)
+	if host != "linkedin.com" && !strings.HasSuffix(host, ".linkedin.com") {
+		return false
+	}
+	return strings.HasPrefix(strings.TrimSuffix(u.Path, "/"), searchPeoplePath)
+}
+
+// looksLikeURL indica se a linha parece uma URL (e não palavras-chave)
+func looksLikeURL(line string) bool {
+	lower := strings.ToLower(line)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") ||
+		strings.HasPrefix(lower, "www.") || strings.HasPrefix(lower, "linkedin.com/")
+}
+
+// BuildSearchURL monta a URL da página de resultados para a query. Palavras-chave
+// viram o parâmetro keywords=; URLs de busca são usadas como estão. Em ambos os
+// casos a paginação é aplicada por cima (page=N a partir da segunda página).
+func BuildSearchURL(query string, page int) string {
+	query = strings.TrimSpace(query)
+
+	if looksLikeURL(query) && !strings.Contains(query, "://") {
+		query = "https://" + query
+	}
+
+	if IsSearchURL(query) {
+		u, _ := url.Parse(query)
+		u.Scheme = "https"
+		u.Host = "www.linkedin.com"
+		u.Fragment = ""
+		q := u.Query()
+		if page > 1 {
+			q.Set("page", strconv.Itoa(page))
+		} else {
+			q.Del("page")
+		}
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+
+	searchURL := "https://www.linkedin.com/search/results/people/?keywords=" + url.QueryEscape(query) + "&origin=CLUSTER_EXPANSION"
+	if page > 1 {
+		searchURL += "&page=" + strconv.Itoa(page)
+	}
+	return searchURL
+}
+
+// ParseQueries interpreta um arquivo/texto de queries (uma por linha), aceitando
+// tanto palavras-chave quanto URLs completas de busca de pessoas. Linhas vazias são
+// ignoradas e "#" é texto da busca ("#OpenToWork"); URLs que não são de busca de
+// pessoas geram erro.
+func ParseQueries(text string) ([]string, error) {
+	var queries []string
+	var invalid []string
+
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if looksLikeURL(line) {
+			candidate := line
+			if !strings.Contains(candidate, "://") {
+				candidate = "https://" + candidate
+			}
+			if !IsSearchURL(candidate) {
+				invalid = append(invalid, fmt.Sprintf("linha %d", i+1))
+				continue
+			}
+		}
+
+		queries = append(queries, line)
+	}
+
+	if len(invalid) > 0 {
+		return queries, fmt.Errorf("URLs que não são buscas de pessoas do LinkedIn (%s)", strings.Join(invalid, ", "))
+	}
+	return queries, nil
+}
+
+// CountSearchURLs conta quantas queries são URLs de busca
+func CountSearchURLs(queries []string) int {
+	n := 0
+	for _, q := range queries {
+		if looksLikeURL(q) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,95 @@
+package crawler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsSearchURL(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"https://www.linkedin.com/search/results/people/?keywords=cto", true},
+		{"https://linkedin.com/search/results/people?geoUrn=%5B%22106057199%22%5D", true},
+		{"https://br.linkedin.com/search/results/people/?keywords=rh", true},
+		{"https://www.linkedin.com/search/results/companies/?keywords=cto", false},
+		{"https://www.linkedin.com/in/ana-souza", false},
+		{"https://example.com/search/results/people/?keywords=cto", false},
+		{"https://notlinkedin.com/search/results/people/", false},
+		{"gerente de vendas", false},
+		{"#OpenToWork", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := IsSearchURL(tt.query); got != tt.want {
+				t.Errorf("IsSearchURL(%q) = %v, esperado %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSearchURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		page  int
+		want  string
+	}{
+		{"palavras-chave", "gerente de vendas", 1,
+			"https://www.linkedin.com/search/results/people/?keywords=gerente+de+vendas&origin=CLUSTER_EXPANSION"},
+		{"palavras-chave página 3", "gerente de vendas", 3,
+			"https://www.linkedin.com/search/results/people/?keywords=gerente+de+vendas&origin=CLUSTER_EXPANSION&page=3"},
+		{"hashtag", "#OpenToWork", 1,
+			"https://www.linkedin.com/search/results/people/?keywords=%23OpenToWork&origin=CLUSTER_EXPANSION"},
+		{"url de busca", "https://www.linkedin.com/search/results/people/?keywords=cto&network=%5B%22S%22%5D", 1,
+			"https://www.linkedin.com/search/results/people/?keywords=cto&network=%5B%22S%22%5D"},
+		{"url de busca página 2", "https://www.linkedin.com/search/results/people/?keywords=cto", 2,
+			"https://www.linkedin.com/search/results/people/?keywords=cto&page=2"},
+		{"url com página substituída", "https://www.linkedin.com/search/results/people/?keywords=cto&page=7", 3,
+			"https://www.linkedin.com/search/results/people/?keywords=cto&page=3"},
+		{"url com página removida na primeira", "https://www.linkedin.com/search/results/people/?keywords=cto&page=7", 1,
+			"https://www.linkedin.com/search/results/people/?keywords=cto"},
+		{"url sem esquema", "linkedin.com/search/results/people/?keywords=rh#top", 1,
+			"https://www.linkedin.com/search/results/people/?keywords=rh"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildSearchURL(tt.query, tt.page); got != tt.want {
+				t.Errorf("BuildSearchURL(%q, %d) = %q, esperado %q", tt.query, tt.page, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueries(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		queries []string
+		wantErr bool
+	}{
+		{"palavras-chave", "gerente de vendas\n  CTO  \n\n", []string{"gerente de vendas", "CTO"}, false},
+		{"hashtag é query", "#OpenToWork\n# recrutador", []string{"#OpenToWork", "# recrutador"}, false},
+		{"urls de busca", "https://www.linkedin.com/search/results/people/?keywords=cto\nlinkedin.com/search/results/people/?keywords=rh",
+			[]string{"https://www.linkedin.com/search/results/people/?keywords=cto", "linkedin.com/search/results/people/?keywords=rh"}, false},
+		{"misto com CRLF", "vendas\r\nhttps://www.linkedin.com/search/results/people/?keywords=cto\r\n",
+			[]string{"vendas", "https://www.linkedin.com/search/results/people/?keywords=cto"}, false},
+		{"url que não é busca", "vendas\nhttps://www.linkedin.com/in/ana-souza", []string{"vendas"}, true},
+		{"vazio", "\n  \n", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQueries(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseQueries(%q) erro = %v, esperado erro %v", tt.text, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.queries) {
+				t.Errorf("ParseQueries(%q) = %q, esperado %q", tt.text, got, tt.queries)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package crawler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ScoringFile arquivo padrão do modelo de pontuação de leads
+const ScoringFile = "data/scoring.json"
+
+// Tipos de regra de pontuação
+const (
+	RuleTitle     = "title"     // palavra-chave no cargo ou na headline
+	RuleSeniority = "seniority" // nível de senioridade inferido do cargo
+	RuleCompany   = "company"   // palavra-chave na empresa
+	RuleLocation  = "location"  // palavra-chave na localização
+	RuleDegree    = "degree"    // grau de conexão (1, 2 ou 3 = 3º+)
+	RuleMutual    = "mutual"    // mínimo de conexões em comum
+)
+
+// Níveis de senioridade reconhecidos e palavras que os indicam
+var seniorityKeywords = []struct {
+	Level    string
+	Keywords []string
+}{
+	{"c-level", []string{"ceo", "cfo", "cto", "coo", "cmo", "cio", "chief", "founder", "co-founder", "cofounder",
+		"fundador", "fundadora", "co-fundador", "co-fundadora", "cofundador", "cofundadora", "socio-fundador", "socia-fundadora",
+		"socio", "socia", "owner", "presidente", "presidenta", "president"}},
+	{"vp", []string{"vp", "svp", "evp", "vice-president", "vice president", "vice-presidente", "vice presidente", "vice-presidenta", "vice presidenta"}},
+	{"director", []string{"diretor", "diretora", "director", "head"}},
+	{"manager", []string{"gerente", "manager", "coordenador", "coordenadora", "lead", "lider", "supervisor", "supervisora"}},
+	{"senior", []string{"senior", "sr", "especialista", "specialist", "principal", "staff"}},
+	{"entry", []string{"junior", "jr", "estagiario", "estagiaria", "intern", "trainee", "assistente", "assistant", "aprendiz"}},
+}
+
+// viceKeywords expressões de vp que contêm uma palavra de c-level
+var viceKeywords = []string{"vice-president", "vice president", "vice-presidente", "vice presidente", "vice-presidenta", "vice presidenta"}
+
+// ScoreRule regra ponderada de pontuação de leads
+type ScoreRule struct {
+	Name      string   `json:"name,omitempty"`
+	Type      string   `json:"type"`
+	Match     []string `json:"match,omitempty"`      // palavras-chave ou níveis de senioridade
+	Degree    int      `json:"degree,omitempty"`     // regra degree
+	MinMutual int      `json:"min_mutual,omitempty"` // regra mutual
+	Weight    float64  `json:"weight"`
+}
+
+// ScoringModel conjunto de regras e limites de admissão por pontuação
+type ScoringModel struct {
+	Rules []ScoreRule `json:"rules"`
+
+	// MinScore pontuação mínima para receber convite
+	MinScore float64 `json:"min_score"`
+
+	// Quando restarem LowBudget convites ou menos na semana, só contatos com
+	// pontuação >= LowBudgetMinScore são convidados (reserva o fim do
+	// orçamento semanal para os melhores leads). 0 desativa.
+	LowBudget         int     `json:"low_budget,omitempty"`
+	LowBudgetMinScore float64 `json:"low_budget_min_score,omitempty"`
+}
+
+// DefaultScoringModel modelo de exemplo exibido quando não há configuração salva
+func DefaultScoringModel() ScoringModel {
+	return ScoringModel{
+		Rules: []ScoreRule{
+			{Name: "decisor", Type: RuleSeniority, Match: []string{"c-level", "vp", "director"}, Weight: 5},
+			{Name: "gestor", Type: RuleSeniority, Match: []string{"manager"}, Weight: 3},
+			{Name: "área comercial", Type: RuleTitle, Match: []string{"vendas", "comercial", "sales"}, Weight: 2},
+			{Name: "estágio", Type: RuleSeniority, Match: []string{"entry"}, Weight: -4},
+			{Name: "São Paulo", Type: RuleLocation, Match: []string{"são paulo"}, Weight: 1},
+			{Name: "2º grau", Type: RuleDegree, Degree: 2, Weight: 1},
+			{Name: "conexões em comum", Type: RuleMutual, MinMutual: 5, Weight: 2},
+		},
+		MinScore: 0,
+	}
+}
+
+// ParseScoringModel interpreta e valida o JSON do modelo de pontuação
+func ParseScoringModel(content []byte) (*ScoringModel, error) {
+	var model ScoringModel
+	if err := json.Unmarshal(content, &model); err != nil {
+		return nil, fmt.Errorf("JSON inválido: %v", err)
+	}
+
+	for i, rule := range model.Rules {
+		switch rule.Type {
+		case RuleTitle, RuleCompany, RuleLocation:
+			if len(rule.Match) == 0 {
+				return nil, fmt.Errorf("regra %d (%s): informe as palavras em match", i+1, rule.Type)
+			}
+		case RuleSeniority:
+			for _, level := range rule.Match {
+				if !isSeniorityLevel(level) {
+					return nil, fmt.Errorf("regra %d: nível de senioridade desconhecido '%s'", i+1, level)
+				}
+			}
+		case RuleDegree:
+			if rule.Degree < 1 || rule.Degree > 3 {
+				return nil, fmt.Errorf("regra %d: degree deve ser 1, 2 ou 3", i+1)
+			}
+		case RuleMutual:
+			if rule.MinMutual < 1 {
+				return nil, fmt.Errorf("regra %d: min_mutual deve ser maior que zero", i+1)
+			}
+		default:
+			return nil, fmt.Errorf("regra %d: tipo desconhecido '%s'", i+1, rule.Type)
+		}
+	}
+	return &model, nil
+}
+
+// LoadScoringModel carrega o modelo do arquivo; retorna nil se ele não existe
+func LoadScoringModel(path string) (*ScoringModel, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao ler modelo de pontuação: %v", err)
+	}
+	return ParseScoringModel(content)
+}
+
+// SaveScoringModel grava o modelo de forma atômica (arquivo temporário + rename)
+func SaveScoringModel(path string, model *ScoringModel) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("erro ao criar diretório: %v", err)
+	}
+	content, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar modelo de pontuação: %v", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return fmt.Errorf("erro ao gravar modelo de pontuação: %v", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// Seniority infere o nível de senioridade do cargo ("" se não reconhecido).
+// Vale o nível mais alto encontrado.
+func Seniority(title string) string {
+	words := " " + strings.Join(strings.FieldsFunc(foldText(title), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-')
+	}), " ") + " "
+
+	// "Vice President" contém "president": as expressões com "vice" contam
+	// como vp e saem do texto antes da busca pelos níveis
+	vice := false
+	for _, kw := range viceKeywords {
+		if strings.Contains(words, " "+kw+" ") {
+			vice = true
+			words = strings.ReplaceAll(words, " "+kw+" ", " ")
+		}
+	}
+
+	for _, level := range seniorityKeywords {
+		if vice && level.Level == "vp" {
+			return level.Level
+		}
+		for _, kw := range level.Keywords {
+			if strings.Contains(words, " "+kw+" ") {
+				return level.Level
+			}
+		}
+	}
+	return ""
+}
+
+// Score calcula a pontuação do contato e as regras que dispararam
+func (m *ScoringModel) Score(c Contact) (float64, []string) {
+	if m == nil {
+		return 0, nil
+	}
+
+	var score float64
+	var fired []string
+	seniority := Seniority(c.Title)
+
+	for _, rule := range m.Rules {
+		hit := false
+		switch rule.Type {
+		case RuleTitle:
+			hit = containsAny(c.Title, rule.Match) || containsAny(c.Headline, rule.Match)
+		case RuleCompany:
+			hit = containsAny(c.Company, rule.Match)
+		case RuleLocation:
+			hit = containsAny(c.Location, rule.Match)
+		case RuleSeniority:
+			for _, level := range rule.Match {
+				if seniority != "" && strings.EqualFold(level, seniority) {
+					hit = true
+				}
+			}
+		case RuleDegree:
+			hit = c.Degree != 0 && c.Degree == rule.Degree
+		case RuleMutual:
+			hit = c.Mutual >= rule.MinMutual
+		}
+		if !hit {
+			continue
+		}
+
+		score += rule.Weight
+		fired = append(fired, rule.label())
+	}
+	return score, fired
+}
+
+// Admit aplica a reserva do fim do orçamento semanal (remaining = convites
+// ainda disponíveis na semana). MinScore é verificado pelo motor antes de
+// consultar CanInvite, inclusive quando não há callback.
+func (m *ScoringModel) Admit(score float64, remaining int) (bool, string) {
+	if m == nil {
+		return true, ""
+	}
+	if m.LowBudget > 0 && remaining <= m.LowBudget && score < m.LowBudgetMinScore {
+		return false, fmt.Sprintf("restam %d convites na semana, reservados a pontuação >= %.1f", remaining, m.LowBudgetMinScore)
+	}
+	return true, ""
+}
+
+// RankByScore ordena os índices dos contatos pela pontuação (maior primeiro),
+// mantendo a ordem da página entre empates
+func RankByScore(contacts []Contact) []int {
+	order := make([]int, len(contacts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return contacts[order[a]].Score > contacts[order[b]].Score
+	})
+	return order
+}
+
+// label nome da regra registrado junto ao contato
+func (r ScoreRule) label() string {
+	name := r.Name
+	if name == "" {
+		name = r.Type
+		if len(r.Match) > 0 {
+			name += ":" + strings.Join(r.Match, "/")
+		}
+	}
+	return fmt.Sprintf("%s(%+g)", name, r.Weight)
+}
+
+func isSeniorityLevel(level string) bool {
+	for _, s := range seniorityKeywords {
+		if strings.EqualFold(s.Level, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny indica se o texto contém alguma das palavras (sem diferenciar
+// maiúsculas nem acentos)
+func containsAny(text string, words []string) bool {
+	folded := foldText(text)
+	for _, w := range words {
+		if w = foldText(strings.TrimSpace(w)); w != "" && strings.Contains(folded, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// accentFold mapeia letras acentuadas para a forma sem acento
+var accentFold = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// foldText normaliza texto para comparação: minúsculas e sem acentos
+func foldText(s string) string {
+	return accentFold.Replace(strings.ToLower(s))
+}
+
+var (
+	rxDegree      = regexp.MustCompile(`[•·]\s*([123])\s*(?:º|°|st|nd|rd)`)
+	rxMutualOther = regexp.MustCompile(`(\d+)\s+(?:outras?|other)\b`)
+	rxMutualCount = regexp.MustCompile(`(\d+)`)
+)
+
+// ParseDegree extrai o grau de conexão do texto do card ("• 2º", "• 3rd+");
+// 0 se não encontrado
+func ParseDegree(text string) int {
+	if m := rxDegree.FindStringSubmatch(text); m != nil {
+		degree, _ := strconv.Atoi(m[1])
+		return degree
+	}
+	return 0
+}
+
+// ParseMutual extrai o número de conexões em comum do texto do card
+// ("Maria e 12 outras conexões em comum" = 13, "12 mutual connections" = 12,
+// "Maria é uma conexão em comum" = 1); 0 se não houver
+func ParseMutual(text string) int {
+	for _, line := range strings.Split(text, "\n") {
+		lower := strings.ToLower(line)
+		if !strings.Contains(lower, "em comum") && !strings.Contains(lower, "mutual connection") {
+			continue
+		}
+		if m := rxMutualOther.FindStringSubmatch(lower); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			return n + 1
+		}
+		if m := rxMutualCount.FindStringSubmatch(lower); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			return n
+		}
+		if strings.Contains(lower, " e ") || strings.Contains(lower, " and ") {
+			return 2
+		}
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,45 @@
+package crawler
+
+import "testing"
+
+func TestSeniority(t *testing.T) {
+	tests := []struct {
+		title string
+		level string
+	}{
+		{"CEO", "c-level"},
+		{"Chief Revenue Officer", "c-level"},
+		{"Presidente", "c-level"},
+		{"President & CEO", "c-level"},
+		{"Founder", "c-level"},
+		{"Co-Founder", "c-level"},
+		{"Cofounder", "c-level"},
+		{"Co-fundador", "c-level"},
+		{"Cofundadora", "c-level"},
+		{"Sócio-fundador", "c-level"},
+		{"Sócia", "c-level"},
+		{"Vice President of Sales", "vp"},
+		{"Vice-President", "vp"},
+		{"Vice Presidente Comercial", "vp"},
+		{"Vice-Presidente de Operações", "vp"},
+		{"VP Engineering", "vp"},
+		{"SVP, Marketing", "vp"},
+		{"Vice President & CEO", "c-level"},
+		{"Diretor Comercial", "director"},
+		{"Head of Growth", "director"},
+		{"Gerente de Vendas", "manager"},
+		{"Tech Lead", "manager"},
+		{"Analista Sênior", "senior"},
+		{"Estagiária de Marketing", "entry"},
+		{"Desenvolvedor", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := Seniority(tt.title); got != tt.level {
+				t.Errorf("Seniority(%q) = %q, esperado %q", tt.title, got, tt.level)
+			}
+		})
+	}
+}
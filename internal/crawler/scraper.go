@@ -88,7 +88,7 @@ func (s *Scraper) OpenSearch(query string) error {
 	log.Printf("Abrindo busca: %s", query)
 
 	// Construir URL de busca
-	searchURL := fmt.Sprintf("https://www.linkedin.com/search/results/people/?keywords=%s&origin=CLUSTER_EXPANSION", query)
+	searchURL := BuildSearchURL(query, 1)
 
 	err := chromedp.Run(s.ctx, chromedp.Navigate(searchURL))
 	if err != nil {
@@ -203,7 +203,6 @@ func (s *Scraper) CaptureVisibleAndConnect(maxConnects int) ([]Contact, int) {
 				// Buscar elementos específicos do LinkedIn
 				let title = '';
 				let company = '';
-				let location = '';
 				
 				// Buscar título e empresa usando seletores mais específicos
 				const titleElements = card.querySelectorAll('span, div, p');
@@ -348,25 +347,6 @@ func (s *Scraper) CaptureVisibleAndConnect(maxConnects int) ([]Contact, int) {
 					}
 				}
 				
-				// Buscar localização (geralmente no final do card)
-				const locationElements = card.querySelectorAll('span, div');
-				for (const elem of locationElements) {
-					const text = elem.textContent.trim();
-					if (text && /%s/i.test(text) && text.length < 50) {
-						// Filtrar status messages e texto irrelevante
-						if (!text.includes('status') && 
-							!text.includes('off-line') && 
-							!text.includes('online') &&
-							!text.includes('O status está') &&
-							!text.includes('Ver perfil') &&
-							!text.includes('Conectar') &&
-							!text.includes('Connect')) {
-							location = text;
-							break;
-						}
-					}
-				}
-				
 				// Fallback: se não encontrou título, usar primeira linha relevante
 				if (!title) {
 					const allText = card.textContent.split('\\n').map(t => t.trim()).filter(t => 
@@ -409,36 +389,18 @@ func (s *Scraper) CaptureVisibleAndConnect(maxConnects int) ([]Contact, int) {
 					}
 				}
 				
-				// Fallback para localização: buscar por padrões específicos de localização
-				if (!location) {
-					const allText = card.textContent.split('\\n').map(t => t.trim()).filter(t => 
-						t && t !== name && t !== title && t !== company && t.length > 3 && t.length < 50 &&
-						/%s/i.test(t) &&
-						!t.includes('status') && 
-						!t.includes('off-line') && 
-						!t.includes('online') &&
-						!t.includes('O status está') &&
-						!t.includes('Ver perfil') &&
-						!t.includes('Conectar') &&
-						!t.includes('Connect')
-					);
-					if (allText.length > 0) {
-						location = allText[0];
-					}
-				}
-				
 				contacts.push({
 					name: name,
 					title: title,
 					company: company,
-					location: location,
-					linkedin: linkedin
+					linkedin: linkedin,
+					text: card.innerText
 				});
 			}
 			
 			return contacts;
 		})()
-			`, SelCardNew, 60, RxLocation)
+			`, SelCardNew, 60)
 
 	var rawContacts []map[string]interface{}
 	err := chromedp.Run(s.ctx, chromedp.Evaluate(js, &rawContacts))
@@ -453,9 +415,10 @@ func (s *Scraper) CaptureVisibleAndConnect(maxConnects int) ([]Contact, int) {
 			Name:     getString(raw, "name"),
 			Title:    getString(raw, "title"),
 			Company:  getString(raw, "company"),
-			Location: getString(raw, "location"),
 			LinkedIn: NormalizeProfileURL(getString(raw, "linkedin")),
 		}
+		contact.Location, _ = PickLocation(getString(raw, "text"), contact.Name, contact.Title, contact.Company)
+		contact.normalize()
 		contacts = append(contacts, contact)
 	}
 
@@ -12,6 +12,41 @@ var (
 	RxConnectLabels = []string{`^conectar$`, `^connect$`}
 	RxSendLabels    = []string{`^enviar$`, `^send$`, `^enviar agora$`}
 
-	// heurística de localização (UF/BR)
-	RxLocation = `,\s*[A-Z]{2}\b|Brasil|Brazil|SP|RJ|CE|PE|PR|SC|RS|MG|BA|DF|GO|ES|AM|PA`
+	// página de perfil (cabeçalho)
+	SelProfileTopCard  = `main section` // primeira seção do main é o top card
+	SelProfileName     = `h1`
+	SelProfileHeadline = `div.text-body-medium`
+	SelProfileLocation = `span.text-body-small.inline`
+
+	// rótulos de ações no perfil
+	RxInviteAria    = `^(convidar|invite) .* (para se conectar|to connect)`
+	RxMoreLabels    = []string{`^mais$`, `^more$`, `^mais ações$`, `^more actions$`}
+	RxPendingLabels = []string{`^pendente$`, `^pending$`}
+	RxSendNoteLabel = `^(enviar sem nota|send without a note|enviar|send|enviar agora)$`
+
+	// aba Pessoas da empresa
+	SelCompanyPeopleCard = `li.org-people-profile-card__profile-card-spacing, div.org-people-profile-card`
+	SelCompanyCardTitle  = `.artdeco-entity-lockup__title`
+	SelCompanyCardSub    = `.artdeco-entity-lockup__subtitle`
+	RxShowMoreLabels     = []string{`exibir mais resultados`, `show more results`, `mostrar mais resultados`}
+
+	// gerenciador de convites enviados
+	SelSentInvitationCard  = `li.invitation-card, li.mn-invitation-list__item`
+	SelSentInvitationTitle = `.invitation-card__title, .invitation-card__tvm-title`
+	SelSentInvitationSub   = `.invitation-card__subtitle`
+	RxSentAgeLine          = `^(enviad[oa]|sent)\b`
+	RxWithdrawLabels       = []string{`^retirar$`, `^withdraw$`}
+
+	// lista de conexões (mais recentes primeiro)
+	SelConnectionCard       = `li.mn-connection-card`
+	SelConnectionName       = `.mn-connection-card__name`
+	SelConnectionOccupation = `.mn-connection-card__occupation`
+	SelConnectionTime       = `time, .time-badge`
+
+	// mensagens (janela de conversa aberta a partir do perfil)
+	RxMessageLabels     = []string{`^mensagem$`, `^message$`, `^enviar mensagem$`}
+	SelMessageEditor    = `div.msg-form__contenteditable[contenteditable="true"]`
+	SelMessageSend      = `button.msg-form__send-button`
+	SelMessageOther     = `.msg-s-event-listitem--other`
+	SelConversationExit = `button.msg-overlay-bubble-header__control--close-btn, button[data-control-name="overlay.close_conversation_window"]`
 )
@@ -0,0 +1,176 @@
+package crawler
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Resultados de cada contato que chegou à etapa de convite
+const (
+	AttemptSent        = "sent"        // convite enviado
+	AttemptPending     = "pending"     // já havia convite pendente
+	AttemptUnavailable = "unavailable" // botão Conectar não disponível
+	AttemptFailed      = "failed"      // clique feito, mas o modal não foi confirmado
+	AttemptSkipped     = "skipped"     // pulado antes do clique (pontuação ou CanInvite)
+	AttemptLimit       = "limit"       // sem vaga no limite de convites da conta
+)
+
+// AttemptOutcomes ordem e descrição dos resultados (relatório da execução)
+var AttemptOutcomes = []struct {
+	ID    string
+	Title string
+}{
+	{AttemptSent, "Enviados"},
+	{AttemptPending, "Pendentes"},
+	{AttemptUnavailable, "Indisponíveis"},
+	{AttemptFailed, "Falhas"},
+	{AttemptSkipped, "Pulados"},
+	{AttemptLimit, "Sem vaga"},
+}
+
+// maxWarnings avisos guardados por execução (os seguintes só vão para o log)
+const maxWarnings = 200
+
+// QueryStats contadores de uma query (slug da empresa no modo company; vazio
+// no modo profiles, que conta a lista inteira)
+type QueryStats struct {
+	Query    string         `json:"query"`
+	Cards    int            `json:"cards"`              // cards (ou perfis) encontrados
+	Captured int            `json:"captured"`           // contatos capturados
+	Attempts map[string]int `json:"attempts,omitempty"` // resultado → quantidade (Attempt*)
+	Error    string         `json:"error,omitempty"`    // erro que interrompeu a query
+}
+
+// RunStats contadores por query e avisos de uma execução do motor
+type RunStats struct {
+	Queries  []QueryStats `json:"queries"`
+	Warnings []string     `json:"warnings,omitempty"`
+}
+
+// Totals soma os contadores de todas as queries
+func (s RunStats) Totals() QueryStats {
+	total := QueryStats{Attempts: map[string]int{}}
+	for _, q := range s.Queries {
+		total.Cards += q.Cards
+		total.Captured += q.Captured
+		for outcome, n := range q.Attempts {
+			total.Attempts[outcome] += n
+		}
+	}
+	return total
+}
+
+// statsRecorder acumula os contadores da execução (lidos por Engine.Stats)
+type statsRecorder struct {
+	mu    sync.Mutex
+	stats RunStats
+}
+
+// query retorna os contadores da query, criando-os na primeira vez (r.mu deve estar travado)
+func (r *statsRecorder) query(query string) *QueryStats {
+	for i := range r.stats.Queries {
+		if r.stats.Queries[i].Query == query {
+			return &r.stats.Queries[i]
+		}
+	}
+	r.stats.Queries = append(r.stats.Queries, QueryStats{Query: query, Attempts: map[string]int{}})
+	return &r.stats.Queries[len(r.stats.Queries)-1]
+}
+
+// cards soma cards encontrados na query
+func (r *statsRecorder) cards(query string, n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.query(query).Cards += n
+}
+
+// captured conta um contato capturado na query
+func (r *statsRecorder) captured(query string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.query(query).Captured++
+}
+
+// attempt conta um resultado de convite na query
+func (r *statsRecorder) attempt(query, outcome string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.query(query).Attempts[outcome]++
+}
+
+// fail registra o erro que interrompeu a query
+func (r *statsRecorder) fail(query string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.query(query).Error = err.Error()
+}
+
+// warn guarda um aviso da execução
+func (r *statsRecorder) warn(msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.stats.Warnings) < maxWarnings {
+		r.stats.Warnings = append(r.stats.Warnings, msg)
+	}
+}
+
+// snapshot cópia dos contadores
+func (r *statsRecorder) snapshot() RunStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := RunStats{Warnings: append([]string(nil), r.stats.Warnings...)}
+	for _, q := range r.stats.Queries {
+		attempts := make(map[string]int, len(q.Attempts))
+		for k, v := range q.Attempts {
+			attempts[k] = v
+		}
+		q.Attempts = attempts
+		out.Queries = append(out.Queries, q)
+	}
+	return out
+}
+
+// Stats contadores por query e avisos da execução (pode ser chamado durante
+// ou depois de Run)
+func (e *Engine) Stats() RunStats {
+	return e.stats.snapshot()
+}
+
+// warn envia o aviso ao log e o guarda no relatório da execução
+func (e *Engine) warn(callbacks Callbacks, msg string) {
+	e.stats.warn(msg)
+	callbacks.OnLog(msg)
+}
+
+// capture conta e repassa o contato capturado
+func (e *Engine) capture(callbacks Callbacks, contact Contact) {
+	e.stats.captured(contact.Query)
+	callbacks.OnCaptured(contact)
+}
+
+// inviteSent registra o convite enviado e confirma a vaga; se o registro
+// falhar, a vaga continua ocupada para que o convite já enviado conte no limite
+func (e *Engine) inviteSent(callbacks Callbacks, slot InviteSlot, contact Contact) {
+	if err := callbacks.OnInviteSent(contact); err != nil {
+		e.warn(callbacks, fmt.Sprintf("Convite para %s enviado mas não registrado (vaga mantida ocupada): %v", contact.Name, err))
+		if err := slot.Keep(); err != nil {
+			e.warn(callbacks, fmt.Sprintf("Erro ao atualizar reserva de convite: %v", err))
+		}
+		return
+	}
+	e.finishSlot(callbacks, slot, true)
+}
+
+// finishSlot confirma (sent) ou devolve a vaga reservada para o convite
+func (e *Engine) finishSlot(callbacks Callbacks, slot InviteSlot, sent bool) {
+	var err error
+	if sent {
+		err = slot.Commit()
+	} else {
+		err = slot.Release()
+	}
+	if err != nil {
+		e.warn(callbacks, fmt.Sprintf("Erro ao atualizar reserva de convite: %v", err))
+	}
+}
@@ -0,0 +1,196 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// connectionsURL lista de conexões da conta, ordenada pelas mais recentes
+const connectionsURL = "https://www.linkedin.com/mynetwork/invite-connect/connections/"
+
+// Connection conexão listada na página de conexões
+type Connection struct {
+	Name          string
+	Headline      string
+	LinkedIn      string
+	ConnectedText string    // texto exibido ("Conectado há 2 dias", "Connected on October 12, 2026")
+	ConnectedAt   time.Time // zero se o texto não foi reconhecido
+}
+
+// SyncConfig configuração da leitura de conexões e convites enviados
+type SyncConfig struct {
+	MaxConnections int // conexões recentes a carregar (padrão 200)
+	MaxSentPages   int // páginas de convites enviados a percorrer (padrão 20)
+	Headless       bool
+}
+
+// SyncResult estado lido do LinkedIn para sincronizar o status dos convites
+type SyncResult struct {
+	Connections []Connection
+	Pending     []SentInvitation
+	// PendingComplete indica que a lista de convites enviados foi lida até o fim
+	PendingComplete bool
+	// OldestConnection data da conexão mais antiga lida (zero se desconhecida);
+	// convites anteriores a ela podem ter sido aceitos fora da janela lida
+	OldestConnection time.Time
+}
+
+var (
+	rxDatePT = regexp.MustCompile(`(\d{1,2}) de ([a-zç]+)\.? de (\d{4})`)
+	rxDateEN = regexp.MustCompile(`([a-z]+)\.? (\d{1,2}), (\d{4})`)
+)
+
+// monthPrefixes meses pelas três primeiras letras (português e inglês)
+var monthPrefixes = map[string]time.Month{
+	"jan": time.January, "fev": time.February, "feb": time.February, "mar": time.March,
+	"abr": time.April, "apr": time.April, "mai": time.May, "may": time.May,
+	"jun": time.June, "jul": time.July, "ago": time.August, "aug": time.August,
+	"set": time.September, "sep": time.September, "out": time.October, "oct": time.October,
+	"nov": time.November, "dez": time.December, "dec": time.December,
+}
+
+// ParseConnectedDate interpreta a data de conexão exibida no card, absoluta
+// ("Conectado em 12 de outubro de 2026", "Connected on October 12, 2026")
+// ou relativa ("Conectado há 2 dias")
+func ParseConnectedDate(text string, now time.Time) (time.Time, bool) {
+	lower := strings.ToLower(strings.TrimSpace(text))
+
+	if m := rxDatePT.FindStringSubmatch(lower); m != nil {
+		if t, ok := buildDate(m[3], m[2], m[1], now.Location()); ok {
+			return t, true
+		}
+	}
+	if m := rxDateEN.FindStringSubmatch(lower); m != nil {
+		if t, ok := buildDate(m[3], m[1], m[2], now.Location()); ok {
+			return t, true
+		}
+	}
+	return ParseSentAge(lower, now)
+}
+
+// buildDate monta a data a partir de ano, nome do mês e dia
+func buildDate(year, month, day string, loc *time.Location) (time.Time, bool) {
+	if len([]rune(month)) < 3 {
+		return time.Time{}, false
+	}
+	m, ok := monthPrefixes[string([]rune(month)[:3])]
+	if !ok {
+		return time.Time{}, false
+	}
+	y, err1 := strconv.Atoi(year)
+	d, err2 := strconv.Atoi(day)
+	if err1 != nil || err2 != nil || d < 1 || d > 31 {
+		return time.Time{}, false
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, loc), true
+}
+
+// SyncInvitations lê as conexões recentes e todos os convites enviados ainda
+// pendentes, para que o chamador atualize o status dos convites registrados
+func (e *Engine) SyncInvitations(cfg SyncConfig, creds Creds, logf func(string)) (SyncResult, error) {
+	defer e.cancel()
+
+	ctx, closeSession, err := e.startSession(cfg.Headless, creds, logf)
+	if err != nil {
+		return SyncResult{}, err
+	}
+	defer closeSession()
+
+	now := time.Now()
+	var result SyncResult
+
+	// Conexões recentes
+	result.Connections, err = e.listConnections(ctx, cfg.MaxConnections, now, logf)
+	if err != nil {
+		return result, err
+	}
+	for _, conn := range result.Connections {
+		if !conn.ConnectedAt.IsZero() && (result.OldestConnection.IsZero() || conn.ConnectedAt.Before(result.OldestConnection)) {
+			result.OldestConnection = conn.ConnectedAt
+		}
+	}
+	logf(fmt.Sprintf("Conexões recentes lidas: %d", len(result.Connections)))
+
+	// Convites enviados ainda pendentes
+	maxPages := cfg.MaxSentPages
+	if maxPages <= 0 {
+		maxPages = 20
+	}
+	for page := 1; page <= maxPages; page++ {
+		invitations, err := e.listSentInvitations(ctx, page, now)
+		if err != nil {
+			return result, err
+		}
+		if len(invitations) == 0 {
+			result.PendingComplete = true
+			break
+		}
+		result.Pending = append(result.Pending, invitations...)
+	}
+	logf(fmt.Sprintf("Convites pendentes lidos: %d (lista completa: %v)", len(result.Pending), result.PendingComplete))
+
+	return result, nil
+}
+
+// listConnections carrega as conexões mais recentes até o limite
+func (e *Engine) listConnections(ctx context.Context, limit int, now time.Time, logf func(string)) ([]Connection, error) {
+	if limit <= 0 {
+		limit = 200
+	}
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(connectionsURL)); err != nil {
+		return nil, err
+	}
+	if err := chromedp.Run(ctx, chromedp.WaitReady("main")); err != nil {
+		return nil, err
+	}
+	time.Sleep(2 * time.Second)
+
+	e.loadMoreResults(ctx, SelConnectionCard, limit, logf)
+
+	var result []map[string]interface{}
+	err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(() => {
+			const cards = document.querySelectorAll('%s');
+			const results = [];
+			for (let i = 0; i < cards.length && results.length < %d; i++) {
+				const card = cards[i];
+				const link = card.querySelector('a[href*="/in/"]');
+				if (!link) continue;
+
+				const name = card.querySelector('%s');
+				const occupation = card.querySelector('%s');
+				const time = card.querySelector('%s');
+				results.push({
+					name: (name ? name.innerText : link.innerText).trim(),
+					headline: occupation ? occupation.innerText.trim() : '',
+					linkedin_url: link.href,
+					connected: time ? time.innerText.trim() : ''
+				});
+			}
+			return results;
+		})()
+	`, SelConnectionCard, limit, SelConnectionName, SelConnectionOccupation, SelConnectionTime), &result))
+	if err != nil {
+		return nil, err
+	}
+
+	connections := make([]Connection, 0, len(result))
+	for _, raw := range result {
+		conn := Connection{
+			Name:          getString(raw, "name"),
+			Headline:      getString(raw, "headline"),
+			LinkedIn:      NormalizeProfileURL(getString(raw, "linkedin_url")),
+			ConnectedText: getString(raw, "connected"),
+		}
+		conn.ConnectedAt, _ = ParseConnectedDate(conn.ConnectedText, now)
+		connections = append(connections, conn)
+	}
+	return connections, nil
+}
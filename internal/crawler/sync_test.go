@@ -0,0 +1,36 @@
+package crawler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseConnectedDate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		text string
+		want time.Time
+		ok   bool
+	}{
+		{"Conectado em 12 de outubro de 2026", time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), true},
+		{"Connected on October 12, 2026", time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), true},
+		{"Connected on Sept. 3, 2025", time.Date(2025, 9, 3, 0, 0, 0, 0, time.UTC), true},
+		{"Conectado há 3 horas", now.Add(-3 * time.Hour), true},
+		{"Connected 3 hours ago", now.Add(-3 * time.Hour), true},
+		{"Connected an hour ago", now.Add(-time.Hour), true},
+		{"Conectado há uma hora", now.Add(-time.Hour), true},
+		{"Conectado há 2 dias", now.AddDate(0, 0, -2), true},
+		{"Connected 2 weeks ago", now.AddDate(0, 0, -14), true},
+		{"Mensagem", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := ParseConnectedDate(tt.text, now)
+			if ok != tt.ok || !got.Equal(tt.want) {
+				t.Errorf("ParseConnectedDate(%q) = (%v, %v), esperado (%v, %v)", tt.text, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
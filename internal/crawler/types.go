@@ -4,11 +4,25 @@ import "time"
 
 // Contact representa um perfil capturado
 type Contact struct {
-	Name     string `json:"name"`
-	Title    string `json:"title"`
-	Company  string `json:"company"`
-	Location string `json:"location"`
-	LinkedIn string `json:"linkedin_url"`
+	Name      string `json:"name"` // nome limpo (ParseName)
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	RawName   string `json:"raw_name,omitempty"` // texto original do link/título (auditoria)
+	Title     string `json:"title"`
+	Company   string `json:"company"`
+	Headline  string `json:"headline,omitempty"` // headline bruta de onde cargo e empresa foram extraídos
+	Location  string `json:"location"`
+	City      string `json:"city,omitempty"`    // localização estruturada (NormalizeLocation)
+	Region    string `json:"region,omitempty"`  // UF/estado
+	Country   string `json:"country,omitempty"` // código do país (BR, PT, US)
+	LinkedIn  string `json:"linkedin_url"`
+	Query     string `json:"query,omitempty"`  // query (palavras-chave ou URL) ou empresa que trouxe o perfil
+	Source    string `json:"source,omitempty"` // modo de origem: search, profiles ou company
+
+	Degree     int      `json:"degree,omitempty"` // grau de conexão (0 = desconhecido)
+	Mutual     int      `json:"mutual,omitempty"` // conexões em comum
+	Score      float64  `json:"score"`
+	ScoreRules []string `json:"score_rules,omitempty"` // regras de pontuação que dispararam
 }
 
 // Creds representa credenciais do LinkedIn
@@ -17,29 +31,160 @@ type Creds struct {
 	Password string `json:"password"`
 }
 
+// Modos de execução do crawler
+const (
+	ModeSearch   = "search"   // queries de busca de pessoas (padrão)
+	ModeProfiles = "profiles" // lista direta de URLs de perfil (/in/)
+	ModeCompany  = "company"  // aba Pessoas da página de uma empresa
+)
+
 // RunConfig configuração para execução do crawler
 type RunConfig struct {
+	Mode               string   `json:"mode"`
 	MaxCardsRead       int      `json:"max_cards"`
 	MaxConnectsPerPage int      `json:"max_connects"`
-	Queries            []string `json:"queries"`
+	MaxInvites         int      `json:"max_invites"` // total por execução (0 = sem limite)
+	Queries            []string `json:"queries"`     // palavras-chave ou URLs de busca de pessoas
+	MaxPages           int      `json:"max_pages"`
+	Profiles           []string `json:"profiles"` // URLs de perfil (modo profiles)
+	Company            string   `json:"company"`  // URL ou slug da empresa (modo company)
+	CompanyKeywords    string   `json:"company_keywords"`
+	CompanyLocation    string   `json:"company_location"`
 	Headless           bool     `json:"headless"`
+
+	// Scoring modelo de pontuação de leads (carregado de data/scoring.json a
+	// cada execução); nil = convida na ordem da página
+	Scoring *ScoringModel `json:"-"`
 }
 
 // Callbacks para integração com a UI
 type Callbacks struct {
-	OnCaptured   func(c Contact) // incrementa captured_session via SSE
-	OnInviteSent func(c Contact) // grava CSV + atualiza invites_week
+	OnCaptured   func(c Contact)       // incrementa captured_session via SSE
+	OnInviteSent func(c Contact) error // grava CSV + atualiza invites_week; erro = convite não registrado
 	OnLog        func(line string)
+
+	// CanInvite é consultado antes de clicar em Conectar (limites e supressão).
+	// Opcional: se nil, todo contato pode ser convidado.
+	CanInvite func(c Contact) (bool, string)
+
+	// ReserveInvite reserva uma vaga no limite de convites da conta logo antes
+	// do clique em Conectar; sem vaga retorna nil e o motivo. O motor chama
+	// OnInviteSent e depois Commit quando o convite é enviado, ou Release
+	// quando não é. Se OnInviteSent falhar, chama Keep: o convite já enviado
+	// continua ocupando a vaga. Opcional: se nil, não há reserva.
+	ReserveInvite func(c Contact) (InviteSlot, string)
 }
 
+// InviteSlot vaga reservada para um convite (ver Callbacks.ReserveInvite)
+type InviteSlot interface {
+	Commit() error  // convite enviado e registrado
+	Keep() error    // convite enviado mas não registrado; a vaga continua ocupada
+	Release() error // convite não enviado; devolve a vaga
+}
+
+// canInvite aplica o callback opcional CanInvite
+func (cb Callbacks) canInvite(c Contact) (bool, string) {
+	if cb.CanInvite == nil {
+		return true, ""
+	}
+	return cb.CanInvite(c)
+}
+
+// noSlot vaga usada quando não há ReserveInvite
+type noSlot struct{}
+
+func (noSlot) Commit() error  { return nil }
+func (noSlot) Keep() error    { return nil }
+func (noSlot) Release() error { return nil }
+
+// reserveInvite aplica o callback opcional ReserveInvite
+func (cb Callbacks) reserveInvite(c Contact) (InviteSlot, string) {
+	if cb.ReserveInvite == nil {
+		return noSlot{}, ""
+	}
+	return cb.ReserveInvite(c)
+}
+
+// Status de um convite registrado
+const (
+	InviteStatusPending   = "pending"   // aguardando resposta (padrão)
+	InviteStatusAccepted  = "accepted"  // o perfil aparece nas conexões da conta
+	InviteStatusWithdrawn = "withdrawn" // retirado pelo gerenciador de convites enviados
+	InviteStatusExpired   = "expired"   // sumiu dos convites enviados sem virar conexão
+)
+
 // InviteRecord registro de convite enviado
 type InviteRecord struct {
 	Timestamp    time.Time `json:"timestamp"`
 	UserEmail    string    `json:"user_email"`
 	ProfileName  string    `json:"profile_name"`
+	FirstName    string    `json:"first_name,omitempty"`
+	LastName     string    `json:"last_name,omitempty"`
+	RawName      string    `json:"raw_name,omitempty"`
 	ProfileTitle string    `json:"profile_title"`
 	Company      string    `json:"company"`
 	Location     string    `json:"location"`
+	City         string    `json:"city,omitempty"`
+	Region       string    `json:"region,omitempty"`
+	Country      string    `json:"country,omitempty"`
 	LinkedInURL  string    `json:"linkedin_url"`
 	Query        string    `json:"query"`
+	Source       string    `json:"source"`
+	Status       string    `json:"status"`
+	Score        float64   `json:"score"`
+	ScoreRules   []string  `json:"score_rules,omitempty"`
+	AcceptedAt   time.Time `json:"accepted_at,omitempty"`
+	WithdrawnAt  time.Time `json:"withdrawn_at,omitempty"`
+	ExpiredAt    time.Time `json:"expired_at,omitempty"`
+}
+
+// NewInviteRecord registro do convite enviado agora pela conta ao contato
+func NewInviteRecord(account string, contact Contact) InviteRecord {
+	return InviteRecord{
+		Timestamp:    time.Now(),
+		UserEmail:    account,
+		ProfileName:  contact.Name,
+		FirstName:    contact.FirstName,
+		LastName:     contact.LastName,
+		RawName:      contact.RawName,
+		ProfileTitle: contact.Title,
+		Company:      contact.Company,
+		Location:     contact.Location,
+		City:         contact.City,
+		Region:       contact.Region,
+		Country:      contact.Country,
+		LinkedInURL:  contact.LinkedIn,
+		Query:        contact.Query,
+		Source:       contact.Source,
+		Score:        contact.Score,
+		ScoreRules:   contact.ScoreRules,
+	}
+}
+
+// Status de uma mensagem de follow-up registrada
+const (
+	MessageStatusSent    = "sent"    // mensagem enviada
+	MessageStatusFailed  = "failed"  // falha no envio (campo Error)
+	MessageStatusReplied = "replied" // resposta detectada; sequência encerrada
+)
+
+// MessageRecord mensagem de follow-up registrada para um contato
+type MessageRecord struct {
+	Timestamp   time.Time `json:"timestamp"`
+	UserEmail   string    `json:"user_email"`
+	LinkedInURL string    `json:"linkedin_url"`
+	ProfileName string    `json:"profile_name"`
+	SequenceID  string    `json:"sequence_id"`
+	Step        int       `json:"step"` // etapa da sequência (a partir de 1)
+	Status      string    `json:"status"`
+	Text        string    `json:"text"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// CaptureRecord contato capturado por uma conta em uma execução
+type CaptureRecord struct {
+	Timestamp time.Time `json:"timestamp"`
+	UserEmail string    `json:"user_email"`
+	RunID     string    `json:"run_id,omitempty"` // execução (job) que capturou
+	Contact
 }
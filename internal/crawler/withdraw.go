@@ -0,0 +1,278 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// sentInvitationsURL página "Convites enviados" do gerenciador de convites
+const sentInvitationsURL = "https://www.linkedin.com/mynetwork/invitation-manager/sent/"
+
+// SentInvitation convite pendente listado no gerenciador de convites enviados
+type SentInvitation struct {
+	Name     string
+	Headline string
+	LinkedIn string
+	SentText string    // texto exibido pelo LinkedIn ("Enviado há 3 semanas")
+	SentAt   time.Time // data estimada a partir de SentText (zero se não reconhecida)
+	index    int
+}
+
+// WithdrawConfig configuração da retirada de convites pendentes
+type WithdrawConfig struct {
+	OlderThan      time.Duration // idade mínima do convite para ser retirado
+	MaxWithdrawals int           // total por execução (0 = sem limite)
+	MaxPages       int           // páginas do gerenciador a percorrer
+	Headless       bool
+}
+
+// WithdrawCallbacks integração da retirada com quem a executa
+type WithdrawCallbacks struct {
+	// ShouldWithdraw aplica filtros adicionais (query, empresa) a convites
+	// que já passaram do limite de idade. Opcional: se nil, todos são retirados.
+	ShouldWithdraw func(inv SentInvitation) bool
+	OnWithdrawn    func(inv SentInvitation)
+	OnLog          func(line string)
+}
+
+var (
+	rxSentAgeAmount = regexp.MustCompile(`(\d+)\s*(segundos?|seconds?|minutos?|minutes?|horas?|hours?|dias?|days?|semanas?|weeks?|m[eê]s|meses|months?|anos?|years?)`)
+	rxSentAgeOne    = regexp.MustCompile(`\b(um|uma|a|an)\s+(minuto|minute|hora|hour|dia|day|semana|week|m[eê]s|month|ano|year)\b`)
+)
+
+// ParseSentAge estima quando o convite foi enviado a partir do texto relativo
+// do LinkedIn ("Enviado há 3 semanas", "Sent 2 months ago", "Enviado hoje")
+func ParseSentAge(text string, now time.Time) (time.Time, bool) {
+	lower := strings.ToLower(strings.TrimSpace(text))
+	if lower == "" {
+		return time.Time{}, false
+	}
+
+	if strings.Contains(lower, "ontem") || strings.Contains(lower, "yesterday") {
+		return now.AddDate(0, 0, -1), true
+	}
+	if strings.Contains(lower, "hoje") || strings.Contains(lower, "today") || strings.Contains(lower, "agora") || strings.Contains(lower, "just now") {
+		return now, true
+	}
+
+	amount, unit := 0, ""
+	if m := rxSentAgeAmount.FindStringSubmatch(lower); m != nil {
+		amount, _ = strconv.Atoi(m[1])
+		unit = m[2]
+	} else if m := rxSentAgeOne.FindStringSubmatch(lower); m != nil {
+		amount, unit = 1, m[2]
+	} else {
+		return time.Time{}, false
+	}
+
+	switch {
+	case strings.HasPrefix(unit, "seg") || strings.HasPrefix(unit, "sec"):
+		return now.Add(-time.Duration(amount) * time.Second), true
+	case strings.HasPrefix(unit, "minut"):
+		return now.Add(-time.Duration(amount) * time.Minute), true
+	case strings.HasPrefix(unit, "hor") || strings.HasPrefix(unit, "hou"):
+		return now.Add(-time.Duration(amount) * time.Hour), true
+	case strings.HasPrefix(unit, "dia") || strings.HasPrefix(unit, "day"):
+		return now.AddDate(0, 0, -amount), true
+	case strings.HasPrefix(unit, "semana") || strings.HasPrefix(unit, "week"):
+		return now.AddDate(0, 0, -7*amount), true
+	case strings.HasPrefix(unit, "mês") || strings.HasPrefix(unit, "mes") || strings.HasPrefix(unit, "month"):
+		return now.AddDate(0, -amount, 0), true
+	case strings.HasPrefix(unit, "ano") || strings.HasPrefix(unit, "year"):
+		return now.AddDate(-amount, 0, 0), true
+	default:
+		// Unidade desconhecida: melhor não estimar do que tratar como antigo
+		return time.Time{}, false
+	}
+}
+
+// Withdraw abre o gerenciador de convites enviados e retira os convites
+// pendentes mais antigos que cfg.OlderThan. Retorna quantos foram retirados.
+func (e *Engine) Withdraw(cfg WithdrawConfig, creds Creds, callbacks WithdrawCallbacks) (int, error) {
+	defer e.cancel()
+
+	ctx, closeSession, err := e.startSession(cfg.Headless, creds, callbacks.OnLog)
+	if err != nil {
+		return 0, err
+	}
+	defer closeSession()
+
+	maxPages := cfg.MaxPages
+	if maxPages <= 0 {
+		maxPages = 20
+	}
+
+	withdrawn := 0
+	attempted := map[string]bool{}
+	now := time.Now()
+
+	for page := 1; page <= maxPages; page++ {
+		invitations, err := e.listSentInvitations(ctx, page, now)
+		if err != nil {
+			return withdrawn, err
+		}
+		if len(invitations) == 0 {
+			callbacks.OnLog(fmt.Sprintf("Página %d sem convites pendentes - fim da lista", page))
+			break
+		}
+		callbacks.OnLog(fmt.Sprintf("Página %d: %d convites pendentes", page, len(invitations)))
+
+		withdrawnHere := 0
+		for _, inv := range invitations {
+			if cfg.MaxWithdrawals > 0 && withdrawn >= cfg.MaxWithdrawals {
+				callbacks.OnLog(fmt.Sprintf("Limite de %d retiradas atingido", cfg.MaxWithdrawals))
+				return withdrawn, nil
+			}
+
+			key := strings.ToLower(inv.LinkedIn)
+			if attempted[key] {
+				continue
+			}
+			if inv.SentAt.IsZero() {
+				callbacks.OnLog(fmt.Sprintf("Idade não reconhecida para %s (%q) - mantido", inv.Name, inv.SentText))
+				continue
+			}
+			if now.Sub(inv.SentAt) < cfg.OlderThan {
+				continue
+			}
+			if callbacks.ShouldWithdraw != nil && !callbacks.ShouldWithdraw(inv) {
+				continue
+			}
+
+			attempted[key] = true
+			if !e.withdrawInvitation(ctx, inv.index, callbacks) {
+				callbacks.OnLog(fmt.Sprintf("Não foi possível retirar o convite de %s", inv.Name))
+				continue
+			}
+
+			withdrawn++
+			withdrawnHere++
+			callbacks.OnLog(fmt.Sprintf("↩️ Convite retirado: %s (%s)", inv.Name, inv.SentText))
+			if callbacks.OnWithdrawn != nil {
+				callbacks.OnWithdrawn(inv)
+			}
+
+			// Jitter entre retiradas
+			time.Sleep(time.Duration(800+time.Now().UnixNano()%1200) * time.Millisecond)
+		}
+
+		// Retiradas deslocam a paginação: reler a mesma página
+		if withdrawnHere > 0 {
+			page--
+		}
+	}
+
+	return withdrawn, nil
+}
+
+// listSentInvitations carrega uma página do gerenciador e marca cada card com data-sel
+func (e *Engine) listSentInvitations(ctx context.Context, page int, now time.Time) ([]SentInvitation, error) {
+	pageURL := sentInvitationsURL
+	if page > 1 {
+		pageURL += "?page=" + strconv.Itoa(page)
+	}
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(pageURL)); err != nil {
+		return nil, err
+	}
+	if err := chromedp.Run(ctx, chromedp.WaitReady("main")); err != nil {
+		return nil, err
+	}
+	time.Sleep(2 * time.Second)
+
+	var result []map[string]interface{}
+	err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(() => {
+			const cards = document.querySelectorAll('%s');
+			const results = [];
+			for (let i = 0; i < cards.length; i++) {
+				const card = cards[i];
+				const link = card.querySelector('a[href*="/in/"]');
+				if (!link) continue;
+
+				card.setAttribute('data-sel', 'sent-inv-' + i);
+				const title = card.querySelector('%s');
+				const sub = card.querySelector('%s');
+				const lines = card.innerText.split('\n').map(l => l.trim()).filter(Boolean);
+				const sent = lines.find(l => /%s/i.test(l)) || '';
+				results.push({
+					index: i,
+					name: (title ? title.innerText : link.innerText).trim(),
+					headline: sub ? sub.innerText.trim() : '',
+					linkedin_url: link.href,
+					sent: sent
+				});
+			}
+			return results;
+		})()
+	`, SelSentInvitationCard, SelSentInvitationTitle, SelSentInvitationSub, RxSentAgeLine), &result))
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]SentInvitation, 0, len(result))
+	for _, raw := range result {
+		inv := SentInvitation{
+			Name:     getString(raw, "name"),
+			Headline: getString(raw, "headline"),
+			LinkedIn: NormalizeProfileURL(getString(raw, "linkedin_url")),
+			SentText: getString(raw, "sent"),
+			index:    int(raw["index"].(float64)),
+		}
+		inv.SentAt, _ = ParseSentAge(inv.SentText, now)
+		invitations = append(invitations, inv)
+	}
+	return invitations, nil
+}
+
+// withdrawInvitation clica em "Retirar" no card e confirma no modal
+func (e *Engine) withdrawInvitation(ctx context.Context, index int, callbacks WithdrawCallbacks) bool {
+	labels := strings.Join(RxWithdrawLabels, "|")
+
+	var clicked bool
+	err := chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(() => {
+			const card = document.querySelector('[data-sel="sent-inv-%d"]');
+			if (!card) return false;
+			for (const btn of card.querySelectorAll('button')) {
+				if (/%s/i.test(btn.innerText.trim())) {
+					btn.click();
+					return true;
+				}
+			}
+			return false;
+		})()
+	`, index, labels), &clicked))
+	if err != nil || !clicked {
+		return false
+	}
+
+	time.Sleep(1 * time.Second)
+
+	var confirmed bool
+	err = chromedp.Run(ctx, chromedp.Evaluate(fmt.Sprintf(`
+		(() => {
+			const buttons = document.querySelectorAll('div[role="alertdialog"] button, div[role="dialog"] button');
+			for (const btn of buttons) {
+				if (/%s/i.test(btn.innerText.trim())) {
+					btn.click();
+					return true;
+				}
+			}
+			return false;
+		})()
+	`, labels), &confirmed))
+	if err != nil {
+		callbacks.OnLog(fmt.Sprintf("Erro ao confirmar retirada: %v", err))
+		return false
+	}
+
+	time.Sleep(1 * time.Second)
+	return confirmed
+}
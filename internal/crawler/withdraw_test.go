@@ -0,0 +1,60 @@
+package crawler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSentAge(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		text string
+		want time.Time
+		ok   bool
+	}{
+		{"Enviado há 30 segundos", now.Add(-30 * time.Second), true},
+		{"Sent 30 seconds ago", now.Add(-30 * time.Second), true},
+		{"Enviado há 5 minutos", now.Add(-5 * time.Minute), true},
+		{"Sent 5 minutes ago", now.Add(-5 * time.Minute), true},
+		{"Enviado há um minuto", now.Add(-time.Minute), true},
+		{"Sent a minute ago", now.Add(-time.Minute), true},
+		{"Enviado há 5 horas", now.Add(-5 * time.Hour), true},
+		{"Sent 5 hours ago", now.Add(-5 * time.Hour), true},
+		{"Enviado há uma hora", now.Add(-time.Hour), true},
+		{"Sent an hour ago", now.Add(-time.Hour), true},
+		{"Sent 1 hour ago", now.Add(-time.Hour), true},
+		{"Enviado há 3 dias", now.AddDate(0, 0, -3), true},
+		{"Sent 3 days ago", now.AddDate(0, 0, -3), true},
+		{"Enviado há um dia", now.AddDate(0, 0, -1), true},
+		{"Sent a day ago", now.AddDate(0, 0, -1), true},
+		{"Enviado há 2 semanas", now.AddDate(0, 0, -14), true},
+		{"Sent 2 weeks ago", now.AddDate(0, 0, -14), true},
+		{"Enviado há uma semana", now.AddDate(0, 0, -7), true},
+		{"Sent a week ago", now.AddDate(0, 0, -7), true},
+		{"Enviado há 1 mês", now.AddDate(0, -1, 0), true},
+		{"Enviado há 3 meses", now.AddDate(0, -3, 0), true},
+		{"Sent 3 months ago", now.AddDate(0, -3, 0), true},
+		{"Enviado há um mês", now.AddDate(0, -1, 0), true},
+		{"Sent a month ago", now.AddDate(0, -1, 0), true},
+		{"Enviado há 2 anos", now.AddDate(-2, 0, 0), true},
+		{"Sent 2 years ago", now.AddDate(-2, 0, 0), true},
+		{"Enviado há um ano", now.AddDate(-1, 0, 0), true},
+		{"Sent a year ago", now.AddDate(-1, 0, 0), true},
+		{"Enviado hoje", now, true},
+		{"Sent today", now, true},
+		{"Enviado ontem", now.AddDate(0, 0, -1), true},
+		{"Sent yesterday", now.AddDate(0, 0, -1), true},
+		{"", time.Time{}, false},
+		{"Convite pendente", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := ParseSentAge(tt.text, now)
+			if ok != tt.ok || !got.Equal(tt.want) {
+				t.Errorf("ParseSentAge(%q) = (%v, %v), esperado (%v, %v)", tt.text, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
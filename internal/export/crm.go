@@ -0,0 +1,116 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Mapping campo do CRM preenchido a partir das colunas exportáveis
+type Mapping struct {
+	Field   string   // nome do campo/cabeçalho no CRM
+	Sources []string // colunas de origem (usa o primeiro valor não vazio)
+	Value   string   // valor fixo quando Sources está vazio ou sem valor
+}
+
+// Profile esquema de importação de um CRM
+type Profile struct {
+	Name     string
+	Label    string
+	Mappings []Mapping
+}
+
+// Perfis de importação. Colunas de contatos e convites são aceitas (name ou
+// profile_name, title ou profile_title).
+var profiles = map[string]Profile{
+	"hubspot": {
+		Name:  "hubspot",
+		Label: "HubSpot (contatos)",
+		Mappings: []Mapping{
+			{Field: "First Name", Sources: []string{"first_name"}},
+			{Field: "Last Name", Sources: []string{"last_name"}},
+			{Field: "Job Title", Sources: []string{"title", "profile_title"}},
+			{Field: "Company Name", Sources: []string{"company"}},
+			{Field: "City", Sources: []string{"city"}},
+			{Field: "State/Region", Sources: []string{"region"}},
+			{Field: "Country/Region", Sources: []string{"country"}},
+			{Field: "LinkedIn URL", Sources: []string{"linkedin_url"}},
+			{Field: "Lifecycle Stage", Value: "lead"},
+			{Field: "Lead Status", Value: "NEW"},
+		},
+	},
+	"pipedrive": {
+		Name:  "pipedrive",
+		Label: "Pipedrive (pessoas)",
+		Mappings: []Mapping{
+			{Field: "Person - Name", Sources: []string{"name", "profile_name"}},
+			{Field: "Person - First name", Sources: []string{"first_name"}},
+			{Field: "Person - Last name", Sources: []string{"last_name"}},
+			{Field: "Person - Job title", Sources: []string{"title", "profile_title"}},
+			{Field: "Organization - Name", Sources: []string{"company"}},
+			{Field: "Organization - Address", Sources: []string{"location"}},
+			{Field: "Person - LinkedIn", Sources: []string{"linkedin_url"}},
+			{Field: "Person - Lead source", Value: "LinkedIn"},
+		},
+	},
+	"salesforce": {
+		Name:  "salesforce",
+		Label: "Salesforce (Lead)",
+		Mappings: []Mapping{
+			{Field: "FirstName", Sources: []string{"first_name"}},
+			// LastName e Company são obrigatórios no Lead
+			{Field: "LastName", Sources: []string{"last_name", "name", "profile_name"}},
+			{Field: "Company", Sources: []string{"company"}, Value: "[não informado]"},
+			{Field: "Title", Sources: []string{"title", "profile_title"}},
+			{Field: "City", Sources: []string{"city"}},
+			{Field: "State", Sources: []string{"region"}},
+			{Field: "Country", Sources: []string{"country"}},
+			{Field: "Website", Sources: []string{"linkedin_url"}},
+			{Field: "LeadSource", Value: "LinkedIn"},
+			{Field: "Description", Sources: []string{"query"}},
+		},
+	},
+}
+
+// LookupProfile encontra um perfil de CRM pelo nome
+func LookupProfile(name string) (Profile, error) {
+	if p, ok := profiles[strings.ToLower(name)]; ok {
+		return p, nil
+	}
+	return Profile{}, fmt.Errorf("perfil de CRM desconhecido: %s (disponíveis: %s)", name, strings.Join(ProfileNames(), ", "))
+}
+
+// ProfileNames nomes dos perfis de CRM
+func ProfileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Map converte um registro para os campos do CRM
+func (p Profile) Map(rec Record) Record {
+	out := Record{}
+	for _, m := range p.Mappings {
+		value := pick(rec, m.Sources...)
+		if value == "" {
+			value = m.Value
+		}
+		out[m.Field] = value
+	}
+	return out
+}
+
+// Apply converte a tabela para o esquema do CRM (colunas = campos do perfil)
+func (p Profile) Apply(t Table) Table {
+	out := Table{Name: t.Name, Lang: LangKey, Records: make([]Record, len(t.Records))}
+	for _, m := range p.Mappings {
+		out.Columns = append(out.Columns, Field{Key: m.Field, PT: m.Field, EN: m.Field})
+	}
+	for i, rec := range t.Records {
+		out.Records[i] = p.Map(rec)
+	}
+	return out
+}
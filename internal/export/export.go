@@ -0,0 +1,301 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// Idiomas do cabeçalho
+const (
+	LangPT  = "pt"  // rótulos em português
+	LangEN  = "en"  // rótulos em inglês
+	LangKey = "key" // nomes das colunas (mesmos do invites.csv)
+)
+
+// Field coluna exportável
+type Field struct {
+	Key    string
+	PT     string
+	EN     string
+	Number bool // exportado como número em JSON/XLSX
+}
+
+// Label cabeçalho da coluna no idioma pedido
+func (f Field) Label(lang string) string {
+	switch lang {
+	case LangPT:
+		return f.PT
+	case LangEN:
+		return f.EN
+	}
+	return f.Key
+}
+
+// fields rótulos de todas as colunas conhecidas (contatos e convites)
+var fields = map[string]Field{}
+
+func init() {
+	for _, f := range []Field{
+		{Key: "name", PT: "Nome", EN: "Name"},
+		{Key: "first_name", PT: "Primeiro nome", EN: "First name"},
+		{Key: "last_name", PT: "Sobrenome", EN: "Last name"},
+		{Key: "raw_name", PT: "Nome original", EN: "Raw name"},
+		{Key: "title", PT: "Cargo", EN: "Title"},
+		{Key: "company", PT: "Empresa", EN: "Company"},
+		{Key: "headline", PT: "Headline", EN: "Headline"},
+		{Key: "location", PT: "Localização", EN: "Location"},
+		{Key: "city", PT: "Cidade", EN: "City"},
+		{Key: "region", PT: "Estado/Região", EN: "Region"},
+		{Key: "country", PT: "País", EN: "Country"},
+		{Key: "linkedin_url", PT: "LinkedIn", EN: "LinkedIn"},
+		{Key: "query", PT: "Query", EN: "Query"},
+		{Key: "source", PT: "Origem", EN: "Source"},
+		{Key: "degree", PT: "Grau", EN: "Degree", Number: true},
+		{Key: "mutual", PT: "Conexões em comum", EN: "Mutual connections", Number: true},
+		{Key: "score", PT: "Pontuação", EN: "Score", Number: true},
+		{Key: "score_rules", PT: "Regras", EN: "Score rules"},
+		{Key: "timestamp", PT: "Data", EN: "Date"},
+		{Key: "user_email", PT: "Conta", EN: "Account"},
+		{Key: "profile_name", PT: "Nome", EN: "Name"},
+		{Key: "profile_title", PT: "Cargo", EN: "Title"},
+		{Key: "status", PT: "Status", EN: "Status"},
+		{Key: "withdrawn_at", PT: "Retirado em", EN: "Withdrawn at"},
+		{Key: "accepted_at", PT: "Aceito em", EN: "Accepted at"},
+		{Key: "expired_at", PT: "Expirado em", EN: "Expired at"},
+	} {
+		fields[f.Key] = f
+	}
+}
+
+// field retorna a coluna pelo nome (colunas sem rótulo usam o próprio nome)
+func field(key string) Field {
+	if f, ok := fields[key]; ok {
+		return f
+	}
+	return Field{Key: key, PT: key, EN: key}
+}
+
+// ContactColumns colunas disponíveis para contatos
+var ContactColumns = []string{
+	"name", "first_name", "last_name", "raw_name", "title", "company", "headline",
+	"location", "city", "region", "country", "linkedin_url", "query", "source",
+	"degree", "mutual", "score", "score_rules",
+}
+
+// DefaultContactColumns colunas padrão do CSV de contatos do crawler
+var DefaultContactColumns = []string{
+	"name", "title", "company", "location", "linkedin_url", "score", "score_rules", "first_name", "last_name",
+}
+
+// Record valores de uma linha, por nome de coluna
+type Record map[string]string
+
+// ContactRecord converte um contato em registro exportável
+func ContactRecord(c crawler.Contact) Record {
+	return Record{
+		"name":         c.Name,
+		"first_name":   c.FirstName,
+		"last_name":    c.LastName,
+		"raw_name":     c.RawName,
+		"title":        c.Title,
+		"company":      c.Company,
+		"headline":     c.Headline,
+		"location":     c.Location,
+		"city":         c.City,
+		"region":       c.Region,
+		"country":      c.Country,
+		"linkedin_url": c.LinkedIn,
+		"query":        c.Query,
+		"source":       c.Source,
+		"degree":       strconv.Itoa(c.Degree),
+		"mutual":       strconv.Itoa(c.Mutual),
+		"score":        strconv.FormatFloat(c.Score, 'f', -1, 64),
+		"score_rules":  strings.Join(c.ScoreRules, ";"),
+	}
+}
+
+// InviteRecord converte um convite em registro exportável (colunas do invites.csv)
+func InviteRecord(r crawler.InviteRecord) Record {
+	rec := Record{}
+	row := storage.InviteRow(r)
+	for i, key := range storage.InviteHeader() {
+		rec[key] = row[i]
+	}
+	// Convites gravados antes da normalização de nomes
+	if rec["first_name"] == "" && rec["last_name"] == "" {
+		name := crawler.ParseName(r.ProfileName)
+		rec["first_name"], rec["last_name"] = name.First, name.Last
+	}
+	return rec
+}
+
+// Options seleção de colunas e idioma do cabeçalho
+type Options struct {
+	Columns []string // vazio = colunas padrão
+	Lang    string   // pt, en ou key (vazio = padrão da tabela)
+	Profile string   // perfil de CRM (hubspot, pipedrive, salesforce); substitui Columns e Lang
+}
+
+// ParseColumns separa uma lista de colunas ("name,title, company")
+func ParseColumns(value string) []string {
+	var cols []string
+	for _, col := range strings.Split(value, ",") {
+		if col = strings.TrimSpace(col); col != "" {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// Table dados prontos para exportar
+type Table struct {
+	Name    string // nome da planilha (XLSX)
+	Columns []Field
+	Records []Record
+	Lang    string
+}
+
+// Header cabeçalho no idioma da tabela
+func (t Table) Header() []string {
+	header := make([]string, len(t.Columns))
+	for i, f := range t.Columns {
+		header[i] = f.Label(t.Lang)
+	}
+	return header
+}
+
+// Row valores do registro na ordem das colunas
+func (t Table) Row(rec Record) []string {
+	row := make([]string, len(t.Columns))
+	for i, f := range t.Columns {
+		row[i] = rec[f.Key]
+	}
+	return row
+}
+
+// newTable aplica as opções sobre as colunas disponíveis
+func newTable(name string, available, defaults []string, defaultLang string, records []Record, opts Options) (Table, error) {
+	if opts.Profile != "" {
+		p, err := LookupProfile(opts.Profile)
+		if err != nil {
+			return Table{}, err
+		}
+		return p.Apply(Table{Name: name, Records: records}), nil
+	}
+
+	t := Table{Name: name, Records: records, Lang: opts.Lang}
+	if t.Lang == "" {
+		t.Lang = defaultLang
+	}
+	if t.Lang != LangPT && t.Lang != LangEN && t.Lang != LangKey {
+		return Table{}, fmt.Errorf("idioma do cabeçalho inválido: %s (use pt, en ou key)", t.Lang)
+	}
+
+	cols := opts.Columns
+	if len(cols) == 0 {
+		cols = defaults
+	}
+	for _, key := range cols {
+		if !contains(available, key) {
+			return Table{}, fmt.Errorf("coluna desconhecida: %s (disponíveis: %s)", key, strings.Join(available, ", "))
+		}
+		t.Columns = append(t.Columns, field(key))
+	}
+	return t, nil
+}
+
+// Contacts monta a tabela de contatos (padrão: colunas do CSV do crawler, cabeçalho em português)
+func Contacts(contacts []crawler.Contact, opts Options) (Table, error) {
+	records := make([]Record, len(contacts))
+	for i, c := range contacts {
+		records[i] = ContactRecord(c)
+	}
+	return newTable("Contatos", ContactColumns, DefaultContactColumns, LangPT, records, opts)
+}
+
+// Invites monta a tabela de convites (padrão: todas as colunas do invites.csv, cabeçalho com os nomes das colunas)
+func Invites(invites []crawler.InviteRecord, opts Options) (Table, error) {
+	records := make([]Record, len(invites))
+	for i, r := range invites {
+		records[i] = InviteRecord(r)
+	}
+	header := storage.InviteHeader()
+	return newTable("Convites", header, header, LangKey, records, opts)
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Format formato de exportação registrado
+type Format struct {
+	Name        string // usado em --format
+	Ext         string // extensão do arquivo (sem ponto)
+	ContentType string
+	Write       func(w io.Writer, t Table) error
+}
+
+// formats registro de formatos por nome ou apelido
+var formats = map[string]Format{}
+
+// Register adiciona um formato ao registro (pelo nome, extensão e apelidos)
+func Register(f Format, aliases ...string) {
+	for _, name := range append([]string{f.Name, f.Ext}, aliases...) {
+		formats[strings.ToLower(name)] = f
+	}
+}
+
+func init() {
+	Register(Format{Name: "csv", Ext: "csv", ContentType: "text/csv; charset=utf-8", Write: writeCSV})
+	Register(Format{Name: "json", Ext: "json", ContentType: "application/json", Write: writeJSON})
+	Register(Format{Name: "jsonl", Ext: "jsonl", ContentType: "application/x-ndjson", Write: writeJSONLines}, "ndjson")
+	Register(Format{Name: "xlsx", Ext: "xlsx", ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Write: writeXLSX})
+	Register(Format{Name: "vcard", Ext: "vcf", ContentType: "text/vcard; charset=utf-8", Write: writeVCard})
+}
+
+// Lookup encontra um formato pelo nome ou extensão
+func Lookup(name string) (Format, error) {
+	if f, ok := formats[strings.ToLower(strings.TrimPrefix(name, "."))]; ok {
+		return f, nil
+	}
+	return Format{}, fmt.Errorf("formato desconhecido: %s (disponíveis: %s)", name, strings.Join(Names(), ", "))
+}
+
+// Names nomes dos formatos registrados
+func Names() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, f := range formats {
+		if !seen[f.Name] {
+			seen[f.Name] = true
+			names = append(names, f.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WriteFile grava a tabela em um arquivo no formato indicado
+func WriteFile(path string, f Format, t Table) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := f.Write(file, t); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,173 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+func TestWriteVCard(t *testing.T) {
+	contacts := []crawler.Contact{
+		{Name: "Ana Souza", FirstName: "Ana", LastName: "Souza",
+			Title:   "Diretora de P&D; Inovação, Novos Negócios e Transformação Digital\nAmérica Latina",
+			Company: `Acme\Brasil`, City: "São Paulo", Region: "SP", Country: "BR",
+			LinkedIn: "https://www.linkedin.com/in/ana-souza"},
+		{Title: "Sem nome"},
+		{Name: "Bruno Lima", FirstName: "Bruno", LastName: "Lima", Location: "Lisboa, Portugal"},
+	}
+	table, err := Contacts(contacts, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeVCard(&buf, table); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"N:Souza;Ana;;;\r\n" +
+		"FN:Ana Souza\r\n" +
+		"TITLE:Diretora de P&D\\; Inovação\\, Novos Negócios e Transformação Digi\r\n" +
+		" tal\\nAmérica Latina\r\n" +
+		"ORG:Acme\\\\Brasil\r\n" +
+		"ADR;TYPE=WORK:;;;São Paulo;SP;;BR\r\n" +
+		"URL:https://www.linkedin.com/in/ana-souza\r\n" +
+		"END:VCARD\r\n" +
+		"BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"N:Lima;Bruno;;;\r\n" +
+		"FN:Bruno Lima\r\n" +
+		"ADR;TYPE=WORK:;;;Lisboa\\, Portugal;;;\r\n" +
+		"END:VCARD\r\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeVCard =\n%q\nesperado\n%q", got, want)
+	}
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("linha com %d bytes, máximo 75: %q", len(line), line)
+		}
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	table := Table{
+		Name:    "Contatos: 2026/10",
+		Lang:    LangPT,
+		Columns: []Field{field("name"), field("degree"), field("score")},
+		Records: []Record{
+			{"name": "P&D <Acme>\x01", "degree": "2", "score": "NaN"},
+			{"name": "", "degree": "0x1p4", "score": "+1.50"},
+			{"name": "Inf", "degree": "Inf", "score": ""},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeXLSX(&buf, table); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(r)
+		r.Close()
+		parts[f.Name] = string(b)
+	}
+
+	if !strings.Contains(parts["xl/workbook.xml"], `<sheet name="Contatos 202610" sheetId="1" r:id="rId1"/>`) {
+		t.Errorf("workbook.xml sem o nome da aba sanitizado:\n%s", parts["xl/workbook.xml"])
+	}
+
+	want := xlsxSheetStart +
+		`<row r="1">` +
+		`<c r="A1" t="inlineStr" s="1"><is><t xml:space="preserve">Nome</t></is></c>` +
+		`<c r="B1" t="inlineStr" s="1"><is><t xml:space="preserve">Grau</t></is></c>` +
+		`<c r="C1" t="inlineStr" s="1"><is><t xml:space="preserve">Pontuação</t></is></c>` +
+		`</row>` +
+		`<row r="2">` +
+		`<c r="A2" t="inlineStr"><is><t xml:space="preserve">P&amp;D &lt;Acme&gt;</t></is></c>` +
+		`<c r="B2"><v>2</v></c>` +
+		`<c r="C2" t="inlineStr"><is><t xml:space="preserve">NaN</t></is></c>` +
+		`</row>` +
+		`<row r="3">` +
+		`<c r="B3" t="inlineStr"><is><t xml:space="preserve">0x1p4</t></is></c>` +
+		`<c r="C3"><v>1.5</v></c>` +
+		`</row>` +
+		`<row r="4">` +
+		`<c r="A4" t="inlineStr"><is><t xml:space="preserve">Inf</t></is></c>` +
+		`<c r="B4" t="inlineStr"><is><t xml:space="preserve">Inf</t></is></c>` +
+		`</row>` +
+		xlsxSheetEnd
+	if got := parts["xl/worksheets/sheet1.xml"]; got != want {
+		t.Errorf("sheet1.xml =\n%s\nesperado\n%s", got, want)
+	}
+}
+
+func TestDecimalNumber(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{"12", "12", true},
+		{"-3.50", "-3.5", true},
+		{"+1", "1", true},
+		{"1e3", "1000", true},
+		{"", "", false},
+		{"NaN", "", false},
+		{"Inf", "", false},
+		{"-infinity", "", false},
+		{"0x1p4", "", false},
+		{"0x10", "", false},
+		{"1e400", "", false},
+		{"1,5", "", false},
+		{"12 ", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := decimalNumber(tt.value)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("decimalNumber(%q) = %q, %v; esperado %q, %v", tt.value, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestContactsColumnsAndLang(t *testing.T) {
+	contacts := []crawler.Contact{{Name: "Ana Souza", Title: "CTO", Score: 7.5}}
+
+	table, err := Contacts(contacts, Options{Columns: ParseColumns("name, score"), Lang: LangEN})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := writeJSONLines(&buf, table); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), `{"name":"Ana Souza","score":7.5}`+"\n"; got != want {
+		t.Errorf("writeJSONLines = %q, esperado %q", got, want)
+	}
+	if got := strings.Join(table.Header(), ","); got != "Name,Score" {
+		t.Errorf("Header() = %q, esperado Name,Score", got)
+	}
+
+	if _, err := Contacts(contacts, Options{Columns: []string{"email"}}); err == nil {
+		t.Error("Contacts com coluna desconhecida = nil, esperado erro")
+	}
+	if _, err := Contacts(contacts, Options{Lang: "es"}); err == nil {
+		t.Error("Contacts com idioma inválido = nil, esperado erro")
+	}
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// writeCSV cabeçalho no idioma da tabela e uma linha por registro
+func writeCSV(w io.Writer, t Table) error {
+	writer := csv.NewWriter(w)
+	writer.Write(t.Header())
+	for _, rec := range t.Records {
+		writer.Write(t.Row(rec))
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeJSON lista de objetos com os nomes das colunas como chaves
+func writeJSON(w io.Writer, t Table) error {
+	buf := bufio.NewWriter(w)
+	buf.WriteString("[")
+	for i, rec := range t.Records {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n  ")
+		buf.Write(jsonObject(t, rec))
+	}
+	if len(t.Records) > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("]\n")
+	return buf.Flush()
+}
+
+// writeJSONLines um objeto JSON por linha
+func writeJSONLines(w io.Writer, t Table) error {
+	buf := bufio.NewWriter(w)
+	for _, rec := range t.Records {
+		buf.Write(jsonObject(t, rec))
+		buf.WriteString("\n")
+	}
+	return buf.Flush()
+}
+
+// jsonObject serializa o registro mantendo a ordem das colunas; colunas
+// numéricas viram números (ou null quando vazias)
+func jsonObject(t Table, rec Record) []byte {
+	out := []byte("{")
+	for i, f := range t.Columns {
+		if i > 0 {
+			out = append(out, ',')
+		}
+		key, _ := json.Marshal(f.Key)
+		out = append(out, key...)
+		out = append(out, ':')
+		out = append(out, jsonValue(f, rec[f.Key])...)
+	}
+	return append(out, '}')
+}
+
+func jsonValue(f Field, value string) []byte {
+	if f.Number {
+		if value == "" {
+			return []byte("null")
+		}
+		if n, ok := decimalNumber(value); ok {
+			return []byte(n)
+		}
+	}
+	b, _ := json.Marshal(value)
+	return b
+}
+
+// decimalNumber normaliza um número decimal finito ("12", "-3.5", "1e3");
+// NaN, Inf e hexadecimais ("0x1p4") não são números na exportação
+func decimalNumber(value string) (string, bool) {
+	if value == "" || strings.Trim(value, "0123456789+-.eE") != "" {
+		return "", false
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+		return "", false
+	}
+	return strconv.FormatFloat(n, 'f', -1, 64), true
+}
@@ -0,0 +1,82 @@
+package export
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// writeVCard um cartão vCard 3.0 por registro (nome, cargo, empresa,
+// endereço e URL do perfil); a seleção de colunas não se aplica
+func writeVCard(w io.Writer, t Table) error {
+	buf := bufio.NewWriter(w)
+	for _, rec := range t.Records {
+		full := pick(rec, "name", "profile_name")
+		if full == "" {
+			continue
+		}
+
+		lines := []string{
+			"BEGIN:VCARD",
+			"VERSION:3.0",
+			"N:" + vcardEscape(rec["last_name"]) + ";" + vcardEscape(rec["first_name"]) + ";;;",
+			"FN:" + vcardEscape(full),
+		}
+		if title := pick(rec, "title", "profile_title"); title != "" {
+			lines = append(lines, "TITLE:"+vcardEscape(title))
+		}
+		if company := rec["company"]; company != "" {
+			lines = append(lines, "ORG:"+vcardEscape(company))
+		}
+		if rec["city"] != "" || rec["region"] != "" || rec["country"] != "" {
+			lines = append(lines, "ADR;TYPE=WORK:;;;"+vcardEscape(rec["city"])+";"+vcardEscape(rec["region"])+";;"+vcardEscape(rec["country"]))
+		} else if location := rec["location"]; location != "" {
+			lines = append(lines, "ADR;TYPE=WORK:;;;"+vcardEscape(location)+";;;")
+		}
+		if url := rec["linkedin_url"]; url != "" {
+			lines = append(lines, "URL:"+vcardEscape(url))
+		}
+		lines = append(lines, "END:VCARD")
+
+		for _, line := range lines {
+			buf.WriteString(vcardFold(line))
+			buf.WriteString("\r\n")
+		}
+	}
+	return buf.Flush()
+}
+
+// pick primeiro valor não vazio entre as colunas
+func pick(rec Record, keys ...string) string {
+	for _, key := range keys {
+		if v := rec[key]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+var vcardEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`)
+
+func vcardEscape(s string) string {
+	return vcardEscaper.Replace(s)
+}
+
+// vcardFold quebra linhas com mais de 75 bytes (continuação começa com espaço)
+func vcardFold(line string) string {
+	if len(line) <= 75 {
+		return line
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
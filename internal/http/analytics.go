@@ -0,0 +1,106 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/linkedin-visible-crawler/internal/analytics"
+)
+
+// dateLayout formato das datas nos filtros do relatório (input type="date")
+const dateLayout = "2006-01-02"
+
+// Analytics renderiza a página do funil (capturados → convidados → aceitos → responderam)
+func (h *Handlers) Analytics(c *gin.Context) {
+	report, from, to, err := h.buildReport(c)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao montar relatório: "+err.Error())
+		return
+	}
+
+	html, err := h.templates.RenderAnalytics(report, from, to)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao renderizar relatório")
+		return
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(http.StatusOK, html)
+}
+
+// ExportAnalyticsCSV baixa uma tabela do funil (/analytics/<dimensão>.csv)
+func (h *Handlers) ExportAnalyticsCSV(c *gin.Context) {
+	id := strings.TrimSuffix(c.Param("file"), ".csv")
+
+	report, from, to, err := h.buildReport(c)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao montar relatório")
+		return
+	}
+	table, ok := report.Table(id)
+	if !ok {
+		c.String(http.StatusNotFound, "Tabela não encontrada")
+		return
+	}
+
+	filename := fmt.Sprintf("funil_%s_%s_%s.csv", id, orAll(from), orAll(to))
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Content-Type", "text/csv")
+	table.WriteCSV(c.Writer)
+}
+
+// buildReport carrega capturas, convites e mensagens e monta o funil no
+// intervalo pedido (from/to em AAAA-MM-DD, vazios = sem limite, ou days=N;
+// padrão: últimos 30 dias)
+func (h *Handlers) buildReport(c *gin.Context) (analytics.Report, string, string, error) {
+	from, hasFrom := c.GetQuery("from")
+	to, hasTo := c.GetQuery("to")
+	if days := c.Query("days"); days != "" {
+		from, to = "", ""
+		if n, _ := strconv.Atoi(days); n > 0 {
+			from = time.Now().AddDate(0, 0, -n+1).Format(dateLayout)
+		}
+	} else if !hasFrom && !hasTo {
+		from = time.Now().AddDate(0, 0, -29).Format(dateLayout)
+	}
+
+	var r analytics.Range
+	if t, err := time.ParseInLocation(dateLayout, from, time.Local); err == nil {
+		r.From = t
+	} else {
+		from = ""
+	}
+	if t, err := time.ParseInLocation(dateLayout, to, time.Local); err == nil {
+		r.To = t.AddDate(0, 0, 1) // dia final inclusivo
+	} else {
+		to = ""
+	}
+
+	captures, err := h.store.ListCaptures()
+	if err != nil {
+		return analytics.Report{}, from, to, err
+	}
+	invites, _, err := h.store.ListInvites(0, math.MaxInt32)
+	if err != nil {
+		return analytics.Report{}, from, to, err
+	}
+	messages, err := h.messageLog.List()
+	if err != nil {
+		return analytics.Report{}, from, to, err
+	}
+
+	zone := func(account string) *time.Location { return h.weeklyCounter.Limits().Get(account).Location() }
+	return analytics.Build(captures, invites, messages, r, zone), from, to, nil
+}
+
+func orAll(date string) string {
+	if date == "" {
+		return "todos"
+	}
+	return date
+}
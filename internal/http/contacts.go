@@ -0,0 +1,47 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// contactsPageSize linhas por página da tabela de contatos
+const contactsPageSize = 50
+
+// ListContacts renderiza a tabela de contatos capturados. Parâmetros
+// opcionais: q (busca), status (pending, accepted, withdrawn, expired ou
+// none = sem convite), sort (last_seen, first_seen, name, company, score,
+// captures), order (asc/desc) e page.
+func (h *Handlers) ListContacts(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "0"))
+	if page < 0 {
+		page = 0
+	}
+
+	q := storage.ContactQuery{
+		Search: c.Query("q"),
+		Status: c.Query("status"),
+		Sort:   c.DefaultQuery("sort", "last_seen"),
+		Asc:    c.Query("order") == "asc",
+		Page:   page,
+		Size:   contactsPageSize,
+	}
+
+	contacts, total, err := h.store.ListContacts(q)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao listar contatos")
+		return
+	}
+
+	html, err := h.templates.RenderContacts(contacts, total, q)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao renderizar tabela")
+		return
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(http.StatusOK, html)
+}
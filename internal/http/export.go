@@ -0,0 +1,81 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/export"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// Export baixa convites ou contatos capturados no formato da extensão
+// (/export/invites.csv, /export/contacts.xlsx, ...). Parâmetros opcionais:
+// columns=name,title,company, lang=pt|en|key e profile=hubspot|pipedrive|salesforce;
+// convites aceitam também os filtros e a ordenação da tabela (ver inviteQuery)
+func (h *Handlers) Export(c *gin.Context) {
+	file := c.Param("file")
+	dot := strings.LastIndex(file, ".")
+	if dot <= 0 {
+		c.String(http.StatusNotFound, "Exportação não encontrada")
+		return
+	}
+	dataset, ext := file[:dot], file[dot+1:]
+
+	format, err := export.Lookup(ext)
+	if err != nil {
+		c.String(http.StatusNotFound, err.Error())
+		return
+	}
+	opts := export.Options{
+		Columns: export.ParseColumns(c.Query("columns")),
+		Lang:    c.Query("lang"),
+		Profile: c.Query("profile"),
+	}
+
+	var table export.Table
+	switch dataset {
+	case "invites":
+		q, _ := inviteQuery(c)
+		table, err = h.exportInvites(q, opts)
+	case "contacts":
+		table, err = h.exportContacts(opts)
+	default:
+		c.String(http.StatusNotFound, "Exportação não encontrada")
+		return
+	}
+	if err != nil {
+		c.String(http.StatusBadRequest, "Erro ao exportar: "+err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("linkedin_%s_%s.%s", dataset, time.Now().Format("20060102_150405"), format.Ext)
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Content-Type", format.ContentType)
+	format.Write(c.Writer, table)
+}
+
+// exportInvites convites que atendem aos filtros, na ordem da tabela
+func (h *Handlers) exportInvites(q storage.InviteQuery, opts export.Options) (export.Table, error) {
+	invites, _, err := h.store.SearchInvites(q)
+	if err != nil {
+		return export.Table{}, err
+	}
+	return export.Invites(invites, opts)
+}
+
+// exportContacts contatos capturados, um por perfil (captura mais recente primeiro)
+func (h *Handlers) exportContacts(opts export.Options) (export.Table, error) {
+	captures, err := h.store.ListCaptures()
+	if err != nil {
+		return export.Table{}, err
+	}
+	contacts := make([]crawler.Contact, 0, len(captures))
+	for i := len(captures) - 1; i >= 0; i-- {
+		contacts = append(contacts, captures[i].Contact)
+	}
+	return export.Contacts(crawler.RemoveDup(contacts), opts)
+}
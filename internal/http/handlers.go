@@ -2,48 +2,88 @@ package http
 
 import (
 	"fmt"
+	"html/template"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"encoding/csv"
-
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/orchestrator"
+	"github.com/your-org/linkedin-visible-crawler/internal/privacy"
+	"github.com/your-org/linkedin-visible-crawler/internal/push"
+	"github.com/your-org/linkedin-visible-crawler/internal/scheduler"
+	"github.com/your-org/linkedin-visible-crawler/internal/sequences"
 	"github.com/your-org/linkedin-visible-crawler/internal/storage"
 	"github.com/your-org/linkedin-visible-crawler/internal/ui"
+	"github.com/your-org/linkedin-visible-crawler/internal/webhooks"
 )
 
 // Handlers gerencia todos os handlers HTTP
 type Handlers struct {
 	templates     *ui.Templates
 	sseBroker     *ui.SSEBroker
-	inviteStorage *storage.InviteStorage
+	store         storage.Store // convites, capturas e execuções (CSV ou SQLite)
 	weeklyCounter *storage.WeeklyCounter
+	quota         *storage.Quota // reservas de convites compartilhadas entre execuções e processos
 	sessionStore  *SessionStore
+	orchestrator  *orchestrator.Orchestrator
+	scheduler     *scheduler.Scheduler
+	querySets     *storage.QuerySets
+	sequences     *sequences.Manager
+	messageLog    *storage.MessageLog
+	webhooks      *webhooks.Manager
+	privacy       *privacy.Manager
+	pusher        *push.Pusher // envio de convites para CRM/endpoint (opcional)
 }
 
 // NewHandlers cria nova instância dos handlers
 func NewHandlers(templates *ui.Templates, sseBroker *ui.SSEBroker,
-	inviteStorage *storage.InviteStorage, weeklyCounter *storage.WeeklyCounter,
-	sessionStore *SessionStore) *Handlers {
+	store storage.Store, weeklyCounter *storage.WeeklyCounter, quota *storage.Quota,
+	sessionStore *SessionStore, orch *orchestrator.Orchestrator,
+	sched *scheduler.Scheduler, querySets *storage.QuerySets,
+	seqs *sequences.Manager, messageLog *storage.MessageLog,
+	hooks *webhooks.Manager, priv *privacy.Manager) *Handlers {
 	return &Handlers{
 		templates:     templates,
 		sseBroker:     sseBroker,
-		inviteStorage: inviteStorage,
+		store:         store,
 		weeklyCounter: weeklyCounter,
+		quota:         quota,
 		sessionStore:  sessionStore,
+		orchestrator:  orch,
+		scheduler:     sched,
+		querySets:     querySets,
+		sequences:     seqs,
+		messageLog:    messageLog,
+		webhooks:      hooks,
+		privacy:       priv,
 	}
 }
 
+// SetPusher ativa o envio dos novos convites para o endpoint de data/push.json
+func (h *Handlers) SetPusher(p *push.Pusher) {
+	h.pusher = p
+}
+
 // Home renderiza a página principal
 func (h *Handlers) Home(c *gin.Context) {
-	html, err := h.templates.RenderHome(map[string]interface{}{})
+	// Limites da conta da sessão (padrão se ainda não há credenciais)
+	account := ""
+	if session, ok := c.Get("session"); ok {
+		account = session.(*SessionState).LinkedInEmail
+	}
+
+	html, err := h.templates.RenderHome(map[string]interface{}{
+		"Limits": h.weeklyCounter.Limits().Get(account),
+	})
 	if err != nil {
 		c.String(http.StatusInternalServerError, "Erro ao renderizar página")
 		return
@@ -110,19 +150,40 @@ func (h *Handlers) UploadQueriesFile(c *gin.Context) {
 		return
 	}
 
+	// Validar linhas (palavras-chave e/ou URLs de busca de pessoas)
+	content, err := os.ReadFile(filepath)
+	if err != nil {
+		c.String(http.StatusInternalServerError, `<div class="text-red-600">Erro ao ler arquivo</div>`)
+		return
+	}
+	summary, err := describeInput(string(content))
+	if err != nil {
+		os.Remove(filepath)
+		c.String(http.StatusBadRequest, fmt.Sprintf(`<div class="text-red-600">%s</div>`, template.HTMLEscapeString(err.Error())))
+		return
+	}
+
 	// Associar arquivo à sessão
 	h.sessionStore.SetQueriesPath(sessionID, filepath)
 
+	// Salvar também como conjunto nomeado (usado pelos agendamentos)
+	savedAs, err := h.saveQuerySet(c.PostForm("query_set_name"), filepath)
+	if err != nil {
+		c.String(http.StatusBadRequest, fmt.Sprintf(`<div class="text-red-600">%s</div>`, template.HTMLEscapeString(err.Error())))
+		return
+	}
+
 	response := fmt.Sprintf(`
 		<div class="text-green-600 bg-green-50 p-3 rounded-md">
 			<strong>✅ Arquivo carregado com sucesso</strong><br>
 			Nome: %s<br>
+			%s<br>%s
 			<button onclick="document.getElementById('queries-status').innerHTML=''" 
 					class="mt-2 text-sm text-blue-600 hover:text-blue-800 underline">
 				Trocar arquivo
 			</button>
 		</div>
-	`, file.Filename)
+	`, template.HTMLEscapeString(file.Filename), summary, savedAs)
 
 	c.String(http.StatusOK, response)
 }
@@ -137,6 +198,13 @@ func (h *Handlers) UploadQueriesText(c *gin.Context) {
 		return
 	}
 
+	// Validar linhas (queries de busca ou lista de perfis)
+	summary, err := describeInput(queriesText)
+	if err != nil {
+		c.String(http.StatusBadRequest, fmt.Sprintf(`<div class="text-red-600">%s</div>`, template.HTMLEscapeString(err.Error())))
+		return
+	}
+
 	// Criar diretório de uploads se não existir
 	uploadDir := "data/uploads/queries"
 	if err := os.MkdirAll(uploadDir, 0755); err != nil {
@@ -157,10 +225,18 @@ func (h *Handlers) UploadQueriesText(c *gin.Context) {
 	// Associar arquivo à sessão
 	h.sessionStore.SetQueriesPath(sessionID, filepath)
 
+	// Salvar também como conjunto nomeado (usado pelos agendamentos)
+	savedAs, err := h.saveQuerySet(c.PostForm("query_set_name"), filepath)
+	if err != nil {
+		c.String(http.StatusBadRequest, fmt.Sprintf(`<div class="text-red-600">%s</div>`, template.HTMLEscapeString(err.Error())))
+		return
+	}
+
 	response := `
 		<div class="text-green-600 bg-green-50 p-3 rounded-md">
 			<strong>✅ Queries salvas com sucesso</strong><br>
-			<small class="text-gray-600">Texto foi salvo como arquivo temporário</small><br>
+			` + summary + `<br>
+			<small class="text-gray-600">Texto foi salvo como arquivo temporário</small><br>` + savedAs + `
 			<button onclick="document.getElementById('queries-status').innerHTML=''" 
 					class="mt-2 text-sm text-blue-600 hover:text-blue-800 underline">
 				Trocar queries
@@ -171,6 +247,36 @@ func (h *Handlers) UploadQueriesText(c *gin.Context) {
 	c.String(http.StatusOK, response)
 }
 
+// describeInput valida o conteúdo enviado — queries de busca (palavras-chave e/ou
+// URLs de busca) ou uma lista de perfis /in/ — e retorna um resumo para a UI
+func describeInput(text string) (string, error) {
+	queries, profiles, err := parseInput(text)
+	if err != nil {
+		return "", err
+	}
+	if profiles != nil {
+		return fmt.Sprintf("%d URLs de perfil (use o modo \"Lista de perfis\")", len(profiles)), nil
+	}
+	urls := crawler.CountSearchURLs(queries)
+	return fmt.Sprintf("%d queries (%d palavras-chave, %d URLs de busca)", len(queries), len(queries)-urls, urls), nil
+}
+
+// parseInput interpreta o arquivo como queries (palavras-chave e URLs de
+// busca) ou, se não for, como lista de URLs de perfil (profiles != nil)
+func parseInput(text string) (queries, profiles []string, err error) {
+	queries, queryErr := crawler.ParseQueries(text)
+	if queryErr == nil {
+		return queries, nil, nil
+	}
+
+	profiles, profileErr := crawler.ParseProfileURLs(text)
+	if profileErr == nil && len(profiles) > 0 {
+		return nil, profiles, nil
+	}
+
+	return nil, nil, queryErr
+}
+
 // RunCrawler executa o crawler em goroutine
 func (h *Handlers) RunCrawler(c *gin.Context) {
 	sessionID := c.MustGet("session_id").(string)
@@ -182,26 +288,27 @@ func (h *Handlers) RunCrawler(c *gin.Context) {
 		return
 	}
 
-	// Validar arquivo de queries
-	if session.QueriesPath == "" {
-		c.String(http.StatusBadRequest, `<div class="text-red-600">Configure o arquivo de queries primeiro</div>`)
-		return
+	mode := c.PostForm("mode")
+	if mode != crawler.ModeProfiles && mode != crawler.ModeCompany {
+		mode = crawler.ModeSearch
 	}
 
-	// Verificar limite semanal
-	canSend, count, err := h.weeklyCounter.CanSendInvite(session.LinkedInEmail)
-	if err != nil {
-		c.String(http.StatusInternalServerError, `<div class="text-red-600">Erro ao verificar limite semanal</div>`)
+	// Validar arquivo de queries (o modo empresa usa apenas o formulário)
+	if mode != crawler.ModeCompany && session.QueriesPath == "" {
+		c.String(http.StatusBadRequest, `<div class="text-red-600">Configure o arquivo de queries primeiro</div>`)
 		return
 	}
 
-	if !canSend {
+	// Verificar limites da conta (semanal e diário)
+	usage := h.weeklyCounter.Usage(session.LinkedInEmail)
+	if !usage.CanSend() {
+		h.EmitLimitReached(session.LinkedInEmail, usage.Week, "run")
 		c.String(http.StatusBadRequest, fmt.Sprintf(`
 			<div class="text-red-600 bg-red-50 p-3 rounded-md">
-				<strong>❌ Limite semanal atingido</strong><br>
-				Você já enviou %d convites esta semana (limite: 200)
+				<strong>❌ Limite de convites atingido</strong><br>
+				Você já enviou %d convites nesta semana (limite: %d) e %d hoje (limite diário: %s)
 			</div>
-		`, count))
+		`, usage.Week, usage.Limits.Weekly, usage.Today, dailyLimitText(usage.Limits.Daily)))
 		return
 	}
 
@@ -216,128 +323,370 @@ func (h *Handlers) RunCrawler(c *gin.Context) {
 		maxConnects = 3
 	}
 
-	// Verificar modo headless
-	headlessMode := c.PostForm("headless_mode") == "on"
-
-	// Ler queries do arquivo
-	queriesBytes, err := os.ReadFile(session.QueriesPath)
-	if err != nil {
-		c.String(http.StatusInternalServerError, `<div class="text-red-600">Erro ao ler arquivo de queries</div>`)
-		return
+	maxPages, _ := strconv.Atoi(c.PostForm("max_pages"))
+	if maxPages == 0 {
+		maxPages = 1
 	}
 
-	queries := strings.Split(string(queriesBytes), "\n")
-	// Filtrar linhas vazias
-	var cleanQueries []string
-	for _, q := range queries {
-		if strings.TrimSpace(q) != "" {
-			cleanQueries = append(cleanQueries, strings.TrimSpace(q))
-		}
-	}
+	// Verificar modo headless
+	headlessMode := c.PostForm("headless_mode") == "on"
 
-	if len(cleanQueries) == 0 {
-		c.String(http.StatusBadRequest, `<div class="text-red-600">Arquivo de queries está vazio</div>`)
-		return
-	}
+	maxInvites, _ := strconv.Atoi(c.PostForm("max_invites"))
 
 	// Configurar crawler
 	cfg := crawler.RunConfig{
+		Mode:               mode,
 		MaxCardsRead:       maxCards,
 		MaxConnectsPerPage: maxConnects,
-		Queries:            cleanQueries,
+		MaxInvites:         maxInvites,
+		MaxPages:           maxPages,
 		Headless:           headlessMode,
 	}
 
+	// Ler queries do arquivo
+	var queriesBytes []byte
+	if mode != crawler.ModeCompany {
+		var err error
+		queriesBytes, err = os.ReadFile(session.QueriesPath)
+		if err != nil {
+			c.String(http.StatusInternalServerError, `<div class="text-red-600">Erro ao ler arquivo de queries</div>`)
+			return
+		}
+	}
+
+	var label string
+	switch mode {
+	case crawler.ModeCompany:
+		// Aba Pessoas de uma empresa
+		slug, err := crawler.ParseCompany(c.PostForm("company"))
+		if err != nil {
+			c.String(http.StatusBadRequest, fmt.Sprintf(`<div class="text-red-600">%s</div>`, template.HTMLEscapeString(err.Error())))
+			return
+		}
+		cfg.Company = slug
+		cfg.CompanyKeywords = strings.TrimSpace(c.PostForm("company_keywords"))
+		cfg.CompanyLocation = strings.TrimSpace(c.PostForm("company_location"))
+		label = "empresa " + slug
+	case crawler.ModeProfiles:
+		// Lista direta de URLs de perfil
+		profiles, err := crawler.ParseProfileURLs(string(queriesBytes))
+		if err != nil {
+			c.String(http.StatusBadRequest, fmt.Sprintf(`<div class="text-red-600">%s</div>`, template.HTMLEscapeString(err.Error())))
+			return
+		}
+		if len(profiles) == 0 {
+			c.String(http.StatusBadRequest, `<div class="text-red-600">Lista de perfis está vazia</div>`)
+			return
+		}
+		cfg.Profiles = profiles
+		label = fmt.Sprintf("%d perfis", len(profiles))
+	default:
+		// Palavras-chave e URLs de busca podem vir misturadas
+		cleanQueries, err := crawler.ParseQueries(string(queriesBytes))
+		if err != nil {
+			c.String(http.StatusBadRequest, fmt.Sprintf(`<div class="text-red-600">%s</div>`, template.HTMLEscapeString(err.Error())))
+			return
+		}
+		if len(cleanQueries) == 0 {
+			c.String(http.StatusBadRequest, `<div class="text-red-600">Arquivo de queries está vazio</div>`)
+			return
+		}
+		cfg.Queries = cleanQueries
+		label = fmt.Sprintf("%d queries", len(cleanQueries))
+	}
+
 	creds := crawler.Creds{
 		Email:    session.LinkedInEmail,
 		Password: session.LinkedInPass,
 	}
 
+	// Enfileirar no orquestrador (uma execução por conta, Chrome limitado no host)
+	job := h.startRun(sessionID, cfg, creds, label)
+
+	if current, ok := h.orchestrator.Get(job.ID); ok && current.Status == orchestrator.StatusQueued {
+		c.String(http.StatusOK, `
+		<div class="text-yellow-700 bg-yellow-50 p-3 rounded-md">
+			<strong>⏳ Execução enfileirada</strong><br>
+			<small class="text-gray-600">A conta já está em uso ou não há navegadores livres; ela iniciará automaticamente</small>
+		</div>
+	`)
+		return
+	}
+
+	response := `
+		<div class="text-green-600 bg-green-50 p-3 rounded-md">
+			<strong>🚀 Crawler iniciado com sucesso!</strong><br>
+			<small class="text-gray-600">Acompanhe o progresso na área de status ao vivo</small>
+		</div>
+	`
+
+	c.String(http.StatusOK, response)
+}
+
+// startRun monta os callbacks de integração com a UI e enfileira a execução
+// no orquestrador. sessionID pode ser vazio para execuções sem sessão
+// (ex.: disparadas pelo agendador).
+func (h *Handlers) startRun(sessionID string, cfg crawler.RunConfig, creds crawler.Creds, label string) *orchestrator.Job {
+	// A sessão pode trocar de conta durante a execução; fixar a conta deste run
+	account := creds.Email
+
+	// Supressão: perfis que já receberam convite não são convidados de novo
+	invited, err := h.store.InvitedURLs()
+	if err != nil {
+		h.sseBroker.PublishError("Erro ao carregar convites anteriores: " + err.Error())
+		invited = map[string]bool{}
+	}
+	var invitedMu sync.Mutex
+
+	// Aviso único de limite (capturados e convites da execução ficam em job.Stats)
+	var limitOnce sync.Once
+	limitReached := func(weekly int) {
+		limitOnce.Do(func() { h.EmitLimitReached(account, weekly, "invite") })
+	}
+
+	// ID da execução (conhecido após Submit; a tarefa só começa depois disso)
+	var runID string
+	ready := make(chan struct{})
+
+	// Falhas do lado do servidor (gravar captura, contato ou convite) também
+	// entram nos avisos do relatório da execução
+	var warningsMu sync.Mutex
+	var warnings []string
+	publishWarning := func(msg string) {
+		h.sseBroker.PublishError(msg)
+		warningsMu.Lock()
+		if len(warnings) < maxRunWarnings {
+			warnings = append(warnings, msg)
+		}
+		warningsMu.Unlock()
+	}
+
+	// Pontuação de leads: regras lidas a cada execução
+	if cfg.Scoring == nil {
+		scoring, err := crawler.LoadScoringModel(crawler.ScoringFile)
+		if err != nil {
+			h.sseBroker.PublishError("Erro no modelo de pontuação (execução sem pontuação): " + err.Error())
+		}
+		cfg.Scoring = scoring
+	}
+
 	// Callbacks para integração com UI
 	callbacks := crawler.Callbacks{
 		OnCaptured: func(contact crawler.Contact) {
+			// Registrar captura (base do funil de analytics)
+			capture := crawler.CaptureRecord{Timestamp: time.Now(), UserEmail: account, RunID: runID, Contact: contact}
+			if err := h.store.AppendCapture(capture); err != nil {
+				publishWarning("Erro ao registrar captura: " + err.Error())
+			}
+			// Consolidar o perfil na tabela de contatos (convidado ou não)
+			if err := h.store.UpsertContact(capture); err != nil {
+				publishWarning("Erro ao registrar contato: " + err.Error())
+			}
+
 			// Incrementar contador de sessão
+			h.orchestrator.Count(runID, 1, 0)
 			h.sessionStore.IncrementCaptured(sessionID)
 
 			// Obter valores atualizados
-			updatedSession, _ := h.sessionStore.GetSession(sessionID)
-			weekly, _ := h.weeklyCounter.CountThisWeek(session.LinkedInEmail)
+			captured := h.capturedCount(sessionID)
 
 			// Publicar métricas via SSE
-			h.sseBroker.PublishMetrics(updatedSession.CapturedCount, weekly)
-			h.sseBroker.PublishLog(fmt.Sprintf("📊 Contato capturado: %s (%d total)", contact.Name, updatedSession.CapturedCount))
+			h.sseBroker.PublishMetrics(captured, h.weeklyCounter.Usage(account))
+			h.sseBroker.PublishLog(fmt.Sprintf("📊 Contato capturado: %s (%d total)", contact.Name, captured))
 		},
-		OnInviteSent: func(contact crawler.Contact) {
+		OnInviteSent: func(contact crawler.Contact) error {
 			h.sseBroker.PublishLog(fmt.Sprintf("🎯 Callback OnInviteSent chamado para: %s", contact.Name))
 
-			// Verificar limite antes de enviar
-			canSend, _, err := h.weeklyCounter.CanSendInvite(session.LinkedInEmail)
-			if err != nil || !canSend {
-				h.sseBroker.PublishLog("Limite semanal atingido, pulando convite")
-				return
+			// Salvar no CSV (a vaga foi reservada em ReserveInvite antes do clique,
+			// então o convite já enviado é sempre registrado)
+			invite := crawler.NewInviteRecord(account, contact)
+
+			// Mesmo sem registro o convite foi enviado: conta no limite desta
+			// execução e não é repetido; com o erro o motor mantém a vaga
+			// ocupada no registro de cotas (Keep)
+			h.weeklyCounter.Add(invite)
+			if err := h.store.AppendInvite(invite); err != nil {
+				invitedMu.Lock()
+				invited[strings.ToLower(crawler.NormalizeProfileURL(contact.LinkedIn))] = true
+				invitedMu.Unlock()
+				publishWarning("Erro ao salvar convite: " + err.Error())
+				return fmt.Errorf("erro ao salvar convite: %v", err)
 			}
 
-			// Salvar no CSV
-			invite := crawler.InviteRecord{
-				Timestamp:    time.Now(),
-				UserEmail:    session.LinkedInEmail,
-				ProfileName:  contact.Name,
-				ProfileTitle: contact.Title,
-				Company:      contact.Company,
-				Location:     contact.Location,
-				LinkedInURL:  contact.LinkedIn,
-				Query:        cleanQueries[0], // Query atual
+			if h.pusher != nil {
+				h.pusher.Enqueue(invite)
 			}
 
-			if err := h.inviteStorage.AppendInvite(invite); err != nil {
-				h.sseBroker.PublishError("Erro ao salvar convite: " + err.Error())
-				return
-			}
+			invitedMu.Lock()
+			invited[strings.ToLower(crawler.NormalizeProfileURL(contact.LinkedIn))] = true
+			invitedMu.Unlock()
 
-			// Publicar via SSE
+			// Publicar via SSE e webhooks
+			h.orchestrator.Count(runID, 0, 1)
 			h.sseBroker.PublishInvite(invite)
+			h.webhooks.Emit(webhooks.EventInviteSent, invite)
 
 			// Atualizar métricas com valores atualizados
-			updatedSession, _ := h.sessionStore.GetSession(sessionID)
-			weekly, _ := h.weeklyCounter.CountThisWeek(session.LinkedInEmail)
-			h.sseBroker.PublishMetrics(updatedSession.CapturedCount, weekly)
-			h.sseBroker.PublishLog(fmt.Sprintf("✅ Convite enviado para: %s (%d convites esta semana)", contact.Name, weekly))
+			usage := h.weeklyCounter.Usage(account)
+			h.sseBroker.PublishMetrics(h.capturedCount(sessionID), usage)
+			h.sseBroker.PublishLog(fmt.Sprintf("✅ Convite enviado para: %s (%d convites esta semana)", contact.Name, usage.Week))
+			return nil
 		},
 		OnLog: func(line string) {
-			h.sseBroker.PublishLog(line)
+			h.sseBroker.PublishLog(fmt.Sprintf("[%s] %s", account, line))
+		},
+		CanInvite: func(contact crawler.Contact) (bool, string) {
+			invitedMu.Lock()
+			already := invited[strings.ToLower(crawler.NormalizeProfileURL(contact.LinkedIn))]
+			invitedMu.Unlock()
+			if already {
+				return false, "convite já enviado anteriormente"
+			}
+
+			usage := h.weeklyCounter.Usage(account)
+			if !usage.CanSend() {
+				limitReached(usage.Week)
+				return false, usage.Reason()
+			}
+			return cfg.Scoring.Admit(contact.Score, usage.Remaining())
+		},
+		ReserveInvite: func(contact crawler.Contact) (crawler.InviteSlot, string) {
+			slot, usage, err := h.quota.Reserve(account)
+			if err != nil {
+				return nil, "erro ao reservar convite: " + err.Error()
+			}
+			if slot == nil {
+				limitReached(usage.Week)
+				return nil, usage.Reason()
+			}
+			return slot, ""
 		},
 	}
 
-	// Executar crawler em goroutine
-	go func() {
-		engine := crawler.NewEngine()
+	// Relatório da execução: configuração, contadores por query e avisos
+	engine := crawler.NewEngine()
+	config := cfg
+	newRun := func() storage.RunRecord {
+		return storage.RunRecord{ID: runID, Account: account, Label: label, Mode: cfg.Mode, Config: &config}
+	}
+
+	job := h.orchestrator.Submit(account, label, func() error {
+		<-ready
+
+		// Registro inicial: execuções interrompidas (processo encerrado) ficam
+		// no histórico como "running" com a configuração usada
+		run := newRun()
+		run.Status = string(orchestrator.StatusRunning)
+		run.StartedAt = time.Now()
+		if err := h.store.SaveRun(run); err != nil {
+			h.sseBroker.PublishError("Erro ao registrar execução: " + err.Error())
+		}
+
 		if err := engine.Run(cfg, creds, callbacks); err != nil {
-			h.sseBroker.PublishError("Erro no crawler: " + err.Error())
+			h.sseBroker.PublishError(fmt.Sprintf("[%s] Erro no crawler: %v", account, err))
+			return err
 		}
+		return nil
+	})
+	runID = job.ID
+	close(ready)
+
+	// Histórico e evento run.finished quando a execução terminar (sucesso ou erro)
+	go func() {
+		job.Wait()
+		done, _ := h.orchestrator.Get(job.ID)
+		stats := engine.Stats()
+		warningsMu.Lock()
+		stats.Warnings = append(stats.Warnings, warnings...)
+		warningsMu.Unlock()
+
+		run := newRun()
+		run.Status = string(done.Status)
+		run.Error = done.Error
+		run.StartedAt = done.StartedAt
+		run.FinishedAt = done.FinishedAt
+		run.Captured = done.Stats.Captured
+		run.Invites = done.Stats.Invites
+		run.Queries = stats.Queries
+		run.Warnings = stats.Warnings
+		if err := h.store.SaveRun(run); err != nil {
+			h.sseBroker.PublishError("Erro ao registrar execução: " + err.Error())
+		}
+		h.webhooks.Emit(webhooks.EventRunFinished, gin.H{
+			"job_id":      done.ID,
+			"account":     account,
+			"label":       label,
+			"mode":        cfg.Mode,
+			"status":      done.Status,
+			"error":       done.Error,
+			"started_at":  done.StartedAt,
+			"finished_at": done.FinishedAt,
+			"captured":    done.Stats.Captured,
+			"invites":     done.Stats.Invites,
+		})
 	}()
+	return job
+}
 
-	response := `
-		<div class="text-green-600 bg-green-50 p-3 rounded-md">
-			<strong>🚀 Crawler iniciado com sucesso!</strong><br>
-			<small class="text-gray-600">Acompanhe o progresso na área de status ao vivo</small>
-		</div>
-	`
+// EmitLimitReached dispara o evento limit.reached (source: run, invite ou schedule)
+func (h *Handlers) EmitLimitReached(account string, count int, source string) {
+	usage := h.weeklyCounter.Usage(account)
+	h.webhooks.Emit(webhooks.EventLimitReached, gin.H{
+		"account":       account,
+		"invites_week":  count,
+		"limit":         usage.Limits.Weekly,
+		"invites_today": usage.Today,
+		"daily_limit":   usage.Limits.Daily,
+		"window":        usage.Limits.Window,
+		"reason":        usage.Reason(),
+		"source":        source,
+	})
+}
 
-	c.String(http.StatusOK, response)
+// capturedCount retorna o contador de capturados da sessão (0 se não houver sessão)
+func (h *Handlers) capturedCount(sessionID string) int {
+	if session, ok := h.sessionStore.GetSession(sessionID); ok {
+		return session.CapturedCount
+	}
+	return 0
+}
+
+// ListJobs renderiza o painel de execuções do orquestrador
+func (h *Handlers) ListJobs(c *gin.Context) {
+	html, err := h.templates.RenderJobs(h.orchestrator.Snapshot())
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao renderizar execuções")
+		return
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(http.StatusOK, html)
 }
 
 // ListInvites lista convites com paginação
 func (h *Handlers) ListInvites(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "0"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "50"))
+	q, filter := inviteQuery(c)
+	q.Page, _ = strconv.Atoi(c.DefaultQuery("page", "0"))
+	q.Size, _ = strconv.Atoi(c.DefaultQuery("page_size", "50"))
+	if q.Page < 0 {
+		q.Page = 0
+	}
+	if q.Size <= 0 {
+		q.Size = 50
+	}
 
-	invites, total, err := h.inviteStorage.ListInvites(page, pageSize)
+	invites, total, err := h.store.SearchInvites(q)
+	if err == nil && len(invites) == 0 && q.Page > 0 && total > 0 {
+		// Filtros mudaram e a página deixou de existir: mostrar a última
+		q.Page = (total - 1) / q.Size
+		invites, total, err = h.store.SearchInvites(q)
+	}
 	if err != nil {
 		c.String(http.StatusInternalServerError, "Erro ao listar convites")
 		return
 	}
 
-	html, err := h.templates.RenderInvites(invites, total, page, pageSize)
+	html, err := h.templates.RenderInvites(invites, total, q, filter.Encode())
 	if err != nil {
 		c.String(http.StatusInternalServerError, "Erro ao renderizar tabela")
 		return
@@ -347,85 +696,75 @@ func (h *Handlers) ListInvites(c *gin.Context) {
 	c.String(http.StatusOK, html)
 }
 
+// inviteQuery lê os filtros e a ordenação da tabela de convites (account,
+// query, company, location, status, q = nome/cargo, from/to em AAAA-MM-DD,
+// sort = coluna do CSV, order = asc/desc; padrão: mais recentes primeiro).
+// Retorna também os filtros aplicados, para a paginação e a exportação.
+func inviteQuery(c *gin.Context) (storage.InviteQuery, url.Values) {
+	filter := url.Values{}
+	param := func(name string) string {
+		value := strings.TrimSpace(c.Query(name))
+		if value != "" {
+			filter.Set(name, value)
+		}
+		return value
+	}
+
+	q := storage.InviteQuery{
+		Account:  param("account"),
+		Query:    param("query"),
+		Company:  param("company"),
+		Location: param("location"),
+		Status:   param("status"),
+		Search:   param("q"),
+	}
+	if t, err := time.ParseInLocation(dateLayout, c.Query("from"), time.Local); err == nil {
+		q.From = t
+		filter.Set("from", c.Query("from"))
+	}
+	if t, err := time.ParseInLocation(dateLayout, c.Query("to"), time.Local); err == nil {
+		q.To = t.AddDate(0, 0, 1) // dia final inclusivo
+		filter.Set("to", c.Query("to"))
+	}
+
+	q.Sort = "timestamp"
+	for _, col := range storage.InviteSortColumns {
+		if c.Query("sort") == col {
+			q.Sort = col
+		}
+	}
+	q.Asc = param("order") == "asc"
+	if q.Sort != "timestamp" {
+		filter.Set("sort", q.Sort)
+	}
+	return q, filter
+}
+
 // GetMetrics retorna métricas atuais
 func (h *Handlers) GetMetrics(c *gin.Context) {
 	session := c.MustGet("session").(*SessionState)
 
-	// Obter contadores
+	// Obter contadores e limites da conta (padrão se não há email configurado)
 	captured := session.CapturedCount
-	weekly := 0
+	usage := h.weeklyCounter.Usage(session.LinkedInEmail)
 
-	// Se há email configurado, obter contadores da semana
-	if session.LinkedInEmail != "" {
-		weekly, _ = h.weeklyCounter.CountThisWeek(session.LinkedInEmail)
-	} else {
+	if session.LinkedInEmail == "" {
 		// Se não há email configurado, tentar obter total geral do CSV
 		// Isso permite mostrar métricas mesmo sem credenciais configuradas
-		total, err := h.inviteStorage.GetTotalCount()
+		total, err := h.store.GetTotalCount()
 		if err == nil && total > 0 {
-			weekly = total
+			usage.Week = total
 		}
 	}
 
 	// Se não há contatos capturados na sessão mas há convites da semana,
 	// mostrar o total de convites como "capturados" (já que foram capturados em execuções anteriores)
-	if captured == 0 && weekly > 0 {
-		captured = weekly
+	if captured == 0 && usage.Week > 0 {
+		captured = usage.Week
 	}
 
 	// Retornar como JSON
-	c.JSON(http.StatusOK, gin.H{
-		"captured_session": captured,
-		"invites_week":     weekly,
-		"invites_limit":    200,
-	})
-}
-
-// ExportInvitesCSV exporta convites como CSV
-func (h *Handlers) ExportInvitesCSV(c *gin.Context) {
-	// Listar todos os convites
-	invites, _, err := h.inviteStorage.ListInvites(0, 10000)
-	if err != nil {
-		c.String(http.StatusInternalServerError, "Erro ao exportar CSV")
-		return
-	}
-
-	// Configurar headers para download
-	filename := fmt.Sprintf("linkedin_invites_%s.csv", time.Now().Format("20060102_150405"))
-	c.Header("Content-Disposition", "attachment; filename="+filename)
-	c.Header("Content-Type", "text/csv")
-
-	// Escrever CSV
-	writer := csv.NewWriter(c.Writer)
-	defer writer.Flush()
-
-	// Cabeçalho
-	header := []string{
-		"timestamp",
-		"user_email",
-		"profile_name",
-		"profile_title",
-		"company",
-		"location",
-		"linkedin_url",
-		"query",
-	}
-	writer.Write(header)
-
-	// Dados
-	for _, invite := range invites {
-		row := []string{
-			invite.Timestamp.Format(time.RFC3339),
-			invite.UserEmail,
-			invite.ProfileName,
-			invite.ProfileTitle,
-			invite.Company,
-			invite.Location,
-			invite.LinkedInURL,
-			invite.Query,
-		}
-		writer.Write(row)
-	}
+	c.JSON(http.StatusOK, ui.MetricsData(captured, usage))
 }
 
 // SSEEvents endpoint para Server-Sent Events
@@ -447,16 +786,16 @@ func (h *Handlers) SSEEvents(c *gin.Context) {
 	// Enviar métricas iniciais se houver sessão
 	if sessionID, exists := c.Get("session_id"); exists {
 		if session, ok := h.sessionStore.GetSession(sessionID.(string)); ok {
-			weekly, _ := h.weeklyCounter.CountThisWeek(session.LinkedInEmail)
+			usage := h.weeklyCounter.Usage(session.LinkedInEmail)
 			captured := session.CapturedCount
 
 			// Se não há contatos capturados na sessão mas há convites da semana,
 			// mostrar o total de convites como "capturados"
-			if captured == 0 && weekly > 0 {
-				captured = weekly
+			if captured == 0 && usage.Week > 0 {
+				captured = usage.Week
 			}
 
-			h.sseBroker.PublishMetrics(captured, weekly)
+			h.sseBroker.PublishMetrics(captured, usage)
 		}
 	}
 
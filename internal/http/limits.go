@@ -0,0 +1,71 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// ListLimits renderiza formulário e limites configurados (padrão e por conta)
+func (h *Handlers) ListLimits(c *gin.Context) {
+	h.renderLimits(c, "")
+}
+
+// SaveLimits grava os limites de uma conta (conta vazia altera o padrão)
+func (h *Handlers) SaveLimits(c *gin.Context) {
+	weekly, _ := strconv.Atoi(c.PostForm("weekly"))
+	daily, _ := strconv.Atoi(c.PostForm("daily"))
+
+	err := h.weeklyCounter.Limits().Set(storage.AccountLimits{
+		Account:  c.PostForm("account"),
+		Weekly:   weekly,
+		Daily:    daily,
+		Window:   c.PostForm("window"),
+		Timezone: c.PostForm("timezone"),
+	})
+	if err != nil {
+		h.renderLimits(c, "Erro ao salvar limites: "+err.Error())
+		return
+	}
+	h.renderLimits(c, "")
+}
+
+// DeleteLimits remove os limites próprios da conta (volta ao padrão)
+func (h *Handlers) DeleteLimits(c *gin.Context) {
+	if err := h.weeklyCounter.Limits().Delete(c.Param("account")); err != nil {
+		h.renderLimits(c, err.Error())
+		return
+	}
+	h.renderLimits(c, "")
+}
+
+// renderLimits responde com o painel de limites e o uso atual de cada conta
+func (h *Handlers) renderLimits(c *gin.Context, errMsg string) {
+	var usages []storage.InviteUsage
+	for _, limits := range h.weeklyCounter.Limits().List() {
+		if limits.Account == "" {
+			usages = append(usages, storage.InviteUsage{Limits: limits})
+			continue
+		}
+		usages = append(usages, h.weeklyCounter.Usage(limits.Account))
+	}
+
+	html, err := h.templates.RenderLimits(usages, errMsg)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao renderizar limites")
+		return
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(http.StatusOK, html)
+}
+
+// dailyLimitText limite diário para mensagens ("sem limite" quando 0)
+func dailyLimitText(daily int) string {
+	if daily <= 0 {
+		return "sem limite"
+	}
+	return strconv.Itoa(daily)
+}
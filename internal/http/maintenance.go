@@ -0,0 +1,126 @@
+package http
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/maintenance"
+	"github.com/your-org/linkedin-visible-crawler/internal/orchestrator"
+)
+
+// WithdrawInvites enfileira a retirada de convites pendentes antigos da conta da sessão
+func (h *Handlers) WithdrawInvites(c *gin.Context) {
+	session := c.MustGet("session").(*SessionState)
+
+	if session.LinkedInEmail == "" || session.LinkedInPass == "" {
+		c.String(http.StatusBadRequest, `<div class="text-red-600">Configure as credenciais do LinkedIn primeiro</div>`)
+		return
+	}
+
+	age, err := maintenance.ParseAge(c.DefaultPostForm("older_than", "21d"))
+	if err != nil {
+		c.String(http.StatusBadRequest, fmt.Sprintf(`<div class="text-red-600">%s</div>`, template.HTMLEscapeString(err.Error())))
+		return
+	}
+
+	maxWithdrawals, _ := strconv.Atoi(c.PostForm("max_withdrawals"))
+
+	opts := maintenance.WithdrawOptions{
+		OlderThan:      age,
+		Query:          strings.TrimSpace(c.PostForm("query")),
+		Company:        strings.TrimSpace(c.PostForm("company")),
+		MaxWithdrawals: maxWithdrawals,
+		Headless:       c.PostForm("headless_mode") == "on",
+	}
+	creds := crawler.Creds{
+		Email:    session.LinkedInEmail,
+		Password: session.LinkedInPass,
+	}
+	account := creds.Email
+
+	label := "🧹 retirar convites > " + maintenance.FormatAge(age)
+	job := h.orchestrator.Submit(account, label, func() error {
+		logf := func(line string) {
+			h.sseBroker.PublishLog(fmt.Sprintf("[%s] %s", account, line))
+		}
+		n, err := maintenance.Withdraw(h.store, opts, creds, logf)
+		if err != nil {
+			h.sseBroker.PublishError(fmt.Sprintf("[%s] Erro ao retirar convites: %v", account, err))
+			return err
+		}
+		logf(fmt.Sprintf("✅ %d convites retirados", n))
+		return nil
+	})
+
+	if current, ok := h.orchestrator.Get(job.ID); ok && current.Status == orchestrator.StatusQueued {
+		c.String(http.StatusOK, `
+		<div class="text-yellow-700 bg-yellow-50 p-3 rounded-md">
+			<strong>⏳ Retirada enfileirada</strong><br>
+			<small class="text-gray-600">A conta já está em uso ou não há navegadores livres</small>
+		</div>
+	`)
+		return
+	}
+
+	c.String(http.StatusOK, `
+		<div class="text-green-600 bg-green-50 p-3 rounded-md">
+			<strong>🧹 Retirada de convites iniciada</strong><br>
+			<small class="text-gray-600">Acompanhe o progresso no log ao vivo</small>
+		</div>
+	`)
+}
+
+// SyncInvites enfileira a sincronização de status dos convites da conta da sessão
+func (h *Handlers) SyncInvites(c *gin.Context) {
+	session := c.MustGet("session").(*SessionState)
+
+	if session.LinkedInEmail == "" || session.LinkedInPass == "" {
+		c.String(http.StatusBadRequest, `<div class="text-red-600">Configure as credenciais do LinkedIn primeiro</div>`)
+		return
+	}
+
+	maxConnections, _ := strconv.Atoi(c.PostForm("max_connections"))
+
+	opts := maintenance.SyncOptions{
+		MaxConnections: maxConnections,
+		Headless:       c.PostForm("headless_mode") == "on",
+	}
+	creds := crawler.Creds{
+		Email:    session.LinkedInEmail,
+		Password: session.LinkedInPass,
+	}
+	account := creds.Email
+
+	job := h.orchestrator.Submit(account, "🔄 sincronizar status dos convites", func() error {
+		logf := func(line string) {
+			h.sseBroker.PublishLog(fmt.Sprintf("[%s] %s", account, line))
+		}
+		if _, err := maintenance.SyncStatuses(h.store, opts, creds, logf); err != nil {
+			h.sseBroker.PublishError(fmt.Sprintf("[%s] Erro ao sincronizar convites: %v", account, err))
+			return err
+		}
+		return nil
+	})
+
+	if current, ok := h.orchestrator.Get(job.ID); ok && current.Status == orchestrator.StatusQueued {
+		c.String(http.StatusOK, `
+		<div class="text-yellow-700 bg-yellow-50 p-3 rounded-md">
+			<strong>⏳ Sincronização enfileirada</strong><br>
+			<small class="text-gray-600">A conta já está em uso ou não há navegadores livres</small>
+		</div>
+	`)
+		return
+	}
+
+	c.String(http.StatusOK, `
+		<div class="text-green-600 bg-green-50 p-3 rounded-md">
+			<strong>🔄 Sincronização de status iniciada</strong><br>
+			<small class="text-gray-600">A tabela de convites será atualizada ao final</small>
+		</div>
+	`)
+}
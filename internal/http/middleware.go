@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -71,6 +72,25 @@ func (s *SessionStore) SetQueriesPath(sessionID string, path string) bool {
 	return false
 }
 
+// FindCredentials procura, entre as sessões ativas, as credenciais de uma conta.
+// Usado por execuções sem sessão (agendador), mantendo as senhas apenas em memória.
+func (s *SessionStore) FindCredentials(email string) (string, string, bool) {
+	var password string
+	s.sessions.Range(func(key, value interface{}) bool {
+		if session, ok := value.(*SessionState); ok {
+			if strings.EqualFold(session.LinkedInEmail, email) && session.LinkedInPass != "" {
+				password = session.LinkedInPass
+				return false
+			}
+		}
+		return true
+	})
+	if password == "" {
+		return "", "", false
+	}
+	return email, password, true
+}
+
 // IncrementCaptured incrementa contador de contatos capturados
 func (s *SessionStore) IncrementCaptured(sessionID string) bool {
 	if value, ok := s.sessions.Load(sessionID); ok {
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/linkedin-visible-crawler/internal/privacy"
+)
+
+// ListPrivacy renderiza o painel de privacidade (pedidos de acesso e remoção)
+func (h *Handlers) ListPrivacy(c *gin.Context) {
+	h.renderPrivacy(c, "", "")
+}
+
+// ExportSubject baixa em JSON tudo o que está registrado sobre a pessoa
+// (url e/ou name)
+func (h *Handlers) ExportSubject(c *gin.Context) {
+	subject := privacy.Subject{URL: c.Query("url"), Name: c.Query("name")}
+	report, err := h.privacy.Export(subject)
+	if err != nil {
+		c.String(http.StatusBadRequest, "Erro ao exportar dados: "+err.Error())
+		return
+	}
+
+	body, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao serializar dados")
+		return
+	}
+	filename := fmt.Sprintf("dados_pessoa_%s.json", time.Now().Format("20060102_150405"))
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, "application/json", body)
+}
+
+// EraseSubject remove os registros da pessoa de todas as origens
+func (h *Handlers) EraseSubject(c *gin.Context) {
+	subject := privacy.Subject{URL: c.PostForm("url"), Name: c.PostForm("name")}
+	record, err := h.privacy.Erase(subject, "web")
+	if err != nil {
+		h.renderPrivacy(c, "", "Erro ao apagar dados: "+err.Error())
+		return
+	}
+
+	h.sseBroker.PublishLog(fmt.Sprintf("🗑️ Dados pessoais apagados (%d registros, auditoria %s)", record.Total(), record.ID))
+	h.renderPrivacy(c, fmt.Sprintf("%d registros apagados (%s).", record.Total(), describeRemoved(record.Removed)), "")
+}
+
+// PurgeRetention aplica agora a retenção configurada
+func (h *Handlers) PurgeRetention(c *gin.Context) {
+	days := h.privacy.Retention()
+	if days <= 0 {
+		h.renderPrivacy(c, "", "Retenção desativada (defina RETENTION_DAYS)")
+		return
+	}
+	record, err := h.privacy.Purge(days, "web")
+	if err != nil {
+		h.renderPrivacy(c, "", "Erro na retenção: "+err.Error())
+		return
+	}
+	h.renderPrivacy(c, fmt.Sprintf("Retenção de %d dias aplicada: %d registros removidos.", days, record.Total()), "")
+}
+
+// describeRemoved resumo "convites: 2, capturas: 5" (origens com registros)
+func describeRemoved(removed map[string]int) string {
+	var parts []string
+	for _, source := range privacy.Sources {
+		if n := removed[source]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", source, n))
+		}
+	}
+	if len(parts) == 0 {
+		return "nenhum registro encontrado"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (h *Handlers) renderPrivacy(c *gin.Context, msg, errMsg string) {
+	audit, err := h.privacy.Audit(20)
+	if err != nil && errMsg == "" {
+		errMsg = err.Error()
+	}
+
+	html, err := h.templates.RenderPrivacy(audit, h.privacy.Retention(), msg, errMsg)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao renderizar privacidade")
+		return
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(http.StatusOK, html)
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// maxRunWarnings avisos do servidor guardados por execução (além dos do motor)
+const maxRunWarnings = 200
+
+// runReport relatório de uma execução (/runs/:id/report.json)
+type runReport struct {
+	storage.RunRecord
+	Totals          crawler.QueryStats `json:"totals"`
+	DurationSeconds float64            `json:"duration_seconds"`
+}
+
+// ListRuns renderiza o histórico de execuções (?account= filtra pela conta)
+func (h *Handlers) ListRuns(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+	account := c.Query("account")
+
+	runs, err := h.store.ListRuns(0)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao carregar execuções: "+err.Error())
+		return
+	}
+	filtered := make([]storage.RunRecord, 0, limit)
+	for _, run := range runs {
+		if account != "" && run.Account != account {
+			continue
+		}
+		if filtered = append(filtered, run); len(filtered) == limit {
+			break
+		}
+	}
+
+	html, err := h.templates.RenderRuns(filtered, account)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao renderizar execuções")
+		return
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(http.StatusOK, html)
+}
+
+// RunReport página com configuração, contadores por query e avisos da execução
+func (h *Handlers) RunReport(c *gin.Context) {
+	report, ok := h.runReport(c)
+	if !ok {
+		return
+	}
+
+	html, err := h.templates.RenderRunReport(report.RunRecord, report.Totals)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao renderizar relatório")
+		return
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(http.StatusOK, html)
+}
+
+// RunReportJSON relatório da execução em JSON (registro completo + totais)
+func (h *Handlers) RunReportJSON(c *gin.Context) {
+	report, ok := h.runReport(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// runReport carrega a execução de :id (responde 404/500 e retorna false se não der)
+func (h *Handlers) runReport(c *gin.Context) (runReport, bool) {
+	run, found, err := h.store.GetRun(c.Param("id"))
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao carregar execução: "+err.Error())
+		return runReport{}, false
+	}
+	if !found {
+		c.String(http.StatusNotFound, "Execução não encontrada")
+		return runReport{}, false
+	}
+	return runReport{
+		RunRecord:       run,
+		Totals:          run.Stats().Totals(),
+		DurationSeconds: run.Duration().Seconds(),
+	}, true
+}
@@ -0,0 +1,151 @@
+package http
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/orchestrator"
+	"github.com/your-org/linkedin-visible-crawler/internal/scheduler"
+)
+
+// ListSchedules renderiza formulário e tabela de agendamentos
+func (h *Handlers) ListSchedules(c *gin.Context) {
+	h.renderSchedules(c, "")
+}
+
+// CreateSchedule cria novo agendamento recorrente
+func (h *Handlers) CreateSchedule(c *gin.Context) {
+	maxCards, _ := strconv.Atoi(c.PostForm("max_cards"))
+	if maxCards == 0 {
+		maxCards = 60
+	}
+
+	maxConnects, _ := strconv.Atoi(c.PostForm("max_connects"))
+	if maxConnects == 0 {
+		maxConnects = 3
+	}
+
+	_, err := h.scheduler.Create(scheduler.Schedule{
+		Name:        c.PostForm("name"),
+		Cron:        c.PostForm("cron"),
+		Account:     c.PostForm("account"),
+		QuerySet:    c.PostForm("query_set"),
+		MaxCards:    maxCards,
+		MaxConnects: maxConnects,
+		Headless:    c.PostForm("headless_mode") == "on",
+	})
+	if err != nil {
+		h.renderSchedules(c, "Erro ao criar agendamento: "+err.Error())
+		return
+	}
+
+	h.renderSchedules(c, "")
+}
+
+// PauseSchedule pausa um agendamento
+func (h *Handlers) PauseSchedule(c *gin.Context) {
+	if err := h.scheduler.SetPaused(c.Param("id"), true); err != nil {
+		h.renderSchedules(c, err.Error())
+		return
+	}
+	h.renderSchedules(c, "")
+}
+
+// ResumeSchedule retoma um agendamento pausado
+func (h *Handlers) ResumeSchedule(c *gin.Context) {
+	if err := h.scheduler.SetPaused(c.Param("id"), false); err != nil {
+		h.renderSchedules(c, err.Error())
+		return
+	}
+	h.renderSchedules(c, "")
+}
+
+// DeleteSchedule remove um agendamento
+func (h *Handlers) DeleteSchedule(c *gin.Context) {
+	if err := h.scheduler.Delete(c.Param("id")); err != nil {
+		h.renderSchedules(c, err.Error())
+		return
+	}
+	h.renderSchedules(c, "")
+}
+
+// LaunchScheduled inicia a execução de um agendamento. As credenciais são
+// obtidas das sessões ativas ou das variáveis LINKEDIN_EMAIL/LINKEDIN_PASSWORD.
+func (h *Handlers) LaunchScheduled(s scheduler.Schedule) (*orchestrator.Job, error) {
+	email, password, ok := h.sessionStore.FindCredentials(s.Account)
+	if !ok && strings.EqualFold(s.Account, os.Getenv("LINKEDIN_EMAIL")) && os.Getenv("LINKEDIN_PASSWORD") != "" {
+		email, password, ok = s.Account, os.Getenv("LINKEDIN_PASSWORD"), true
+	}
+	if !ok {
+		return nil, fmt.Errorf("sem credenciais ativas para %s", s.Account)
+	}
+
+	text, err := h.querySets.Load(s.QuerySet)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mesmas regras do arquivo enviado na página: comentários e linhas vazias
+	// são ignorados e listas de URLs de perfil rodam no modo profiles
+	queries, profiles, err := parseInput(text)
+	if err != nil {
+		return nil, fmt.Errorf("conjunto de queries '%s': %v", s.QuerySet, err)
+	}
+	if len(queries) == 0 && len(profiles) == 0 {
+		return nil, fmt.Errorf("conjunto de queries '%s' está vazio", s.QuerySet)
+	}
+
+	cfg := crawler.RunConfig{
+		MaxCardsRead:       s.MaxCards,
+		MaxConnectsPerPage: s.MaxConnects,
+		Queries:            queries,
+		Headless:           s.Headless,
+	}
+	if profiles != nil {
+		cfg.Mode = crawler.ModeProfiles
+		cfg.Profiles = profiles
+	}
+	creds := crawler.Creds{Email: email, Password: password}
+
+	return h.startRun("", cfg, creds, "⏰ "+s.Name), nil
+}
+
+// renderSchedules responde com o painel de agendamentos (e mensagem de erro opcional)
+func (h *Handlers) renderSchedules(c *gin.Context, errMsg string) {
+	sets, _ := h.querySets.List()
+
+	html, err := h.templates.RenderSchedules(h.scheduler.List(), sets, errMsg)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao renderizar agendamentos")
+		return
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(http.StatusOK, html)
+}
+
+// saveQuerySet copia o arquivo de queries para um conjunto nomeado, se informado,
+// e retorna o trecho HTML de confirmação
+func (h *Handlers) saveQuerySet(name, path string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler queries: %v", err)
+	}
+
+	slug, err := h.querySets.Save(name, content)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`Salvo como conjunto: <strong>%s</strong><br>`, template.HTMLEscapeString(slug)), nil
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+// GetScoring renderiza o editor do modelo de pontuação de leads
+func (h *Handlers) GetScoring(c *gin.Context) {
+	model, err := crawler.LoadScoringModel(crawler.ScoringFile)
+	if err != nil {
+		h.renderScoring(c, "", false, err.Error())
+		return
+	}
+	if model == nil {
+		example := crawler.DefaultScoringModel()
+		content, _ := json.MarshalIndent(example, "", "  ")
+		h.renderScoring(c, string(content), false, "")
+		return
+	}
+
+	content, _ := json.MarshalIndent(model, "", "  ")
+	h.renderScoring(c, string(content), true, "")
+}
+
+// SaveScoring valida e grava o modelo de pontuação (vale a partir da próxima execução)
+func (h *Handlers) SaveScoring(c *gin.Context) {
+	content := c.PostForm("model")
+	model, err := crawler.ParseScoringModel([]byte(content))
+	if err != nil {
+		h.renderScoring(c, content, false, "Modelo inválido: "+err.Error())
+		return
+	}
+	if err := crawler.SaveScoringModel(crawler.ScoringFile, model); err != nil {
+		h.renderScoring(c, content, false, err.Error())
+		return
+	}
+
+	formatted, _ := json.MarshalIndent(model, "", "  ")
+	h.renderScoring(c, string(formatted), true, "")
+}
+
+func (h *Handlers) renderScoring(c *gin.Context, content string, active bool, errMsg string) {
+	html, err := h.templates.RenderScoring(content, active, errMsg)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao renderizar pontuação")
+		return
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(http.StatusOK, html)
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/orchestrator"
+	"github.com/your-org/linkedin-visible-crawler/internal/sequences"
+)
+
+// ListSequences renderiza formulário, sequências e últimas mensagens
+func (h *Handlers) ListSequences(c *gin.Context) {
+	h.renderSequences(c, "")
+}
+
+// CreateSequence cria nova sequência de follow-up
+func (h *Handlers) CreateSequence(c *gin.Context) {
+	if _, err := h.sequences.Create(c.PostForm("name"), c.PostForm("account"), c.PostForm("steps")); err != nil {
+		h.renderSequences(c, "Erro ao criar sequência: "+err.Error())
+		return
+	}
+	h.renderSequences(c, "")
+}
+
+// PauseSequence pausa uma sequência
+func (h *Handlers) PauseSequence(c *gin.Context) {
+	if err := h.sequences.SetActive(c.Param("id"), false); err != nil {
+		h.renderSequences(c, err.Error())
+		return
+	}
+	h.renderSequences(c, "")
+}
+
+// ResumeSequence reativa uma sequência
+func (h *Handlers) ResumeSequence(c *gin.Context) {
+	if err := h.sequences.SetActive(c.Param("id"), true); err != nil {
+		h.renderSequences(c, err.Error())
+		return
+	}
+	h.renderSequences(c, "")
+}
+
+// DeleteSequence remove uma sequência e suas inscrições
+func (h *Handlers) DeleteSequence(c *gin.Context) {
+	if err := h.sequences.Delete(c.Param("id")); err != nil {
+		h.renderSequences(c, err.Error())
+		return
+	}
+	h.renderSequences(c, "")
+}
+
+// RunFollowUps enfileira a execução de follow-ups da conta da sessão
+func (h *Handlers) RunFollowUps(c *gin.Context) {
+	session := c.MustGet("session").(*SessionState)
+
+	if session.LinkedInEmail == "" || session.LinkedInPass == "" {
+		c.String(http.StatusBadRequest, `<div class="text-red-600">Configure as credenciais do LinkedIn primeiro</div>`)
+		return
+	}
+
+	maxMessages, _ := strconv.Atoi(c.PostForm("max_messages"))
+
+	opts := sequences.RunOptions{
+		MaxMessages: maxMessages,
+		Headless:    c.PostForm("headless_mode") == "on",
+	}
+	creds := crawler.Creds{
+		Email:    session.LinkedInEmail,
+		Password: session.LinkedInPass,
+	}
+	account := creds.Email
+
+	job := h.orchestrator.Submit(account, "✉️ follow-ups pós-aceitação", func() error {
+		logf := func(line string) {
+			h.sseBroker.PublishLog(fmt.Sprintf("[%s] %s", account, line))
+		}
+		if _, err := sequences.Run(h.sequences, h.store, h.messageLog, opts, creds, logf); err != nil {
+			h.sseBroker.PublishError(fmt.Sprintf("[%s] Erro nos follow-ups: %v", account, err))
+			return err
+		}
+		return nil
+	})
+
+	if current, ok := h.orchestrator.Get(job.ID); ok && current.Status == orchestrator.StatusQueued {
+		c.String(http.StatusOK, `
+		<div class="text-yellow-700 bg-yellow-50 p-3 rounded-md">
+			<strong>⏳ Follow-ups enfileirados</strong><br>
+			<small class="text-gray-600">A conta já está em uso ou não há navegadores livres</small>
+		</div>
+	`)
+		return
+	}
+
+	c.String(http.StatusOK, `
+		<div class="text-green-600 bg-green-50 p-3 rounded-md">
+			<strong>✉️ Follow-ups iniciados</strong><br>
+			<small class="text-gray-600">Acompanhe o envio no log ao vivo</small>
+		</div>
+	`)
+}
+
+// renderSequences responde com o painel de sequências (e mensagem de erro opcional)
+func (h *Handlers) renderSequences(c *gin.Context, errMsg string) {
+	recent, _ := h.messageLog.Recent(20)
+
+	html, err := h.templates.RenderSequences(h.sequences.List(), h.sequences.Enrollments(), recent, errMsg)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao renderizar sequências")
+		return
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(http.StatusOK, html)
+}
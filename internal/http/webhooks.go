@@ -0,0 +1,66 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/linkedin-visible-crawler/internal/webhooks"
+)
+
+// ListWebhooks renderiza formulário, inscrições e últimas entregas
+func (h *Handlers) ListWebhooks(c *gin.Context) {
+	h.renderWebhooks(c, nil, "")
+}
+
+// CreateWebhook cria nova inscrição (eventos marcados no formulário; nenhum = todos).
+// O segredo completo só é exibido nesta resposta.
+func (h *Handlers) CreateWebhook(c *gin.Context) {
+	sub, err := h.webhooks.Create(c.PostForm("name"), c.PostForm("url"), c.PostForm("secret"), c.PostFormArray("events"))
+	if err != nil {
+		h.renderWebhooks(c, nil, "Erro ao criar webhook: "+err.Error())
+		return
+	}
+	h.renderWebhooks(c, &sub, "")
+}
+
+// PauseWebhook pausa uma inscrição
+func (h *Handlers) PauseWebhook(c *gin.Context) {
+	if err := h.webhooks.SetActive(c.Param("id"), false); err != nil {
+		h.renderWebhooks(c, nil, err.Error())
+		return
+	}
+	h.renderWebhooks(c, nil, "")
+}
+
+// ResumeWebhook reativa uma inscrição
+func (h *Handlers) ResumeWebhook(c *gin.Context) {
+	if err := h.webhooks.SetActive(c.Param("id"), true); err != nil {
+		h.renderWebhooks(c, nil, err.Error())
+		return
+	}
+	h.renderWebhooks(c, nil, "")
+}
+
+// DeleteWebhook remove uma inscrição
+func (h *Handlers) DeleteWebhook(c *gin.Context) {
+	if err := h.webhooks.Delete(c.Param("id")); err != nil {
+		h.renderWebhooks(c, nil, err.Error())
+		return
+	}
+	h.renderWebhooks(c, nil, "")
+}
+
+// renderWebhooks responde com o painel de webhooks (inscrição recém-criada
+// e mensagem de erro opcionais)
+func (h *Handlers) renderWebhooks(c *gin.Context, created *webhooks.Subscription, errMsg string) {
+	recent, _ := h.webhooks.Recent(20)
+
+	html, err := h.templates.RenderWebhooks(h.webhooks.List(), recent, created, errMsg)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "Erro ao renderizar webhooks")
+		return
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(http.StatusOK, html)
+}
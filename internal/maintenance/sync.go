@@ -0,0 +1,105 @@
+package maintenance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// SyncOptions configuração da sincronização de status dos convites
+type SyncOptions struct {
+	MaxConnections int // conexões recentes a ler (0 = padrão do crawler)
+	Headless       bool
+}
+
+// SyncSummary contagem do resultado da sincronização
+type SyncSummary struct {
+	Accepted int // convites que passaram a aceitos
+	Expired  int // convites que passaram a expirados
+	Pending  int // convites ainda listados como pendentes
+	Unknown  int // pendentes sem evidência suficiente para mudar de status
+}
+
+// String resumo legível da sincronização
+func (s SyncSummary) String() string {
+	return fmt.Sprintf("%d aceitos, %d expirados, %d pendentes, %d indeterminados", s.Accepted, s.Expired, s.Pending, s.Unknown)
+}
+
+// SyncStatuses lê conexões recentes e convites enviados da conta e atualiza o
+// status dos convites registrados
+func SyncStatuses(store storage.Store, opts SyncOptions, creds crawler.Creds, logf func(string)) (SyncSummary, error) {
+	logf("Sincronizando status dos convites (conexões + convites enviados)...")
+
+	cfg := crawler.SyncConfig{
+		MaxConnections: opts.MaxConnections,
+		Headless:       opts.Headless,
+	}
+	result, err := crawler.NewEngine().SyncInvitations(cfg, creds, logf)
+	if err != nil {
+		return SyncSummary{}, err
+	}
+
+	summary, err := ApplySync(store, creds.Email, result, time.Now())
+	if err != nil {
+		return summary, fmt.Errorf("erro ao atualizar convites: %v", err)
+	}
+	logf("Status sincronizado: " + summary.String())
+	return summary, nil
+}
+
+// ApplySync atualiza os convites pendentes da conta a partir do estado lido:
+// perfis nas conexões viram aceitos; perfis ausentes da lista completa de
+// convites enviados viram expirados quando a janela de conexões lida cobre a
+// data do convite (senão poderiam ter sido aceitos antes dessa janela)
+func ApplySync(store storage.Store, account string, result crawler.SyncResult, now time.Time) (SyncSummary, error) {
+	connected := make(map[string]crawler.Connection, len(result.Connections))
+	for _, conn := range result.Connections {
+		connected[strings.ToLower(conn.LinkedIn)] = conn
+	}
+	pending := make(map[string]bool, len(result.Pending))
+	for _, inv := range result.Pending {
+		pending[strings.ToLower(inv.LinkedIn)] = true
+	}
+
+	var summary SyncSummary
+	_, err := store.UpdateInvites(func(record *crawler.InviteRecord) bool {
+		if !strings.EqualFold(record.UserEmail, account) || record.LinkedInURL == "" {
+			return false
+		}
+		key := strings.ToLower(crawler.NormalizeProfileURL(record.LinkedInURL))
+
+		if conn, ok := connected[key]; ok {
+			if record.Status == crawler.InviteStatusAccepted {
+				return false
+			}
+			record.Status = crawler.InviteStatusAccepted
+			record.AcceptedAt = conn.ConnectedAt
+			if record.AcceptedAt.IsZero() {
+				record.AcceptedAt = now
+			}
+			summary.Accepted++
+			return true
+		}
+
+		if record.Status != crawler.InviteStatusPending {
+			return false
+		}
+		if pending[key] {
+			summary.Pending++
+			return false
+		}
+		if !result.PendingComplete || result.OldestConnection.IsZero() || !result.OldestConnection.Before(record.Timestamp) {
+			summary.Unknown++
+			return false
+		}
+
+		record.Status = crawler.InviteStatusExpired
+		record.ExpiredAt = now
+		summary.Expired++
+		return true
+	})
+	return summary, err
+}
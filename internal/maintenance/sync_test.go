@@ -0,0 +1,94 @@
+package maintenance
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// chdirTemp roda o teste em um diretório vazio (o armazenamento CSV usa data/)
+func chdirTemp(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestApplySyncHourTexts(t *testing.T) {
+	chdirTemp(t)
+	store, err := storage.NewCSVStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	const account = "vendas@empresa.com"
+	invites := []crawler.InviteRecord{
+		{Timestamp: now.AddDate(0, 0, -5), UserEmail: account, ProfileName: "Ana", LinkedInURL: "https://www.linkedin.com/in/ana"},
+		{Timestamp: now.AddDate(0, 0, -5), UserEmail: account, ProfileName: "Bruno", LinkedInURL: "https://www.linkedin.com/in/bruno"},
+		{Timestamp: now.AddDate(0, 0, -5), UserEmail: account, ProfileName: "Carla", LinkedInURL: "https://www.linkedin.com/in/carla"},
+	}
+	for _, inv := range invites {
+		if err := store.AppendInvite(inv); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Conexões de poucas horas atrás: a janela lida não cobre convites de 5
+	// dias, então Carla (fora das conexões e dos pendentes) fica indeterminada
+	var result crawler.SyncResult
+	for _, c := range []struct{ url, text string }{
+		{"https://www.linkedin.com/in/ana", "Connected 3 hours ago"},
+		{"https://www.linkedin.com/in/zeca", "Conectado há 1 hora"},
+		{"https://www.linkedin.com/in/lia", "Connected an hour ago"},
+	} {
+		at, ok := crawler.ParseConnectedDate(c.text, now)
+		if !ok {
+			t.Fatalf("ParseConnectedDate(%q) não reconhecido", c.text)
+		}
+		result.Connections = append(result.Connections, crawler.Connection{LinkedIn: c.url, ConnectedText: c.text, ConnectedAt: at})
+		if result.OldestConnection.IsZero() || at.Before(result.OldestConnection) {
+			result.OldestConnection = at
+		}
+	}
+	result.Pending = []crawler.SentInvitation{{LinkedIn: "https://www.linkedin.com/in/bruno"}}
+	result.PendingComplete = true
+
+	if want := now.Add(-3 * time.Hour); !result.OldestConnection.Equal(want) {
+		t.Fatalf("OldestConnection = %v, esperado %v", result.OldestConnection, want)
+	}
+
+	summary, err := ApplySync(store, account, result, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary != (SyncSummary{Accepted: 1, Pending: 1, Unknown: 1}) {
+		t.Fatalf("resumo = %+v", summary)
+	}
+
+	records, err := store.InvitesByURL(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := map[string]crawler.InviteRecord{}
+	for _, r := range records {
+		status[r.ProfileName] = r
+	}
+	if ana := status["Ana"]; ana.Status != crawler.InviteStatusAccepted || !ana.AcceptedAt.Equal(now.Add(-3*time.Hour)) {
+		t.Errorf("Ana = %s aceito em %v, esperado aceito em %v", ana.Status, ana.AcceptedAt, now.Add(-3*time.Hour))
+	}
+	if got := status["Bruno"].Status; got != crawler.InviteStatusPending {
+		t.Errorf("Bruno = %s, esperado pendente", got)
+	}
+	if got := status["Carla"].Status; got != crawler.InviteStatusPending {
+		t.Errorf("Carla = %s, esperado pendente (indeterminado)", got)
+	}
+}
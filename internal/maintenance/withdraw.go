@@ -0,0 +1,133 @@
+package maintenance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// DefaultWithdrawAge idade padrão a partir da qual convites pendentes são retirados
+const DefaultWithdrawAge = 21 * 24 * time.Hour
+
+// WithdrawOptions filtros da retirada de convites pendentes
+type WithdrawOptions struct {
+	OlderThan      time.Duration
+	Query          string // só convites registrados com query que contém este texto
+	Company        string // só convites para esta empresa (registro ou headline)
+	MaxWithdrawals int    // 0 = sem limite
+	Headless       bool
+}
+
+// ageUnits unidades aceitas por ParseAge (dias e semanas, EN e PT)
+var ageUnits = map[string]time.Duration{
+	"d":       24 * time.Hour,
+	"day":     24 * time.Hour,
+	"days":    24 * time.Hour,
+	"dia":     24 * time.Hour,
+	"dias":    24 * time.Hour,
+	"w":       7 * 24 * time.Hour,
+	"week":    7 * 24 * time.Hour,
+	"weeks":   7 * 24 * time.Hour,
+	"sem":     7 * 24 * time.Hour,
+	"semana":  7 * 24 * time.Hour,
+	"semanas": 7 * 24 * time.Hour,
+}
+
+// ParseAge interpreta idades em dias ou semanas ("21d", "3w", "21 dias",
+// "3 semanas"). Horas, minutos e segundos são recusados: a idade mínima é
+// de 1 dia, para que um valor pequeno não retire todos os convites pendentes.
+func ParseAge(text string) (time.Duration, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return 0, fmt.Errorf("idade não informada")
+	}
+
+	i := strings.IndexFunc(text, func(r rune) bool { return r < '0' || r > '9' })
+	if i <= 0 {
+		return 0, fmt.Errorf("idade inválida '%s' (use ex.: 21d, 3w)", text)
+	}
+	unit, ok := ageUnits[strings.TrimSpace(text[i:])]
+	if !ok {
+		return 0, fmt.Errorf("idade inválida '%s' (use dias ou semanas, ex.: 21d, 3w)", text)
+	}
+	n, err := strconv.Atoi(text[:i])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("idade deve ser de pelo menos 1 dia: %s", text)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// Withdraw retira os convites pendentes da conta mais antigos que opts.OlderThan,
+// aplicando os filtros de query/empresa, e registra cada retirada no convite
+// correspondente do storage
+func Withdraw(store storage.Store, opts WithdrawOptions, creds crawler.Creds, logf func(string)) (int, error) {
+	if opts.OlderThan <= 0 {
+		opts.OlderThan = DefaultWithdrawAge
+	}
+
+	records, err := store.InvitesByURL(creds.Email)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao carregar convites registrados: %v", err)
+	}
+
+	query := strings.ToLower(strings.TrimSpace(opts.Query))
+	company := strings.ToLower(strings.TrimSpace(opts.Company))
+
+	logf(fmt.Sprintf("Retirando convites pendentes há mais de %s (query=%q, empresa=%q)",
+		FormatAge(opts.OlderThan), opts.Query, opts.Company))
+
+	callbacks := crawler.WithdrawCallbacks{
+		ShouldWithdraw: func(inv crawler.SentInvitation) bool {
+			if query == "" && company == "" {
+				return true
+			}
+
+			// Filtros dependem do registro do convite feito pelo crawler
+			record, ok := records[strings.ToLower(inv.LinkedIn)]
+			if !ok {
+				return false
+			}
+			if query != "" && !strings.Contains(strings.ToLower(record.Query), query) {
+				return false
+			}
+			if company != "" &&
+				!strings.Contains(strings.ToLower(record.Company), company) &&
+				!strings.Contains(strings.ToLower(inv.Headline), company) {
+				return false
+			}
+			return true
+		},
+		OnWithdrawn: func(inv crawler.SentInvitation) {
+			found, err := store.MarkWithdrawn(creds.Email, inv.LinkedIn, time.Now())
+			if err != nil {
+				logf(fmt.Sprintf("Erro ao registrar retirada de %s: %v", inv.Name, err))
+				return
+			}
+			if !found {
+				logf(fmt.Sprintf("Convite de %s não foi enviado pelo crawler - retirada não registrada", inv.Name))
+			}
+		},
+		OnLog: logf,
+	}
+
+	cfg := crawler.WithdrawConfig{
+		OlderThan:      opts.OlderThan,
+		MaxWithdrawals: opts.MaxWithdrawals,
+		Headless:       opts.Headless,
+	}
+
+	return crawler.NewEngine().Withdraw(cfg, creds, callbacks)
+}
+
+// FormatAge formata a idade em dias ("21d") quando exata, senão como duração Go
+func FormatAge(d time.Duration) string {
+	day := 24 * time.Hour
+	if d%day == 0 {
+		return fmt.Sprintf("%dd", d/day)
+	}
+	return d.String()
+}
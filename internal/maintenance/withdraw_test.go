@@ -0,0 +1,46 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	const day = 24 * time.Hour
+
+	tests := []struct {
+		text string
+		want time.Duration
+		ok   bool
+	}{
+		{"21d", 21 * day, true},
+		{"1d", day, true},
+		{"3w", 21 * day, true},
+		{" 3W ", 21 * day, true},
+		{"21 dias", 21 * day, true},
+		{"1 dia", day, true},
+		{"3 semanas", 21 * day, true},
+		{"2 weeks", 14 * day, true},
+		{"10 days", 10 * day, true},
+		{"30s", 0, false}, // segundos em Go, não semanas
+		{"1m", 0, false},
+		{"10h", 0, false},
+		{"72h", 0, false},
+		{"36h30m", 0, false},
+		{"0d", 0, false},
+		{"-3d", 0, false},
+		{"d", 0, false},
+		{"21", 0, false},
+		{"21x", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := ParseAge(tt.text)
+			if (err == nil) != tt.ok || got != tt.want {
+				t.Errorf("ParseAge(%q) = (%v, %v), esperado (%v, ok=%v)", tt.text, got, err, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobStatus representa o estado de uma execução no orquestrador
+type JobStatus string
+
+const (
+	StatusQueued  JobStatus = "queued"
+	StatusRunning JobStatus = "running"
+	StatusDone    JobStatus = "done"
+	StatusFailed  JobStatus = "failed"
+)
+
+// maxHistory limita quantas execuções finalizadas ficam em memória
+const maxHistory = 100
+
+// Job representa uma execução (um Chrome) associada a uma conta do LinkedIn
+type Job struct {
+	ID         string    `json:"id"`
+	Account    string    `json:"account"`
+	Label      string    `json:"label"`
+	Status     JobStatus `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	QueuedAt   time.Time `json:"queued_at"`
+	StartedAt  time.Time `json:"started_at,omitempty"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+	Stats      JobStats  `json:"stats"`
+
+	task func() error
+	done chan struct{}
+}
+
+// JobStats contadores da execução, informados pela tarefa via Orchestrator.Count
+type JobStats struct {
+	Captured int `json:"captured"`
+	Invites  int `json:"invites"`
+}
+
+// Wait bloqueia até a execução terminar e retorna o erro final (se houver)
+func (j *Job) Wait() error {
+	<-j.done
+	if j.Error != "" {
+		return fmt.Errorf("%s", j.Error)
+	}
+	return nil
+}
+
+// Snapshot visão consistente do orquestrador para o dashboard
+type Snapshot struct {
+	MaxChrome int   `json:"max_chrome"`
+	Running   int   `json:"running"`
+	Queued    int   `json:"queued"`
+	Jobs      []Job `json:"jobs"`
+}
+
+// Orchestrator gerencia um pool limitado de engines concorrentes:
+// no máximo uma execução ativa por conta e no máximo maxChrome
+// processos do Chrome no host. Execuções excedentes ficam na fila.
+type Orchestrator struct {
+	mu        sync.Mutex
+	maxChrome int
+	running   int
+	busy      map[string]bool
+	queue     []*Job
+	jobs      map[string]*Job
+	finished  []*Job
+	onChange  func()
+}
+
+// New cria novo orquestrador com limite de processos do Chrome
+func New(maxChrome int) *Orchestrator {
+	if maxChrome <= 0 {
+		maxChrome = 1
+	}
+	return &Orchestrator{
+		maxChrome: maxChrome,
+		busy:      make(map[string]bool),
+		jobs:      make(map[string]*Job),
+	}
+}
+
+// OnChange registra função chamada sempre que o estado das execuções muda
+func (o *Orchestrator) OnChange(fn func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.onChange = fn
+}
+
+// Submit enfileira uma execução para a conta informada e a inicia assim
+// que a conta estiver livre e houver um slot de Chrome disponível
+func (o *Orchestrator) Submit(account, label string, task func() error) *Job {
+	job := &Job{
+		ID:       uuid.New().String(),
+		Account:  normalizeAccount(account),
+		Label:    label,
+		Status:   StatusQueued,
+		QueuedAt: time.Now(),
+		task:     task,
+		done:     make(chan struct{}),
+	}
+
+	o.mu.Lock()
+	o.jobs[job.ID] = job
+	o.queue = append(o.queue, job)
+	o.dispatchLocked()
+	o.mu.Unlock()
+
+	o.notify()
+	return job
+}
+
+// Get retorna uma cópia da execução pelo ID
+func (o *Orchestrator) Get(id string) (Job, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	job, ok := o.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return job.view(), true
+}
+
+// Count soma capturados e convites aos contadores da execução
+func (o *Orchestrator) Count(id string, captured, invites int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if job, ok := o.jobs[id]; ok {
+		job.Stats.Captured += captured
+		job.Stats.Invites += invites
+	}
+}
+
+// IsBusy informa se a conta possui execução ativa
+func (o *Orchestrator) IsBusy(account string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.busy[normalizeAccount(account)]
+}
+
+// Snapshot retorna o estado atual de todas as execuções conhecidas,
+// com as ativas primeiro, depois a fila e por fim o histórico recente
+func (o *Orchestrator) Snapshot() Snapshot {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	snap := Snapshot{
+		MaxChrome: o.maxChrome,
+		Running:   o.running,
+		Queued:    len(o.queue),
+	}
+
+	for _, job := range o.jobs {
+		snap.Jobs = append(snap.Jobs, job.view())
+	}
+
+	rank := map[JobStatus]int{StatusRunning: 0, StatusQueued: 1, StatusFailed: 2, StatusDone: 2}
+	sort.SliceStable(snap.Jobs, func(i, j int) bool {
+		a, b := snap.Jobs[i], snap.Jobs[j]
+		if rank[a.Status] != rank[b.Status] {
+			return rank[a.Status] < rank[b.Status]
+		}
+		return a.QueuedAt.After(b.QueuedAt)
+	})
+
+	return snap
+}
+
+// dispatchLocked inicia, em ordem de chegada, todas as execuções da fila
+// cuja conta esteja livre enquanto houver slots de Chrome. Deve ser
+// chamado com o mutex adquirido.
+func (o *Orchestrator) dispatchLocked() {
+	remaining := o.queue[:0]
+	for _, job := range o.queue {
+		if o.running >= o.maxChrome || o.busy[job.Account] {
+			remaining = append(remaining, job)
+			continue
+		}
+
+		o.running++
+		o.busy[job.Account] = true
+		job.Status = StatusRunning
+		job.StartedAt = time.Now()
+		go o.execute(job)
+	}
+	o.queue = remaining
+}
+
+// execute roda a tarefa da execução e libera a conta e o slot ao final
+func (o *Orchestrator) execute(job *Job) {
+	err := runTask(job.task)
+
+	o.mu.Lock()
+	job.FinishedAt = time.Now()
+	if err != nil {
+		job.Status = StatusFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = StatusDone
+	}
+	job.task = nil
+
+	o.running--
+	delete(o.busy, job.Account)
+	o.archiveLocked(job)
+	o.dispatchLocked()
+	o.mu.Unlock()
+
+	close(job.done)
+	o.notify()
+}
+
+// archiveLocked mantém apenas as últimas execuções finalizadas em memória
+func (o *Orchestrator) archiveLocked(job *Job) {
+	o.finished = append(o.finished, job)
+	for len(o.finished) > maxHistory {
+		delete(o.jobs, o.finished[0].ID)
+		o.finished = o.finished[1:]
+	}
+}
+
+func (o *Orchestrator) notify() {
+	o.mu.Lock()
+	fn := o.onChange
+	o.mu.Unlock()
+	if fn != nil {
+		fn()
+	}
+}
+
+// view retorna cópia exportável da execução (sem a tarefa)
+func (j *Job) view() Job {
+	return Job{
+		ID:         j.ID,
+		Account:    j.Account,
+		Label:      j.Label,
+		Status:     j.Status,
+		Error:      j.Error,
+		QueuedAt:   j.QueuedAt,
+		StartedAt:  j.StartedAt,
+		FinishedAt: j.FinishedAt,
+		Stats:      j.Stats,
+	}
+}
+
+// runTask executa a tarefa convertendo panics em erro para não derrubar o pool
+func runTask(task func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic na execução: %v", r)
+		}
+	}()
+	return task()
+}
+
+func normalizeAccount(account string) string {
+	return strings.ToLower(strings.TrimSpace(account))
+}
@@ -0,0 +1,164 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitFor espera a condição por até 2s
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("tempo esgotado esperando %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestConcurrencyCap(t *testing.T) {
+	o := New(2)
+	release := make(chan struct{})
+	var running, peak int32
+
+	var jobs []*Job
+	for i := 0; i < 5; i++ {
+		jobs = append(jobs, o.Submit(fmt.Sprintf("conta%d@exemplo.com", i), "", func() error {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			<-release
+			atomic.AddInt32(&running, -1)
+			return nil
+		}))
+	}
+
+	waitFor(t, "2 execuções ativas", func() bool { return atomic.LoadInt32(&running) == 2 })
+	snap := o.Snapshot()
+	if snap.Running != 2 || snap.Queued != 3 {
+		t.Errorf("Snapshot = %d ativas, %d na fila; esperado 2 ativas, 3 na fila", snap.Running, snap.Queued)
+	}
+
+	close(release)
+	for _, job := range jobs {
+		if err := job.Wait(); err != nil {
+			t.Errorf("Wait() = %v", err)
+		}
+	}
+	if peak != 2 {
+		t.Errorf("pico de execuções simultâneas = %d, esperado 2", peak)
+	}
+}
+
+func TestOneRunPerAccountInQueueOrder(t *testing.T) {
+	o := New(4)
+	var mu sync.Mutex
+	var order []string
+	gate := make(chan struct{})
+
+	first := o.Submit("Vendas@Exemplo.com", "primeira", func() error {
+		<-gate
+		mu.Lock()
+		order = append(order, "primeira")
+		mu.Unlock()
+		return nil
+	})
+	second := o.Submit("vendas@exemplo.com", "segunda", func() error {
+		mu.Lock()
+		order = append(order, "segunda")
+		mu.Unlock()
+		return nil
+	})
+	other := o.Submit("outra@exemplo.com", "outra", func() error { return nil })
+
+	// A outra conta não espera pela fila da primeira
+	if err := other.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if job, _ := o.Get(second.ID); job.Status != StatusQueued {
+		t.Errorf("segunda execução da conta = %s, esperado %s enquanto a primeira roda", job.Status, StatusQueued)
+	}
+	if !o.IsBusy("VENDAS@exemplo.com") {
+		t.Error("IsBusy = false, esperado true com execução ativa")
+	}
+
+	close(gate)
+	first.Wait()
+	second.Wait()
+	if strings.Join(order, ",") != "primeira,segunda" {
+		t.Errorf("ordem = %v, esperado [primeira segunda]", order)
+	}
+	if o.IsBusy("vendas@exemplo.com") {
+		t.Error("IsBusy = true, esperado false após as execuções")
+	}
+}
+
+func TestPanicAndErrorRecovery(t *testing.T) {
+	o := New(1)
+
+	panicked := o.Submit("a@exemplo.com", "", func() error { panic("falhou feio") })
+	if err := panicked.Wait(); err == nil || !strings.Contains(err.Error(), "falhou feio") {
+		t.Errorf("Wait() após panic = %v, esperado erro com a mensagem do panic", err)
+	}
+	failed := o.Submit("a@exemplo.com", "", func() error { return errors.New("sem login") })
+	if err := failed.Wait(); err == nil || err.Error() != "sem login" {
+		t.Errorf("Wait() = %v, esperado 'sem login'", err)
+	}
+
+	// O slot foi liberado e o pool continua funcionando
+	ok := o.Submit("a@exemplo.com", "", func() error { return nil })
+	if err := ok.Wait(); err != nil {
+		t.Errorf("Wait() = %v, esperado nil", err)
+	}
+
+	for id, want := range map[string]JobStatus{panicked.ID: StatusFailed, failed.ID: StatusFailed, ok.ID: StatusDone} {
+		if job, _ := o.Get(id); job.Status != want {
+			t.Errorf("status de %s = %s, esperado %s", id, job.Status, want)
+		}
+	}
+}
+
+func TestHistoryLimitAndCounters(t *testing.T) {
+	o := New(3)
+	var jobs []*Job
+	for i := 0; i < maxHistory+5; i++ {
+		id := make(chan string, 1)
+		job := o.Submit(fmt.Sprintf("conta%d@exemplo.com", i%3), "", func() error {
+			o.Count(<-id, 2, 1)
+			return nil
+		})
+		id <- job.ID
+		jobs = append(jobs, job)
+	}
+	for _, job := range jobs {
+		job.Wait()
+	}
+
+	if n := len(o.Snapshot().Jobs); n != maxHistory {
+		t.Errorf("Snapshot com %d execuções, esperado %d", n, maxHistory)
+	}
+	kept := 0
+	for _, job := range jobs {
+		got, ok := o.Get(job.ID)
+		if !ok {
+			continue
+		}
+		kept++
+		if got.Stats != (JobStats{Captured: 2, Invites: 1}) {
+			t.Errorf("Stats = %+v, esperado {Captured:2 Invites:1}", got.Stats)
+		}
+	}
+	if kept != maxHistory {
+		t.Errorf("%d execuções retidas, esperado %d", kept, maxHistory)
+	}
+}
@@ -0,0 +1,611 @@
+package privacy
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/export"
+	"github.com/your-org/linkedin-visible-crawler/internal/push"
+	"github.com/your-org/linkedin-visible-crawler/internal/sequences"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// Tipos de registro de auditoria
+const (
+	KindErasure   = "erasure"   // pedido de remoção de uma pessoa
+	KindRetention = "retention" // remoção automática por idade
+)
+
+// Subject pessoa de um pedido de acesso ou remoção: URL do perfil e/ou nome
+// completo (o nome pode coincidir com homônimos; prefira a URL)
+type Subject struct {
+	URL  string `json:"url,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// key URL do perfil normalizada, em minúsculas
+func (s Subject) key() string {
+	return profileKey(s.URL)
+}
+
+// profileKey perfil normalizado a partir de "/in/" (o mesmo perfil aparece
+// com ou sem "https://www."), em minúsculas
+func profileKey(url string) string {
+	key := strings.ToLower(crawler.NormalizeProfileURL(strings.TrimSpace(url)))
+	if i := strings.Index(key, "/in/"); i >= 0 {
+		return key[i:]
+	}
+	return key
+}
+
+// name nome normalizado (minúsculas, espaços simples)
+func (s Subject) name() string {
+	return strings.ToLower(strings.Join(strings.Fields(s.Name), " "))
+}
+
+// Validate exige URL de perfil (/in/) ou nome
+func (s Subject) Validate() error {
+	if strings.TrimSpace(s.URL) != "" && !strings.Contains(s.key(), "/in/") {
+		return fmt.Errorf("URL de perfil inválida: %s (esperado .../in/<perfil>)", s.URL)
+	}
+	if s.key() == "" && s.name() == "" {
+		return fmt.Errorf("informe a URL do perfil ou o nome")
+	}
+	return nil
+}
+
+// Match indica se o registro (URL e nome) pertence à pessoa
+func (s Subject) Match(url, name string) bool {
+	if key := s.key(); key != "" && url != "" && profileKey(url) == key {
+		return true
+	}
+	if n := s.name(); n != "" && strings.ToLower(strings.Join(strings.Fields(name), " ")) == n {
+		return true
+	}
+	return false
+}
+
+// MatchText indica se um texto livre (linha de log, registro exportado)
+// menciona o perfil ou o nome da pessoa
+func (s Subject) MatchText(text string) bool {
+	text = strings.ToLower(text)
+	if key := s.key(); key != "" && containsWord(text, key) {
+		return true
+	}
+	if n := s.name(); n != "" && containsWord(strings.Join(strings.Fields(text), " "), n) {
+		return true
+	}
+	return false
+}
+
+// containsWord procura word em text sem aceitar letras/dígitos colados às
+// pontas da palavra ("/in/ana" não encontra "/in/anabela")
+func containsWord(text, word string) bool {
+	wordChar := func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' }
+	first, _ := utf8.DecodeRuneInString(word)
+	last, _ := utf8.DecodeLastRuneInString(word)
+	for from := 0; ; {
+		i := strings.Index(text[from:], word)
+		if i < 0 {
+			return false
+		}
+		start, end := from+i, from+i+len(word)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !(wordChar(first) && wordChar(before)) && !(wordChar(last) && wordChar(after)) {
+			return true
+		}
+		from = start + 1
+	}
+}
+
+// criteria como a pessoa foi identificada
+func (s Subject) criteria() string {
+	var parts []string
+	if s.key() != "" {
+		parts = append(parts, "url")
+	}
+	if s.name() != "" {
+		parts = append(parts, "name")
+	}
+	return strings.Join(parts, "+")
+}
+
+// Report dados registrados sobre uma pessoa (pedido de acesso)
+type Report struct {
+	Subject     Subject                 `json:"subject"`
+	GeneratedAt time.Time               `json:"generated_at"`
+	Invites     []crawler.InviteRecord  `json:"invites"`
+	Captures    []crawler.CaptureRecord `json:"captures"`
+	Contacts    []storage.ContactRecord `json:"contacts"`
+	Messages    []crawler.MessageRecord `json:"messages"`
+	Enrollments []sequences.Enrollment  `json:"enrollments"`
+	PushFailed  []export.Record         `json:"push_failed"`          // envios ao CRM não entregues
+	Quarantine  []string                `json:"quarantine,omitempty"` // linhas truncadas dos CSVs
+	Runs        []storage.RunRecord     `json:"runs,omitempty"`       // execuções que citam a pessoa
+	Counts      map[string]int          `json:"counts"`               // registros por origem
+}
+
+// AuditRecord registro de uma remoção em data/erasures.jsonl. A pessoa é
+// identificada só por um HMAC com o segredo local (data/privacy_secret), para
+// que o registro não guarde os dados apagados nem permita testar nomes ou
+// URLs candidatos sem o segredo.
+type AuditRecord struct {
+	ID          string         `json:"id"`
+	Timestamp   time.Time      `json:"timestamp"`
+	Kind        string         `json:"kind"`   // erasure ou retention
+	Source      string         `json:"source"` // web, cli ou auto
+	SubjectHash string         `json:"subject_hash,omitempty"`
+	Criteria    string         `json:"criteria"` // url, name, url+name ou "older_than=<N>d"
+	Removed     map[string]int `json:"removed"`  // registros removidos por origem
+	Errors      []string       `json:"errors,omitempty"`
+}
+
+// Total registros removidos
+func (r AuditRecord) Total() int {
+	total := 0
+	for _, n := range r.Removed {
+		total += n
+	}
+	return total
+}
+
+// Origens dos dados pessoais (chaves de Report.Counts e AuditRecord.Removed)
+var Sources = []string{"invites", "captures", "contacts", "messages", "enrollments", "push_failed", "quarantine", "runs"}
+
+// Manager exportação, remoção e retenção dos dados pessoais guardados em
+// convites, capturas, contatos, mensagens, inscrições de follow-up, envios
+// não entregues, quarentena dos CSVs e relatórios de execução
+type Manager struct {
+	mu         sync.Mutex // uma remoção por vez
+	store      storage.Store
+	messages   *storage.MessageLog
+	seqs       *sequences.Manager
+	auditPath  string
+	secretPath string
+	secret     []byte // chave do HMAC da auditoria (carregada no primeiro uso)
+	retention  int    // dias (0 = desativada)
+}
+
+// New cria o gerenciador (seqs pode ser nil); auditoria em data/erasures.jsonl
+func New(store storage.Store, messages *storage.MessageLog, seqs *sequences.Manager) *Manager {
+	return &Manager{
+		store:      store,
+		messages:   messages,
+		seqs:       seqs,
+		auditPath:  filepath.Join("data", "erasures.jsonl"),
+		secretPath: filepath.Join("data", "privacy_secret"),
+	}
+}
+
+// Hash identifica a pessoa no registro de auditoria sem guardar os dados
+// apagados: HMAC-SHA256 da URL e do nome com o segredo local
+func (m *Manager) Hash(s Subject) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	secret, err := m.loadSecret()
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(s.key() + "|" + s.name()))
+	return hex.EncodeToString(mac.Sum(nil)[:12]), nil
+}
+
+// loadSecret lê o segredo de data/privacy_secret, gerando-o (modo 0600) no
+// primeiro uso (m.mu deve estar travado)
+func (m *Manager) loadSecret() ([]byte, error) {
+	if m.secret != nil {
+		return m.secret, nil
+	}
+
+	data, err := os.ReadFile(m.secretPath)
+	if err == nil {
+		secret, err := hex.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil || len(secret) < 16 {
+			return nil, fmt.Errorf("segredo de privacidade inválido em %s", m.secretPath)
+		}
+		m.secret = secret
+		return secret, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("erro ao ler segredo de privacidade: %v", err)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("erro ao gerar segredo de privacidade: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(m.secretPath), 0755); err != nil {
+		return nil, err
+	}
+	tmp := m.secretPath + ".tmp"
+	if err := os.WriteFile(tmp, []byte(hex.EncodeToString(secret)+"\n"), 0600); err != nil {
+		return nil, fmt.Errorf("erro ao salvar segredo de privacidade: %v", err)
+	}
+	if err := os.Rename(tmp, m.secretPath); err != nil {
+		return nil, fmt.Errorf("erro ao salvar segredo de privacidade: %v", err)
+	}
+	m.secret = secret
+	return secret, nil
+}
+
+// SetRetention define a retenção em dias (0 = desativada)
+func (m *Manager) SetRetention(days int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.retention = days
+}
+
+// Retention retenção em dias (0 = desativada)
+func (m *Manager) Retention() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.retention
+}
+
+// Matchers por tipo de registro (nome exibido e nome bruto do LinkedIn)
+func (s Subject) matchInvite(r crawler.InviteRecord) bool {
+	return s.Match(r.LinkedInURL, r.ProfileName) || s.Match("", r.RawName)
+}
+
+func (s Subject) matchCapture(r crawler.CaptureRecord) bool {
+	return s.Match(r.LinkedIn, r.Name) || s.Match("", r.RawName)
+}
+
+func (s Subject) matchContact(r storage.ContactRecord) bool {
+	return s.Match(r.Key, r.Contact.Name) || s.Match("", r.Contact.RawName)
+}
+
+func (s Subject) matchMessage(r crawler.MessageRecord) bool {
+	return s.Match(r.LinkedInURL, r.ProfileName)
+}
+
+func (s Subject) matchEnrollment(en sequences.Enrollment) bool {
+	return s.Match(en.LinkedInURL, en.Name)
+}
+
+// matchRun a execução cita a pessoa (perfis e queries da configuração,
+// contadores por query, avisos ou erro final)
+func (s Subject) matchRun(r storage.RunRecord) bool {
+	run := r
+	return s.scrubRun(&run)
+}
+
+// scrubRun tira da execução o que cita a pessoa; retorna true se algo mudou.
+// Trabalha sobre cópias, então também serve para só verificar (matchRun).
+func (s Subject) scrubRun(r *storage.RunRecord) bool {
+	changed := false
+	keep := func(values []string, match func(string) bool) []string {
+		var kept []string
+		for _, v := range values {
+			if match(v) {
+				changed = true
+				continue
+			}
+			kept = append(kept, v)
+		}
+		return kept
+	}
+
+	if r.Config != nil {
+		cfg := *r.Config
+		cfg.Profiles = keep(cfg.Profiles, func(url string) bool { return s.Match(url, "") || s.MatchText(url) })
+		cfg.Queries = keep(cfg.Queries, s.MatchText)
+		r.Config = &cfg
+	}
+
+	var queries []crawler.QueryStats
+	for _, q := range r.Queries {
+		if s.MatchText(q.Query) || s.MatchText(q.Error) {
+			changed = true
+			continue
+		}
+		queries = append(queries, q)
+	}
+	r.Queries = queries
+	r.Warnings = keep(r.Warnings, s.MatchText)
+
+	if s.MatchText(r.Error) {
+		r.Error = "[removido]"
+		changed = true
+	}
+	return changed
+}
+
+func (s Subject) matchRecord(r export.Record) bool {
+	keys := make([]string, 0, len(r))
+	for k := range r {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make([]string, 0, len(r))
+	for _, k := range keys {
+		values = append(values, r[k])
+	}
+	return s.MatchText(strings.Join(values, " , "))
+}
+
+// Export reúne tudo o que está registrado sobre a pessoa (pedido de acesso)
+func (m *Manager) Export(subject Subject) (Report, error) {
+	if err := subject.Validate(); err != nil {
+		return Report{}, err
+	}
+	report := Report{Subject: subject, GeneratedAt: time.Now()}
+
+	invites, _, err := m.store.SearchInvites(storage.InviteQuery{Asc: true})
+	if err != nil {
+		return report, err
+	}
+	for _, r := range invites {
+		if subject.matchInvite(r) {
+			report.Invites = append(report.Invites, r)
+		}
+	}
+
+	captures, err := m.store.ListCaptures()
+	if err != nil {
+		return report, err
+	}
+	for _, r := range captures {
+		if subject.matchCapture(r) {
+			report.Captures = append(report.Captures, r)
+		}
+	}
+
+	contacts, _, err := m.store.ListContacts(storage.ContactQuery{})
+	if err != nil {
+		return report, err
+	}
+	for _, r := range contacts {
+		if subject.matchContact(r) {
+			report.Contacts = append(report.Contacts, r)
+		}
+	}
+
+	messages, err := m.messages.List()
+	if err != nil {
+		return report, err
+	}
+	for _, r := range messages {
+		if subject.matchMessage(r) {
+			report.Messages = append(report.Messages, r)
+		}
+	}
+
+	if m.seqs != nil {
+		for _, en := range m.seqs.Enrollments() {
+			if subject.matchEnrollment(en) {
+				report.Enrollments = append(report.Enrollments, en)
+			}
+		}
+	}
+
+	if report.PushFailed, err = push.FailedRecords(subject.matchRecord); err != nil {
+		return report, err
+	}
+	if report.Quarantine, err = storage.QuarantineLines(subject.MatchText); err != nil {
+		return report, err
+	}
+
+	runs, err := m.store.ListRuns(0)
+	if err != nil {
+		return report, err
+	}
+	for i := len(runs) - 1; i >= 0; i-- {
+		if subject.matchRun(runs[i]) {
+			report.Runs = append(report.Runs, runs[i])
+		}
+	}
+
+	report.Counts = map[string]int{
+		"invites":     len(report.Invites),
+		"captures":    len(report.Captures),
+		"contacts":    len(report.Contacts),
+		"messages":    len(report.Messages),
+		"enrollments": len(report.Enrollments),
+		"push_failed": len(report.PushFailed),
+		"quarantine":  len(report.Quarantine),
+		"runs":        len(report.Runs),
+	}
+	return report, nil
+}
+
+// step remoção em uma origem
+type step struct {
+	source string
+	run    func() (int, error)
+}
+
+// Erase remove os registros da pessoa de todas as origens e grava a
+// auditoria. Uma origem com erro não interrompe as demais; os erros ficam
+// no registro de auditoria e são retornados.
+func (m *Manager) Erase(subject Subject, source string) (AuditRecord, error) {
+	if err := subject.Validate(); err != nil {
+		return AuditRecord{}, err
+	}
+
+	record := AuditRecord{Kind: KindErasure, Source: source, Criteria: subject.criteria()}
+	hash, err := m.Hash(subject)
+	if err != nil {
+		record.Errors = append(record.Errors, fmt.Sprintf("hash: %v", err))
+	}
+	record.SubjectHash = hash
+	return m.apply(record, []step{
+		{"invites", func() (int, error) { return m.store.DeleteInvites(subject.matchInvite) }},
+		{"captures", func() (int, error) { return m.store.DeleteCaptures(subject.matchCapture) }},
+		{"contacts", func() (int, error) { return m.store.DeleteContacts(subject.matchContact) }},
+		{"messages", func() (int, error) { return m.messages.Delete(subject.matchMessage) }},
+		{"enrollments", func() (int, error) { return m.removeEnrollments(subject.matchEnrollment) }},
+		{"push_failed", func() (int, error) { return push.EraseFailed(subject.matchRecord) }},
+		{"quarantine", func() (int, error) { return storage.ScrubQuarantine(subject.MatchText) }},
+		{"runs", func() (int, error) { return m.store.UpdateRuns(subject.scrubRun) }},
+	}, true)
+}
+
+// Purge remove os registros com mais de days dias (convites, capturas,
+// contatos não vistos desde então, mensagens, inscrições encerradas, envios
+// não entregues, arquivos da quarentena e execuções). Só grava auditoria se algo saiu.
+func (m *Manager) Purge(days int, source string) (AuditRecord, error) {
+	if days <= 0 {
+		return AuditRecord{}, fmt.Errorf("retenção inválida: %d dias", days)
+	}
+	cutoff := time.Now().AddDate(0, 0, -days)
+
+	record := AuditRecord{Kind: KindRetention, Source: source, Criteria: fmt.Sprintf("older_than=%dd", days)}
+	return m.apply(record, []step{
+		{"invites", func() (int, error) {
+			return m.store.DeleteInvites(func(r crawler.InviteRecord) bool { return r.Timestamp.Before(cutoff) })
+		}},
+		{"captures", func() (int, error) {
+			return m.store.DeleteCaptures(func(r crawler.CaptureRecord) bool { return r.Timestamp.Before(cutoff) })
+		}},
+		{"contacts", func() (int, error) {
+			return m.store.DeleteContacts(func(r storage.ContactRecord) bool { return r.LastSeen.Before(cutoff) })
+		}},
+		{"messages", func() (int, error) {
+			return m.messages.Delete(func(r crawler.MessageRecord) bool { return r.Timestamp.Before(cutoff) })
+		}},
+		{"enrollments", func() (int, error) {
+			return m.removeEnrollments(func(en sequences.Enrollment) bool {
+				return en.Stopped() && en.StoppedAt.Before(cutoff)
+			})
+		}},
+		{"push_failed", func() (int, error) { return push.PurgeFailed(cutoff) }},
+		{"quarantine", func() (int, error) { return storage.PurgeQuarantine(cutoff) }},
+		{"runs", func() (int, error) {
+			return m.store.DeleteRuns(func(r storage.RunRecord) bool { return r.StartedAt.Before(cutoff) })
+		}},
+	}, false)
+}
+
+// removeEnrollments inscrições de follow-up (sem sequências configuradas: nada)
+func (m *Manager) removeEnrollments(match func(en sequences.Enrollment) bool) (int, error) {
+	if m.seqs == nil {
+		return 0, nil
+	}
+	return m.seqs.RemoveEnrollments(match)
+}
+
+// apply executa as remoções e grava o registro de auditoria
+func (m *Manager) apply(record AuditRecord, steps []step, always bool) (AuditRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record.ID = uuid.New().String()
+	record.Timestamp = time.Now()
+	record.Removed = map[string]int{}
+	for _, st := range steps {
+		n, err := st.run()
+		record.Removed[st.source] = n
+		if err != nil {
+			record.Errors = append(record.Errors, fmt.Sprintf("%s: %v", st.source, err))
+		}
+	}
+
+	if always || record.Total() > 0 || len(record.Errors) > 0 {
+		if err := m.audit(record); err != nil {
+			record.Errors = append(record.Errors, fmt.Sprintf("auditoria: %v", err))
+		}
+	}
+	if len(record.Errors) > 0 {
+		return record, fmt.Errorf("remoção incompleta: %s", strings.Join(record.Errors, "; "))
+	}
+	return record, nil
+}
+
+// audit acrescenta o registro a data/erasures.jsonl (m.mu deve estar travado)
+func (m *Manager) audit(record AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(m.auditPath), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(m.auditPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Audit últimos n registros de auditoria, do mais recente para o mais antigo
+func (m *Manager) Audit(n int) ([]AuditRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, err := os.Open(m.auditPath)
+	if os.IsNotExist(err) {
+		return []AuditRecord{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir auditoria: %v", err)
+	}
+	defer file.Close()
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record AuditRecord
+		if json.Unmarshal(scanner.Bytes(), &record) == nil {
+			records = append(records, record)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler auditoria: %v", err)
+	}
+
+	out := make([]AuditRecord, 0, n)
+	for i := len(records) - 1; i >= 0 && len(out) < n; i-- {
+		out = append(out, records[i])
+	}
+	return out, nil
+}
+
+// StartRetention aplica a retenção agora e a cada 24 horas (days <= 0: nada)
+func (m *Manager) StartRetention(days int, onLog func(line string)) {
+	m.SetRetention(days)
+	if days <= 0 {
+		return
+	}
+
+	go func() {
+		for {
+			record, err := m.Purge(days, "auto")
+			switch {
+			case err != nil:
+				onLog(fmt.Sprintf("⚠️ Retenção (%d dias): %v", days, err))
+			case record.Total() > 0:
+				onLog(fmt.Sprintf("🧹 Retenção (%d dias): %d registros removidos %v", days, record.Total(), record.Removed))
+			}
+			time.Sleep(24 * time.Hour)
+		}
+	}()
+}
@@ -0,0 +1,201 @@
+package privacy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/sequences"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// chdirTemp roda o teste em um diretório vazio (o armazenamento CSV usa data/)
+func chdirTemp(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestEraseAcrossSources(t *testing.T) {
+	chdirTemp(t)
+	store, err := storage.NewCSVStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := storage.NewMessageLog()
+
+	now := time.Now().Truncate(time.Second)
+	const account = "vendas@empresa.com"
+	people := []crawler.Contact{
+		{Name: "Ana Souza", LinkedIn: "https://www.linkedin.com/in/ana-souza"},
+		{Name: "Bruno Lima", LinkedIn: "https://www.linkedin.com/in/bruno-lima"},
+	}
+	for _, p := range people {
+		if err := store.AppendInvite(crawler.InviteRecord{Timestamp: now, UserEmail: account, ProfileName: p.Name, LinkedInURL: p.LinkedIn, Status: crawler.InviteStatusPending}); err != nil {
+			t.Fatal(err)
+		}
+		capture := crawler.CaptureRecord{Timestamp: now, UserEmail: account, Contact: p}
+		if err := store.AppendCapture(capture); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.UpsertContact(capture); err != nil {
+			t.Fatal(err)
+		}
+		if err := messages.Append(crawler.MessageRecord{Timestamp: now, UserEmail: account, LinkedInURL: p.LinkedIn, ProfileName: p.Name, Step: 1, Text: "Olá!"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run := storage.RunRecord{
+		ID: "run-1", Account: account, StartedAt: now,
+		Config:   &crawler.RunConfig{Profiles: []string{people[0].LinkedIn, people[1].LinkedIn}},
+		Warnings: []string{"perfil /in/ana-souza indisponível"},
+	}
+	if err := store.SaveRun(run); err != nil {
+		t.Fatal(err)
+	}
+
+	m := New(store, messages, nil)
+	subject := Subject{URL: "linkedin.com/in/ana-souza/"}
+	record, err := m.Erase(subject, "cli")
+	if err != nil {
+		t.Fatalf("Erase: %v", err)
+	}
+
+	want := map[string]int{"invites": 1, "captures": 1, "contacts": 1, "messages": 1, "runs": 1}
+	for source, n := range want {
+		if record.Removed[source] != n {
+			t.Errorf("Removed[%s] = %d, esperado %d", source, record.Removed[source], n)
+		}
+	}
+
+	// Nada da pessoa sobra; a outra pessoa continua
+	report, err := m.Export(subject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for source, n := range report.Counts {
+		if n != 0 {
+			t.Errorf("após remoção, Counts[%s] = %d, esperado 0", source, n)
+		}
+	}
+	other, err := m.Export(Subject{URL: people[1].LinkedIn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, source := range []string{"invites", "captures", "contacts", "messages", "runs"} {
+		if other.Counts[source] != 1 {
+			t.Errorf("outra pessoa: Counts[%s] = %d, esperado 1", source, other.Counts[source])
+		}
+	}
+
+	// Auditoria: HMAC com segredo local, não o SHA-256 puro dos dados
+	audit, err := m.Audit(1)
+	if err != nil || len(audit) != 1 {
+		t.Fatalf("Audit(1) = %v, %v", audit, err)
+	}
+	plain := sha256.Sum256([]byte(subject.key() + "|" + subject.name()))
+	if audit[0].SubjectHash == "" || audit[0].SubjectHash == hex.EncodeToString(plain[:12]) {
+		t.Errorf("SubjectHash = %q, esperado HMAC com o segredo local", audit[0].SubjectHash)
+	}
+
+	info, err := os.Stat(filepath.Join("data", "privacy_secret"))
+	if err != nil {
+		t.Fatalf("segredo não foi gravado: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("permissão do segredo = %o, esperado 600", perm)
+	}
+
+	// Outro gerenciador reutiliza o mesmo segredo
+	hash, err := New(store, messages, nil).Hash(subject)
+	if err != nil || hash != audit[0].SubjectHash {
+		t.Errorf("Hash com segredo relido = %q, %v; esperado %q", hash, err, audit[0].SubjectHash)
+	}
+}
+
+// Remoção feita pela CLI com o servidor rodando: as gravações seguintes do
+// servidor não trazem a pessoa de volta
+func TestEraseSurvivesRunningServer(t *testing.T) {
+	chdirTemp(t)
+	const account = "vendas@empresa.com"
+	now := time.Now().Truncate(time.Second)
+	ana := crawler.Contact{Name: "Ana Souza", LinkedIn: "https://www.linkedin.com/in/ana-souza"}
+	bruno := crawler.Contact{Name: "Bruno Lima", LinkedIn: "https://www.linkedin.com/in/bruno-lima"}
+
+	os.MkdirAll("data", 0755)
+	seeded := `{"sequences":[{"id":"s1","name":"Boas-vindas","steps":[{"delay_days":0,"template":"Olá!"}],"active":true}],
+"enrollments":[{"sequence_id":"s1","account":"` + account + `","linkedin_url":"` + ana.LinkedIn + `","name":"Ana Souza"},
+{"sequence_id":"s1","account":"` + account + `","linkedin_url":"` + bruno.LinkedIn + `","name":"Bruno Lima"}]}`
+	if err := os.WriteFile(filepath.Join("data", "sequences.json"), []byte(seeded), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Servidor
+	serverStore, err := storage.NewCSVStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverSeqs, err := sequences.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []crawler.Contact{ana, bruno} {
+		if err := serverStore.UpsertContact(crawler.CaptureRecord{Timestamp: now, UserEmail: account, Contact: p}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// CLI
+	cliStore, err := storage.NewCSVStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cliSeqs, err := sequences.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := New(cliStore, storage.NewMessageLog(), cliSeqs).Erase(Subject{URL: ana.LinkedIn}, "cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Removed["contacts"] != 1 || record.Removed["enrollments"] != 1 {
+		t.Fatalf("Removed = %v, esperado 1 contato e 1 inscrição", record.Removed)
+	}
+
+	// O servidor continua gravando com o estado que tinha em memória
+	if err := serverStore.UpsertContact(crawler.CaptureRecord{Timestamp: now.Add(time.Minute), UserEmail: account, Contact: bruno}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := serverSeqs.Create("Outra", "", "1 | Oi!"); err != nil {
+		t.Fatal(err)
+	}
+
+	freshStore, err := storage.NewCSVStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	freshSeqs, err := sequences.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := New(freshStore, storage.NewMessageLog(), freshSeqs).Export(Subject{URL: ana.LinkedIn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Counts["contacts"] != 0 || report.Counts["enrollments"] != 0 {
+		t.Errorf("após gravações do servidor, Counts = %v, esperado nada da pessoa removida", report.Counts)
+	}
+	if n := len(freshSeqs.Enrollments()); n != 1 {
+		t.Errorf("%d inscrições, esperado só a do Bruno", n)
+	}
+}
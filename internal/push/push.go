@@ -0,0 +1,469 @@
+package push
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/export"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// ConfigFile configuração do envio de convites para um endpoint HTTP
+const ConfigFile = "data/push.json"
+
+// failedFile lotes que esgotaram as tentativas (um JSON por linha)
+var failedFile = filepath.Join("data", "push_failed.jsonl")
+
+// failedMu serializa gravações e regravações de failedFile
+var failedMu sync.Mutex
+
+// Config conteúdo de data/push.json
+type Config struct {
+	Enabled        bool              `json:"enabled"`
+	URL            string            `json:"url"`
+	Headers        map[string]string `json:"headers,omitempty"`         // ex.: Authorization
+	Profile        string            `json:"profile,omitempty"`         // perfil de CRM (hubspot, pipedrive, salesforce)
+	Fields         map[string]string `json:"fields,omitempty"`          // campo no destino → coluna do convite
+	Envelope       string            `json:"envelope,omitempty"`        // chave da lista no corpo (padrão "records")
+	BatchSize      int               `json:"batch_size,omitempty"`      // padrão 20
+	FlushSeconds   int               `json:"flush_seconds,omitempty"`   // envio de lotes incompletos (padrão 30)
+	MaxRetries     int               `json:"max_retries,omitempty"`     // padrão 3; -1 = sem novas tentativas
+	TimeoutSeconds int               `json:"timeout_seconds,omitempty"` // padrão 10
+}
+
+// LoadConfig lê a configuração; retorna nil se o arquivo não existir
+func LoadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler %s: %v", path, err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(content, &cfg); err != nil {
+		return nil, fmt.Errorf("JSON inválido em %s: %v", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// validate confere URL, perfil e colunas de origem e aplica os padrões
+func (cfg *Config) validate() error {
+	if cfg.Enabled && !strings.HasPrefix(cfg.URL, "http://") && !strings.HasPrefix(cfg.URL, "https://") {
+		return fmt.Errorf("url inválida para o envio de convites: %q", cfg.URL)
+	}
+	if cfg.Profile != "" {
+		if _, err := export.LookupProfile(cfg.Profile); err != nil {
+			return err
+		}
+	}
+	header := storage.InviteHeader()
+	for target, source := range cfg.Fields {
+		if !containsString(header, source) {
+			return fmt.Errorf("campo %s: coluna desconhecida %s (disponíveis: %s)", target, source, strings.Join(header, ", "))
+		}
+	}
+	if cfg.Envelope == "" {
+		cfg.Envelope = "records"
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 20
+	}
+	if cfg.FlushSeconds <= 0 {
+		cfg.FlushSeconds = 30
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = 3
+	}
+	if cfg.TimeoutSeconds <= 0 {
+		cfg.TimeoutSeconds = 10
+	}
+	return nil
+}
+
+// Pusher envia convites em lotes para o endpoint configurado, com novas
+// tentativas (backoff exponencial) em erros de rede, 429 e 5xx
+type Pusher struct {
+	cfg        Config
+	client     *http.Client
+	retryDelay time.Duration // espera antes da primeira nova tentativa (dobra a cada uma)
+	onLog      func(line string)
+
+	mu      sync.Mutex
+	queue   []export.Record
+	closed  bool // Stop já foi chamado
+	flush   chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// New cria o envio a partir da configuração (ver LoadConfig)
+func New(cfg Config) (*Pusher, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &Pusher{
+		cfg:        cfg,
+		client:     &http.Client{Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second},
+		retryDelay: time.Second,
+		onLog:      func(string) {},
+		flush:      make(chan struct{}, 1),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}, nil
+}
+
+// OnLog define o destino das mensagens de log
+func (p *Pusher) OnLog(fn func(line string)) {
+	p.onLog = fn
+}
+
+// Map converte o convite nos campos enviados: perfil de CRM (se houver),
+// mais os campos mapeados em Fields; sem perfil nem Fields, todas as colunas
+func (p *Pusher) Map(invite crawler.InviteRecord) export.Record {
+	rec := export.InviteRecord(invite)
+	out := export.Record{}
+	if p.cfg.Profile != "" {
+		profile, _ := export.LookupProfile(p.cfg.Profile)
+		out = profile.Map(rec)
+	} else if len(p.cfg.Fields) == 0 {
+		out = rec
+	}
+	for target, source := range p.cfg.Fields {
+		out[target] = rec[source]
+	}
+	return out
+}
+
+// Start inicia o envio periódico dos lotes em background
+func (p *Pusher) Start() {
+	go func() {
+		defer close(p.stopped)
+		ticker := time.NewTicker(time.Duration(p.cfg.FlushSeconds) * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.sendQueued()
+			case <-p.flush:
+				p.sendQueued()
+			case <-p.done:
+				p.sendQueued()
+				return
+			}
+		}
+	}()
+}
+
+// Stop envia o que está na fila (uma tentativa por lote, sem esperar novas
+// tentativas) e encerra o envio em background; o que não for entregue vai
+// para data/push_failed.jsonl
+func (p *Pusher) Stop() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	close(p.done)
+	<-p.stopped
+}
+
+// errStopped causa registrada para convites recebidos depois de Stop
+var errStopped = errors.New("envio encerrado")
+
+// Enqueue adiciona um convite à fila; lotes completos são enviados logo.
+// Depois de Stop, o convite vai direto para data/push_failed.jsonl.
+func (p *Pusher) Enqueue(invite crawler.InviteRecord) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		if err := saveFailed([]export.Record{p.Map(invite)}, errStopped); err != nil {
+			p.onLog(fmt.Sprintf("Aviso: erro ao gravar convite não enviado: %v", err))
+		}
+		return
+	}
+	p.queue = append(p.queue, p.Map(invite))
+	full := len(p.queue) >= p.cfg.BatchSize
+	p.mu.Unlock()
+
+	if full {
+		select {
+		case p.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// sendQueued esvazia a fila em lotes
+func (p *Pusher) sendQueued() {
+	p.mu.Lock()
+	records := p.queue
+	p.queue = nil
+	p.mu.Unlock()
+
+	if len(records) > 0 {
+		p.Send(records)
+	}
+}
+
+// Send envia os registros em lotes de BatchSize, de forma síncrona. Lotes que
+// falham após todas as tentativas vão para data/push_failed.jsonl.
+func (p *Pusher) Send(records []export.Record) (sent int, err error) {
+	for start := 0; start < len(records); start += p.cfg.BatchSize {
+		end := start + p.cfg.BatchSize
+		if end > len(records) {
+			end = len(records)
+		}
+		batch := records[start:end]
+
+		if batchErr := p.sendBatch(batch); batchErr != nil {
+			p.onLog(fmt.Sprintf("❌ Envio de %d convites para %s falhou: %v", len(batch), p.cfg.URL, batchErr))
+			if saveErr := saveFailed(batch, batchErr); saveErr != nil {
+				p.onLog(fmt.Sprintf("Aviso: erro ao gravar lote com falha: %v", saveErr))
+			}
+			err = batchErr
+			continue
+		}
+		sent += len(batch)
+		p.onLog(fmt.Sprintf("📤 %d convites enviados para %s", len(batch), p.cfg.URL))
+	}
+	return sent, err
+}
+
+// Body corpo JSON de um lote ({"records": [...]})
+func (p *Pusher) Body(batch []export.Record) ([]byte, error) {
+	return json.Marshal(map[string][]export.Record{p.cfg.Envelope: batch})
+}
+
+// sendBatch faz o POST com novas tentativas (interrompidas por Stop)
+func (p *Pusher) sendBatch(batch []export.Record) error {
+	body, err := p.Body(batch)
+	if err != nil {
+		return err
+	}
+
+	delay := p.retryDelay
+	for attempt := 0; ; attempt++ {
+		retry, err := p.post(body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= p.cfg.MaxRetries {
+			return err
+		}
+		if p.stopping() {
+			return err
+		}
+		p.onLog(fmt.Sprintf("⚠️ Envio de convites: %v (nova tentativa em %s)", err, delay))
+		select {
+		case <-time.After(delay):
+		case <-p.done:
+			return err
+		}
+		delay *= 2
+	}
+}
+
+// stopping indica se Stop já foi chamado
+func (p *Pusher) stopping() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// post envia o corpo; retry indica se o erro é transitório
+func (p *Pusher) post(body []byte) (retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, p.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "linkedin-visible-crawler")
+	for key, value := range p.cfg.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("status %d", resp.StatusCode)
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
+
+// failedBatch linha de failedFile
+type failedBatch struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Error     string          `json:"error"`
+	Records   []export.Record `json:"records"`
+}
+
+// saveFailed registra o lote que não pôde ser entregue
+func saveFailed(batch []export.Record, cause error) error {
+	line, err := json.Marshal(failedBatch{time.Now(), cause.Error(), batch})
+	if err != nil {
+		return err
+	}
+
+	failedMu.Lock()
+	defer failedMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(failedFile), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(failedFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// readFailed lê os lotes de failedFile (failedMu deve estar travado)
+func readFailed() ([]failedBatch, error) {
+	content, err := os.ReadFile(failedFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var batches []failedBatch
+	for _, line := range strings.Split(string(content), "\n") {
+		var batch failedBatch
+		if json.Unmarshal([]byte(line), &batch) == nil {
+			batches = append(batches, batch)
+		}
+	}
+	return batches, nil
+}
+
+// FailedRecords registros de lotes não entregues para os quais match retorna true
+func FailedRecords(match func(record export.Record) bool) ([]export.Record, error) {
+	failedMu.Lock()
+	defer failedMu.Unlock()
+
+	batches, err := readFailed()
+	if err != nil {
+		return nil, err
+	}
+	var out []export.Record
+	for _, batch := range batches {
+		for _, record := range batch.Records {
+			if match(record) {
+				out = append(out, record)
+			}
+		}
+	}
+	return out, nil
+}
+
+// EraseFailed remove dos lotes não entregues os registros para os quais
+// match retorna true (lotes que ficam vazios são descartados)
+func EraseFailed(match func(record export.Record) bool) (int, error) {
+	return rewriteFailed(func(_ failedBatch, record export.Record) bool { return !match(record) })
+}
+
+// PurgeFailed descarta os lotes não entregues registrados antes de before
+func PurgeFailed(before time.Time) (int, error) {
+	return rewriteFailed(func(batch failedBatch, _ export.Record) bool { return !batch.Timestamp.Before(before) })
+}
+
+// rewriteFailed regrava failedFile só com os registros mantidos (arquivo
+// temporário + rename). Retorna quantos registros foram removidos.
+func rewriteFailed(keep func(batch failedBatch, record export.Record) bool) (int, error) {
+	failedMu.Lock()
+	defer failedMu.Unlock()
+
+	batches, err := readFailed()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	var buf bytes.Buffer
+	for _, batch := range batches {
+		records := batch.Records[:0]
+		for _, record := range batch.Records {
+			if keep(batch, record) {
+				records = append(records, record)
+			} else {
+				removed++
+			}
+		}
+		if len(records) == 0 {
+			continue
+		}
+		batch.Records = records
+		line, err := json.Marshal(batch)
+		if err != nil {
+			return 0, err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if removed == 0 {
+		return 0, nil
+	}
+
+	tmp := failedFile + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0644); err != nil {
+		return 0, fmt.Errorf("erro ao gravar %s: %v", failedFile, err)
+	}
+	return removed, os.Rename(tmp, failedFile)
+}
+
+// Describe resumo da configuração para logs
+func (cfg Config) Describe() string {
+	fields := make([]string, 0, len(cfg.Fields))
+	for target := range cfg.Fields {
+		fields = append(fields, target)
+	}
+	sort.Strings(fields)
+	desc := fmt.Sprintf("%s (lotes de %d", cfg.URL, cfg.BatchSize)
+	if cfg.Profile != "" {
+		desc += ", perfil " + cfg.Profile
+	}
+	if len(fields) > 0 {
+		desc += ", campos " + strings.Join(fields, ",")
+	}
+	return desc + ")"
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
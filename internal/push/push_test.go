@@ -0,0 +1,192 @@
+package push
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/export"
+)
+
+// chdirTemp executa o teste em um diretório temporário (data/ relativo)
+func chdirTemp(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+// endpoint servidor de teste que responde com os status da lista (o último
+// se repete) e guarda os corpos recebidos
+type endpoint struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   []map[string][]export.Record
+	headers  []http.Header
+}
+
+func newEndpoint(t *testing.T, statuses ...int) (*endpoint, *httptest.Server) {
+	e := &endpoint{statuses: statuses}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var decoded map[string][]export.Record
+		json.Unmarshal(body, &decoded)
+
+		e.mu.Lock()
+		status := e.statuses[len(e.statuses)-1]
+		if len(e.bodies) < len(e.statuses) {
+			status = e.statuses[len(e.bodies)]
+		}
+		e.bodies = append(e.bodies, decoded)
+		e.headers = append(e.headers, r.Header.Clone())
+		e.mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return e, srv
+}
+
+func (e *endpoint) attempts() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.bodies)
+}
+
+func newTestPusher(t *testing.T, cfg Config) *Pusher {
+	t.Helper()
+	p, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.retryDelay = time.Millisecond
+	return p
+}
+
+func invite(i int) crawler.InviteRecord {
+	return crawler.InviteRecord{
+		Timestamp:    time.Date(2026, 10, 14, 9, i, 0, 0, time.UTC),
+		UserEmail:    "vendas@empresa.com",
+		ProfileName:  fmt.Sprintf("Pessoa %d", i),
+		ProfileTitle: "CTO",
+		LinkedInURL:  fmt.Sprintf("https://www.linkedin.com/in/pessoa-%d", i),
+		Status:       crawler.InviteStatusPending,
+	}
+}
+
+// failedCount registros gravados em data/push_failed.jsonl
+func failedCount(t *testing.T) int {
+	t.Helper()
+	records, err := FailedRecords(func(export.Record) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(records)
+}
+
+func TestPusherBatchesAndMapsFields(t *testing.T) {
+	chdirTemp(t)
+	e, srv := newEndpoint(t, http.StatusOK)
+	p := newTestPusher(t, Config{
+		Enabled:   true,
+		URL:       srv.URL,
+		Headers:   map[string]string{"Authorization": "Bearer segredo"},
+		Fields:    map[string]string{"email": "user_email", "perfil": "linkedin_url", "nome": "profile_name"},
+		Envelope:  "contacts",
+		BatchSize: 2,
+	})
+	p.Start()
+	for i := 1; i <= 3; i++ {
+		p.Enqueue(invite(i))
+	}
+	p.Stop()
+
+	if len(e.bodies) != 2 || len(e.bodies[0]["contacts"]) != 2 || len(e.bodies[1]["contacts"]) != 1 {
+		t.Fatalf("lotes recebidos = %v, esperado 2 lotes (2 e 1 registros) em \"contacts\"", e.bodies)
+	}
+	want := export.Record{"email": "vendas@empresa.com", "perfil": "https://www.linkedin.com/in/pessoa-1", "nome": "Pessoa 1"}
+	if got := e.bodies[0]["contacts"][0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("registro enviado = %v, esperado %v", got, want)
+	}
+	for _, h := range e.headers {
+		if h.Get("Authorization") != "Bearer segredo" || h.Get("Content-Type") != "application/json" {
+			t.Errorf("cabeçalhos = %v, esperado Authorization e Content-Type", h)
+		}
+	}
+	if n := failedCount(t); n != 0 {
+		t.Errorf("%d registros em push_failed.jsonl, esperado 0", n)
+	}
+}
+
+func TestPusherRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []int
+		maxRetries int
+		attempts   int
+		sent       int
+	}{
+		{"429 e 503 depois sucesso", []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK}, 3, 3, 1},
+		{"5xx esgota as tentativas", []int{http.StatusInternalServerError}, 2, 3, 0},
+		{"4xx não tenta de novo", []int{http.StatusBadRequest, http.StatusOK}, 3, 1, 0},
+		{"sem novas tentativas", []int{http.StatusBadGateway, http.StatusOK}, -1, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdirTemp(t)
+			e, srv := newEndpoint(t, tt.statuses...)
+			p := newTestPusher(t, Config{Enabled: true, URL: srv.URL, MaxRetries: tt.maxRetries})
+
+			sent, err := p.Send([]export.Record{p.Map(invite(1))})
+			if sent != tt.sent || (err != nil) != (tt.sent == 0) {
+				t.Errorf("Send = %d, %v; esperado %d enviados", sent, err, tt.sent)
+			}
+			if n := e.attempts(); n != tt.attempts {
+				t.Errorf("%d tentativas, esperado %d", n, tt.attempts)
+			}
+			if n, want := failedCount(t), 1-tt.sent; n != want {
+				t.Errorf("%d registros em push_failed.jsonl, esperado %d", n, want)
+			}
+		})
+	}
+}
+
+func TestStopDrainsQueueToFailedFile(t *testing.T) {
+	chdirTemp(t)
+	e, srv := newEndpoint(t, http.StatusServiceUnavailable)
+	p := newTestPusher(t, Config{Enabled: true, URL: srv.URL, BatchSize: 10, FlushSeconds: 3600})
+	p.retryDelay = time.Hour // Stop não espera novas tentativas
+
+	p.Start()
+	p.Enqueue(invite(1))
+	p.Enqueue(invite(2))
+	p.Stop()
+
+	if n := e.attempts(); n != 1 {
+		t.Errorf("%d tentativas no encerramento, esperado 1", n)
+	}
+	if n := failedCount(t); n != 2 {
+		t.Errorf("%d registros em push_failed.jsonl após Stop, esperado 2", n)
+	}
+
+	// Depois de Stop, o convite vai direto para o arquivo de falhas
+	p.Enqueue(invite(3))
+	if n := failedCount(t); n != 3 {
+		t.Errorf("%d registros em push_failed.jsonl após Enqueue pós-Stop, esperado 3", n)
+	}
+	p.Stop()
+}
@@ -0,0 +1,197 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronExpr expressão cron de 5 campos: minuto hora dia-do-mês mês dia-da-semana
+type CronExpr struct {
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	anyDom  bool
+	anyDow  bool
+	literal string
+}
+
+// cronField limites de cada campo da expressão
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minuto", 0, 59},
+	{"hora", 0, 23},
+	{"dia do mês", 1, 31},
+	{"mês", 1, 12},
+	{"dia da semana", 0, 7},
+}
+
+// cronAliases atalhos aceitos no lugar da expressão completa
+var cronAliases = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 1",
+	"@monthly": "0 0 1 * *",
+}
+
+// ParseCron interpreta uma expressão cron com suporte a *, listas (1,2),
+// intervalos (1-5) e passos (*/15, 8-18/2). Domingo pode ser 0 ou 7.
+func ParseCron(expr string) (CronExpr, error) {
+	expr = strings.TrimSpace(expr)
+	spec := expr
+	if alias, ok := cronAliases[strings.ToLower(expr)]; ok {
+		spec = alias
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return CronExpr{}, fmt.Errorf("expressão cron deve ter 5 campos: %q", expr)
+	}
+
+	masks := make([]uint64, len(parts))
+	for i, part := range parts {
+		mask, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return CronExpr{}, err
+		}
+		masks[i] = mask
+	}
+
+	// Domingo como 7 equivale a 0
+	if masks[4]&(1<<7) != 0 {
+		masks[4] |= 1
+	}
+
+	return CronExpr{
+		minute:  masks[0],
+		hour:    masks[1],
+		dom:     masks[2],
+		month:   masks[3],
+		dow:     masks[4],
+		anyDom:  isAnyField(parts[2], masks[2], 1, 31),
+		anyDow:  isAnyField(parts[4], masks[4], 0, 6), // domingo como 7 já está no bit 0
+		literal: expr,
+	}, nil
+}
+
+// isAnyField indica se o campo aceita qualquer valor: começa com "*" (como
+// "*/2", regra do cron clássico) ou cobre todo o intervalo ("1-31", "0-6")
+func isAnyField(field string, mask uint64, lo, hi int) bool {
+	if strings.HasPrefix(field, "*") {
+		return true
+	}
+	for v := lo; v <= hi; v++ {
+		if mask&(1<<uint(v)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// String retorna a expressão original
+func (c CronExpr) String() string {
+	return c.literal
+}
+
+// Next retorna o próximo instante (com precisão de minuto) após t que
+// satisfaz a expressão, ou zero se nenhum for encontrado em 5 anos
+func (c CronExpr) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches aplica a regra clássica do cron: se dia do mês e dia da
+// semana forem ambos restritos, basta um deles coincidir
+func (c CronExpr) dayMatches(t time.Time) bool {
+	domOK := c.dom&(1<<uint(t.Day())) != 0
+	dowOK := c.dow&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case c.anyDom && c.anyDow:
+		return true
+	case c.anyDom:
+		return dowOK
+	case c.anyDow:
+		return domOK
+	default:
+		return domOK || dowOK
+	}
+}
+
+// parseCronField converte um campo em máscara de bits dos valores aceitos
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var mask uint64
+
+	for _, item := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(item, "/"); i != -1 {
+			s, err := strconv.Atoi(item[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("passo inválido no campo %s: %q", spec.name, item)
+			}
+			step = s
+			item = item[:i]
+		}
+
+		lo, hi := spec.min, spec.max
+		switch {
+		case item == "*":
+		case strings.Contains(item, "-"):
+			bounds := strings.SplitN(item, "-", 2)
+			a, errA := strconv.Atoi(bounds[0])
+			b, errB := strconv.Atoi(bounds[1])
+			if errA != nil || errB != nil || a > b {
+				return 0, fmt.Errorf("intervalo inválido no campo %s: %q", spec.name, item)
+			}
+			lo, hi = a, b
+		default:
+			v, err := strconv.Atoi(item)
+			if err != nil {
+				return 0, fmt.Errorf("valor inválido no campo %s: %q", spec.name, item)
+			}
+			lo, hi = v, v
+			if step > 1 {
+				hi = spec.max
+			}
+		}
+
+		if lo < spec.min || hi > spec.max {
+			return 0, fmt.Errorf("valor fora do intervalo %d-%d no campo %s: %q", spec.min, spec.max, spec.name, item)
+		}
+
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+
+	return mask, nil
+}
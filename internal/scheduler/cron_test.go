@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCronNext(t *testing.T) {
+	// quarta-feira, 14/10/2026 10:07
+	from := time.Date(2026, 10, 14, 10, 7, 30, 0, time.UTC)
+	at := func(month time.Month, day, hour, min int) time.Time {
+		year := 2026
+		if month < time.October {
+			year = 2027
+		}
+		return time.Date(year, month, day, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", at(10, 14, 10, 15)},
+		{"0 * * * *", at(10, 14, 11, 0)},
+		{"@hourly", at(10, 14, 11, 0)},
+		{"30 9 * * *", at(10, 15, 9, 30)},
+		{"@daily", at(10, 15, 0, 0)},
+		{"0 9 * * 1-5", at(10, 15, 9, 0)},
+		{"0 9 * * 1", at(10, 19, 9, 0)},
+		{"@weekly", at(10, 19, 0, 0)},
+		{"0 9 * * 0", at(10, 18, 9, 0)},
+		{"0 9 * * 7", at(10, 18, 9, 0)},
+		{"0 8-18/2 * * *", at(10, 14, 12, 0)},
+		{"0 9,17 * * *", at(10, 14, 17, 0)},
+		{"0 0 1 * *", at(11, 1, 0, 0)},
+		{"@monthly", at(11, 1, 0, 0)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// dia do mês e da semana restritos: basta um coincidir
+		{"0 9 20 * 5", at(10, 16, 9, 0)},
+		// "*/2" e intervalo completo contam como "qualquer": vale só o outro campo
+		{"0 9 */2 * 1", at(10, 19, 9, 0)},
+		{"0 9 1-31 * 1", at(10, 19, 9, 0)},
+		{"0 9 20 * 0-6", at(10, 20, 9, 0)},
+		{"0 9 20 * 0-7", at(10, 20, 9, 0)},
+		// nunca dispara
+		{"0 0 31 2 *", time.Time{}},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) = %v", tt.expr, err)
+			}
+			if got := expr.Next(from); !got.Equal(tt.want) {
+				t.Errorf("ParseCron(%q).Next = %v, esperado %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@yearly",
+	}
+
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			if _, err := ParseCron(expr); err == nil {
+				t.Errorf("ParseCron(%q) = nil, esperado erro", expr)
+			}
+		})
+	}
+}
+
+func TestCreateRejectsCronThatNeverFires(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	s, err := New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Create(Schedule{Cron: "0 0 31 2 *", Account: "vendas@empresa.com", QuerySet: "ti"})
+	if err == nil || !strings.Contains(err.Error(), "nunca dispara") {
+		t.Errorf("Create(31/02) = %v, esperado erro 'nunca dispara'", err)
+	}
+	if n := len(s.List()); n != 0 {
+		t.Errorf("%d agendamentos após Create inválido, esperado 0", n)
+	}
+
+	if _, err := s.Create(Schedule{Cron: "0 9 * * 1-5", Account: "vendas@empresa.com", QuerySet: "ti"}); err != nil {
+		t.Errorf("Create(dias úteis) = %v, esperado nil", err)
+	}
+}
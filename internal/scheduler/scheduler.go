@@ -0,0 +1,339 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/linkedin-visible-crawler/internal/orchestrator"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// Outcome resultado de uma execução disparada pelo agendador
+type Outcome string
+
+const (
+	OutcomeDone         Outcome = "done"
+	OutcomeFailed       Outcome = "failed"
+	OutcomeSkippedLimit Outcome = "skipped_limit"
+	OutcomeSkippedError Outcome = "skipped_error"
+)
+
+// maxRunHistory quantos resultados são mantidos por agendamento
+const maxRunHistory = 20
+
+// tickInterval intervalo de verificação de agendamentos vencidos
+const tickInterval = 30 * time.Second
+
+// RunResult registro de uma execução iniciada (ou pulada) pelo agendador
+type RunResult struct {
+	At      time.Time `json:"at"`
+	Outcome Outcome   `json:"outcome"`
+	Message string    `json:"message,omitempty"`
+	JobID   string    `json:"job_id,omitempty"`
+	Invites int       `json:"invites"`
+}
+
+// Schedule agendamento recorrente de uma conta com um conjunto de queries salvo
+type Schedule struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Cron        string      `json:"cron"`
+	Account     string      `json:"account"`
+	QuerySet    string      `json:"query_set"`
+	MaxCards    int         `json:"max_cards"`
+	MaxConnects int         `json:"max_connects"`
+	Headless    bool        `json:"headless"`
+	Paused      bool        `json:"paused"`
+	CreatedAt   time.Time   `json:"created_at"`
+	NextRunAt   time.Time   `json:"next_run_at"`
+	LastRunAt   time.Time   `json:"last_run_at,omitempty"`
+	History     []RunResult `json:"history,omitempty"`
+}
+
+// LastResult retorna o resultado mais recente, se houver
+func (s Schedule) LastResult() *RunResult {
+	if len(s.History) == 0 {
+		return nil
+	}
+	return &s.History[len(s.History)-1]
+}
+
+// Launcher inicia a execução de um agendamento no orquestrador
+type Launcher func(s Schedule) (*orchestrator.Job, error)
+
+// Scheduler mantém agendamentos no formato cron e dispara execuções
+type Scheduler struct {
+	mu        sync.Mutex
+	path      string
+	schedules []*Schedule
+	counter   *storage.WeeklyCounter
+	launch    Launcher
+	logf      func(line string)
+	onLimit   func(account string, count int)
+}
+
+// New carrega os agendamentos de data/schedules.json
+func New(counter *storage.WeeklyCounter) (*Scheduler, error) {
+	s := &Scheduler{
+		path:    filepath.Join("data", "schedules.json"),
+		counter: counter,
+		logf:    func(string) {},
+		onLimit: func(string, int) {},
+	}
+
+	content, err := os.ReadFile(s.path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("erro ao ler agendamentos: %v", err)
+	}
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, &s.schedules); err != nil {
+			return nil, fmt.Errorf("erro ao interpretar agendamentos: %v", err)
+		}
+	}
+
+	// Execuções perdidas enquanto o servidor estava parado não são recuperadas
+	now := time.Now()
+	for _, sc := range s.schedules {
+		if expr, err := ParseCron(sc.Cron); err == nil && sc.NextRunAt.Before(now) {
+			sc.NextRunAt = expr.Next(now)
+		}
+	}
+
+	return s, nil
+}
+
+// SetLauncher define como as execuções agendadas são iniciadas
+func (s *Scheduler) SetLauncher(launch Launcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.launch = launch
+}
+
+// OnLog define destino das mensagens do agendador
+func (s *Scheduler) OnLog(fn func(line string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logf = fn
+}
+
+// OnLimitReached registra função chamada quando um agendamento é pulado
+// porque a conta atingiu o limite semanal ou diário
+func (s *Scheduler) OnLimitReached(fn func(account string, count int)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onLimit = fn
+}
+
+// Start inicia a verificação periódica dos agendamentos
+func (s *Scheduler) Start() {
+	go func() {
+		ticker := time.NewTicker(tickInterval)
+		defer ticker.Stop()
+
+		for now := range ticker.C {
+			s.tick(now)
+		}
+	}()
+}
+
+// List retorna cópia dos agendamentos
+func (s *Scheduler) List() []Schedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]Schedule, 0, len(s.schedules))
+	for _, sc := range s.schedules {
+		cp := *sc
+		cp.History = append([]RunResult(nil), sc.History...)
+		out = append(out, cp)
+	}
+	return out
+}
+
+// Create valida e adiciona novo agendamento
+func (s *Scheduler) Create(sc Schedule) (Schedule, error) {
+	expr, err := ParseCron(sc.Cron)
+	if err != nil {
+		return Schedule{}, err
+	}
+	sc.Account = strings.ToLower(strings.TrimSpace(sc.Account))
+	if sc.Account == "" {
+		return Schedule{}, fmt.Errorf("conta é obrigatória")
+	}
+	if sc.QuerySet == "" {
+		return Schedule{}, fmt.Errorf("conjunto de queries é obrigatório")
+	}
+	if sc.Name == "" {
+		sc.Name = sc.QuerySet
+	}
+
+	sc.ID = uuid.New().String()
+	sc.CreatedAt = time.Now()
+	sc.NextRunAt = expr.Next(sc.CreatedAt)
+	if sc.NextRunAt.IsZero() {
+		return Schedule{}, fmt.Errorf("expressão cron nunca dispara: %q", sc.Cron)
+	}
+	sc.History = nil
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.schedules = append(s.schedules, &sc)
+	if err := s.saveLocked(); err != nil {
+		return Schedule{}, err
+	}
+	return sc, nil
+}
+
+// SetPaused pausa ou retoma um agendamento
+func (s *Scheduler) SetPaused(id string, paused bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sc := s.findLocked(id)
+	if sc == nil {
+		return fmt.Errorf("agendamento não encontrado: %s", id)
+	}
+
+	sc.Paused = paused
+	if !paused {
+		if expr, err := ParseCron(sc.Cron); err == nil {
+			sc.NextRunAt = expr.Next(time.Now())
+		}
+	}
+	return s.saveLocked()
+}
+
+// Delete remove um agendamento
+func (s *Scheduler) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, sc := range s.schedules {
+		if sc.ID == id {
+			s.schedules = append(s.schedules[:i], s.schedules[i+1:]...)
+			return s.saveLocked()
+		}
+	}
+	return fmt.Errorf("agendamento não encontrado: %s", id)
+}
+
+// tick dispara os agendamentos vencidos e calcula a próxima execução
+func (s *Scheduler) tick(now time.Time) {
+	s.mu.Lock()
+	var due []Schedule
+	for _, sc := range s.schedules {
+		if sc.Paused || sc.NextRunAt.IsZero() || sc.NextRunAt.After(now) {
+			continue
+		}
+		expr, err := ParseCron(sc.Cron)
+		if err != nil {
+			continue
+		}
+		sc.LastRunAt = now
+		sc.NextRunAt = expr.Next(now)
+		due = append(due, *sc)
+	}
+	if len(due) > 0 {
+		_ = s.saveLocked()
+	}
+	s.mu.Unlock()
+
+	for _, sc := range due {
+		s.fire(sc)
+	}
+}
+
+// fire verifica os limites da conta e inicia a execução do agendamento,
+// registrando o resultado quando ela terminar
+func (s *Scheduler) fire(sc Schedule) {
+	s.mu.Lock()
+	launch, logf, onLimit := s.launch, s.logf, s.onLimit
+	s.mu.Unlock()
+
+	usage := s.counter.Usage(sc.Account)
+	if !usage.CanSend() {
+		logf(fmt.Sprintf("⏰ Agendamento '%s' pulado: %s", sc.Name, usage.Reason()))
+		onLimit(sc.Account, usage.Week)
+		s.record(sc.ID, RunResult{At: time.Now(), Outcome: OutcomeSkippedLimit, Message: usage.Reason()})
+		return
+	}
+
+	if launch == nil {
+		s.record(sc.ID, RunResult{At: time.Now(), Outcome: OutcomeSkippedError, Message: "agendador sem executor configurado"})
+		return
+	}
+
+	job, err := launch(sc)
+	if err != nil {
+		logf(fmt.Sprintf("⏰ Agendamento '%s' não iniciado: %v", sc.Name, err))
+		s.record(sc.ID, RunResult{At: time.Now(), Outcome: OutcomeSkippedError, Message: err.Error()})
+		return
+	}
+
+	logf(fmt.Sprintf("⏰ Agendamento '%s' iniciado para %s", sc.Name, sc.Account))
+
+	go func() {
+		// Convites contados pela própria execução (o contador semanal da conta
+		// também recebe execuções manuais e muda de janela)
+		runErr := job.Wait()
+		result := RunResult{At: time.Now(), Outcome: OutcomeDone, JobID: job.ID, Invites: job.Stats.Invites}
+		if runErr != nil {
+			result.Outcome = OutcomeFailed
+			result.Message = runErr.Error()
+		}
+		s.record(sc.ID, result)
+	}()
+}
+
+// record anexa o resultado ao histórico do agendamento
+func (s *Scheduler) record(id string, result RunResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sc := s.findLocked(id)
+	if sc == nil {
+		return
+	}
+
+	sc.History = append(sc.History, result)
+	if len(sc.History) > maxRunHistory {
+		sc.History = sc.History[len(sc.History)-maxRunHistory:]
+	}
+	if err := s.saveLocked(); err != nil {
+		s.logf(fmt.Sprintf("Erro ao salvar agendamentos: %v", err))
+	}
+}
+
+func (s *Scheduler) findLocked(id string) *Schedule {
+	for _, sc := range s.schedules {
+		if sc.ID == id {
+			return sc
+		}
+	}
+	return nil
+}
+
+// saveLocked grava os agendamentos de forma atômica (arquivo temporário + rename)
+func (s *Scheduler) saveLocked() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("erro ao criar diretório de agendamentos: %v", err)
+	}
+
+	content, err := json.MarshalIndent(s.schedules, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar agendamentos: %v", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return fmt.Errorf("erro ao gravar agendamentos: %v", err)
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,249 @@
+package sequences
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/maintenance"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// RunOptions configuração de uma execução de follow-ups
+type RunOptions struct {
+	MaxMessages    int // mensagens por execução (padrão 20)
+	MaxConnections int // conexões recentes lidas para detectar aceitações
+	Headless       bool
+}
+
+// RunSummary contagem do resultado de uma execução de follow-ups
+type RunSummary struct {
+	Enrolled int
+	Sent     int
+	Failed   int
+	Replied  int
+	Finished int
+}
+
+// String resumo legível da execução
+func (s RunSummary) String() string {
+	return fmt.Sprintf("%d inscritos, %d enviadas, %d falhas, %d responderam, %d concluídos",
+		s.Enrolled, s.Sent, s.Failed, s.Replied, s.Finished)
+}
+
+// MessageData campos disponíveis nos templates das etapas
+type MessageData struct {
+	Name      string // nome completo
+	FirstName string
+	LastName  string // sobrenome com partículas ("da Silva")
+	Title     string
+	Company   string
+}
+
+// Render aplica os dados do contato ao template da etapa
+func Render(step Step, en Enrollment) (string, error) {
+	tmpl, err := template.New("step").Parse(step.Template)
+	if err != nil {
+		return "", err
+	}
+
+	// Nomes gravados antes da normalização ainda podem trazer emojis ou "MBA"
+	name := crawler.ParseName(en.Name)
+	data := MessageData{
+		Name:      name.Full,
+		FirstName: name.First,
+		LastName:  name.Last,
+		Title:     en.Title,
+		Company:   en.Company,
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// Run detecta conexões recém-aceitas, inscreve-as nas sequências ativas e
+// envia as etapas vencidas, encerrando a sequência de quem respondeu. Cada
+// mensagem (e cada resposta detectada) é registrada no MessageLog.
+func Run(m *Manager, invites storage.Store, messages *storage.MessageLog,
+	opts RunOptions, creds crawler.Creds, logf func(string)) (RunSummary, error) {
+	account := strings.ToLower(creds.Email)
+	if opts.MaxMessages <= 0 {
+		opts.MaxMessages = 20
+	}
+
+	var summary RunSummary
+	err := crawler.NewEngine().WithMessenger(opts.Headless, creds, logf, func(ms *crawler.Messenger) error {
+		// Detectar aceitações pelas conexões recentes
+		connections, err := ms.RecentConnections(opts.MaxConnections)
+		if err != nil {
+			return fmt.Errorf("erro ao ler conexões: %v", err)
+		}
+		synced, err := maintenance.ApplySync(invites, account, crawler.SyncResult{Connections: connections}, time.Now())
+		if err != nil {
+			return fmt.Errorf("erro ao atualizar convites: %v", err)
+		}
+		if synced.Accepted > 0 {
+			logf(fmt.Sprintf("🤝 %d convites aceitos desde a última verificação", synced.Accepted))
+		}
+
+		// Inscrever convites aceitos
+		summary.Enrolled, err = m.enrollAccepted(invites, account)
+		if err != nil {
+			return err
+		}
+
+		// Enviar etapas vencidas
+		for _, en := range m.due(account, time.Now()) {
+			if summary.Sent >= opts.MaxMessages {
+				logf(fmt.Sprintf("Limite de %d mensagens da execução atingido", opts.MaxMessages))
+				break
+			}
+			if err := m.process(ms, messages, en, &summary, logf); err != nil {
+				return err
+			}
+
+			// Jitter entre contatos
+			time.Sleep(time.Duration(2000+time.Now().UnixNano()%2000) * time.Millisecond)
+		}
+		return nil
+	})
+
+	logf("Follow-ups: " + summary.String())
+	return summary, err
+}
+
+// enrollAccepted inscreve nas sequências ativos os convites aceitos da conta
+func (m *Manager) enrollAccepted(invites storage.Store, account string) (int, error) {
+	records, err := invites.InvitesByURL(account)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao carregar convites: %v", err)
+	}
+
+	enrolled := 0
+	for _, record := range records {
+		if record.Status != crawler.InviteStatusAccepted {
+			continue
+		}
+		ok, err := m.enroll(Enrollment{
+			Account:     account,
+			LinkedInURL: crawler.NormalizeProfileURL(record.LinkedInURL),
+			Name:        record.ProfileName,
+			Title:       record.ProfileTitle,
+			Company:     record.Company,
+			AcceptedAt:  record.AcceptedAt,
+		})
+		if err != nil {
+			return enrolled, err
+		}
+		if ok {
+			enrolled++
+		}
+	}
+	return enrolled, nil
+}
+
+// due retorna as inscrições da conta cuja próxima etapa já venceu
+func (m *Manager) due(account string, now time.Time) []Enrollment {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refreshLocked()
+
+	var out []Enrollment
+	for _, en := range m.state.Enrollments {
+		if en.Stopped() || !strings.EqualFold(en.Account, account) {
+			continue
+		}
+		seq := m.findLocked(en.SequenceID)
+		if seq == nil || !seq.Active || en.NextStep >= len(seq.Steps) {
+			continue
+		}
+		if now.Before(en.AcceptedAt.AddDate(0, 0, seq.Steps[en.NextStep].DelayDays)) {
+			continue
+		}
+		out = append(out, *en)
+	}
+	return out
+}
+
+// process verifica resposta e envia a próxima etapa de uma inscrição. Só
+// retorna erro quando a etapa foi enviada mas a inscrição não pôde ser salva:
+// a execução precisa parar, senão a mesma etapa seria enviada de novo.
+func (m *Manager) process(ms *crawler.Messenger, messages *storage.MessageLog, en Enrollment, summary *RunSummary, logf func(string)) error {
+	seq, ok := m.get(en.SequenceID)
+	if !ok || en.NextStep >= len(seq.Steps) {
+		return nil
+	}
+
+	record := crawler.MessageRecord{
+		UserEmail:   en.Account,
+		LinkedInURL: en.LinkedInURL,
+		ProfileName: en.Name,
+		SequenceID:  en.SequenceID,
+		Step:        en.NextStep + 1,
+	}
+	logMessage := func(status, text, errMsg string) {
+		record.Timestamp = time.Now()
+		record.Status, record.Text, record.Error = status, text, errMsg
+		if err := messages.Append(record); err != nil {
+			logf(fmt.Sprintf("Erro ao registrar mensagem: %v", err))
+		}
+	}
+
+	// Resposta encerra a sequência
+	replied, err := ms.HasReplied(en.LinkedInURL)
+	if err != nil {
+		logf(fmt.Sprintf("Não foi possível abrir a conversa com %s: %v", en.Name, err))
+		summary.Failed++
+		return nil
+	}
+	if replied {
+		logMessage(crawler.MessageStatusReplied, "", "")
+		if err := m.update(en, func(e *Enrollment) {
+			e.StopReason, e.StoppedAt = StopReplied, time.Now()
+		}); err != nil {
+			logf(fmt.Sprintf("Erro ao encerrar a sequência de %s: %v", en.Name, err))
+		}
+		summary.Replied++
+		logf(fmt.Sprintf("💬 %s respondeu - sequência '%s' encerrada", en.Name, seq.Name))
+		return nil
+	}
+
+	text, err := Render(seq.Steps[en.NextStep], en)
+	if err != nil {
+		logMessage(crawler.MessageStatusFailed, "", err.Error())
+		summary.Failed++
+		return nil
+	}
+
+	if err := ms.Send(en.LinkedInURL, text); err != nil {
+		logMessage(crawler.MessageStatusFailed, text, err.Error())
+		summary.Failed++
+		logf(fmt.Sprintf("Erro ao enviar etapa %d para %s: %v", en.NextStep+1, en.Name, err))
+		return nil
+	}
+
+	logMessage(crawler.MessageStatusSent, text, "")
+	summary.Sent++
+	logf(fmt.Sprintf("✉️ Etapa %d/%d de '%s' enviada para %s", en.NextStep+1, len(seq.Steps), seq.Name, en.Name))
+
+	err = m.update(en, func(e *Enrollment) {
+		e.NextStep++
+		e.LastSentAt = time.Now()
+		if e.NextStep >= len(seq.Steps) {
+			e.StopReason, e.StoppedAt = StopFinished, e.LastSentAt
+		}
+	})
+	if err != nil {
+		logf(fmt.Sprintf("Erro ao salvar a inscrição de %s após enviar a etapa %d: %v", en.Name, en.NextStep+1, err))
+		return fmt.Errorf("etapa %d enviada para %s, mas a inscrição não foi salva (execução interrompida para não reenviar): %v", en.NextStep+1, en.Name, err)
+	}
+	if en.NextStep+1 >= len(seq.Steps) {
+		summary.Finished++
+	}
+	return nil
+}
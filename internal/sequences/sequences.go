@@ -0,0 +1,390 @@
+package sequences
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+)
+
+// Step etapa de uma sequência: mensagem enviada DelayDays dias após a aceitação
+type Step struct {
+	DelayDays int    `json:"delay_days"`
+	Template  string `json:"template"`
+}
+
+// Sequence sequência ordenada de mensagens de follow-up pós-aceitação
+type Sequence struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Account   string    `json:"account,omitempty"` // vazio = todas as contas
+	Steps     []Step    `json:"steps"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Motivos de encerramento de uma inscrição
+const (
+	StopFinished = "finished" // todas as etapas enviadas
+	StopReplied  = "replied"  // o contato respondeu
+)
+
+// Enrollment contato inscrito em uma sequência após aceitar o convite
+type Enrollment struct {
+	SequenceID  string    `json:"sequence_id"`
+	Account     string    `json:"account"`
+	LinkedInURL string    `json:"linkedin_url"`
+	Name        string    `json:"name"`
+	Title       string    `json:"title,omitempty"`
+	Company     string    `json:"company,omitempty"`
+	AcceptedAt  time.Time `json:"accepted_at"`
+	NextStep    int       `json:"next_step"`
+	LastSentAt  time.Time `json:"last_sent_at,omitempty"`
+	StoppedAt   time.Time `json:"stopped_at,omitempty"`
+	StopReason  string    `json:"stop_reason,omitempty"`
+}
+
+// Stopped indica se a inscrição foi encerrada
+func (en Enrollment) Stopped() bool {
+	return en.StopReason != ""
+}
+
+// state conteúdo de data/sequences.json
+type state struct {
+	Sequences   []*Sequence   `json:"sequences"`
+	Enrollments []*Enrollment `json:"enrollments"`
+}
+
+// Manager mantém sequências e inscrições em data/sequences.json. Servidor e
+// CLI compartilham o arquivo: cada operação trava sequences.json.lock e relê
+// o estado antes de alterá-lo.
+type Manager struct {
+	mu    sync.Mutex
+	path  string
+	state state
+}
+
+// New carrega sequências e inscrições de data/sequences.json
+func New() (*Manager, error) {
+	m := &Manager{path: filepath.Join("data", "sequences.json")}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unlock, err := m.lockLocked(false)
+	if err != nil {
+		return nil, err
+	}
+	unlock()
+	return m, nil
+}
+
+// lockLocked trava o arquivo entre processos e relê o estado gravado (m.mu
+// deve estar travado). Retorna a função que libera a trava.
+func (m *Manager) lockLocked(exclusive bool) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(m.path), 0755); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório de sequências: %v", err)
+	}
+	unlock, err := storage.LockFile(m.path, exclusive)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(m.path)
+	if err != nil && !os.IsNotExist(err) {
+		unlock()
+		return nil, fmt.Errorf("erro ao ler sequências: %v", err)
+	}
+	var st state
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, &st); err != nil {
+			unlock()
+			return nil, fmt.Errorf("erro ao interpretar sequências: %v", err)
+		}
+	}
+	m.state = st
+	return unlock, nil
+}
+
+// refreshLocked relê o estado para leitura; em caso de erro mantém o último
+// estado lido (m.mu deve estar travado)
+func (m *Manager) refreshLocked() {
+	if unlock, err := m.lockLocked(false); err == nil {
+		unlock()
+	}
+}
+
+// ParseSteps interpreta as etapas, uma por linha no formato "dias | mensagem".
+// Os dias contam a partir da aceitação e não podem diminuir entre etapas;
+// "\n" na mensagem vira quebra de linha.
+func ParseSteps(text string) ([]Step, error) {
+	var steps []Step
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "|", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("linha %d: use o formato 'dias | mensagem'", i+1)
+		}
+		days, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+		if err != nil || days < 0 {
+			return nil, fmt.Errorf("linha %d: dias inválidos '%s'", i+1, strings.TrimSpace(parts[0]))
+		}
+		body := strings.ReplaceAll(strings.TrimSpace(parts[1]), `\n`, "\n")
+		if body == "" {
+			return nil, fmt.Errorf("linha %d: mensagem vazia", i+1)
+		}
+		if _, err := template.New("step").Parse(body); err != nil {
+			return nil, fmt.Errorf("linha %d: template inválido: %v", i+1, err)
+		}
+		if len(steps) > 0 && days < steps[len(steps)-1].DelayDays {
+			return nil, fmt.Errorf("linha %d: os dias devem ser crescentes", i+1)
+		}
+
+		steps = append(steps, Step{DelayDays: days, Template: body})
+	}
+
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("a sequência precisa de ao menos uma etapa")
+	}
+	return steps, nil
+}
+
+// List retorna cópia das sequências
+func (m *Manager) List() []Sequence {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refreshLocked()
+
+	out := make([]Sequence, 0, len(m.state.Sequences))
+	for _, seq := range m.state.Sequences {
+		cp := *seq
+		cp.Steps = append([]Step(nil), seq.Steps...)
+		out = append(out, cp)
+	}
+	return out
+}
+
+// Enrollments retorna cópia das inscrições
+func (m *Manager) Enrollments() []Enrollment {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refreshLocked()
+
+	out := make([]Enrollment, 0, len(m.state.Enrollments))
+	for _, en := range m.state.Enrollments {
+		out = append(out, *en)
+	}
+	return out
+}
+
+// Create adiciona uma sequência ativa a partir do texto das etapas
+func (m *Manager) Create(name, account, stepsText string) (Sequence, error) {
+	steps, err := ParseSteps(stepsText)
+	if err != nil {
+		return Sequence{}, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Sequence{}, fmt.Errorf("nome é obrigatório")
+	}
+
+	seq := Sequence{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Account:   strings.ToLower(strings.TrimSpace(account)),
+		Steps:     steps,
+		Active:    true,
+		CreatedAt: time.Now(),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unlock, err := m.lockLocked(true)
+	if err != nil {
+		return Sequence{}, err
+	}
+	defer unlock()
+
+	m.state.Sequences = append(m.state.Sequences, &seq)
+	if err := m.saveLocked(); err != nil {
+		return Sequence{}, err
+	}
+	return seq, nil
+}
+
+// SetActive ativa ou pausa uma sequência (inscrições pausadas não recebem mensagens)
+func (m *Manager) SetActive(id string, active bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unlock, err := m.lockLocked(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	seq := m.findLocked(id)
+	if seq == nil {
+		return fmt.Errorf("sequência não encontrada: %s", id)
+	}
+	seq.Active = active
+	return m.saveLocked()
+}
+
+// Delete remove a sequência e suas inscrições
+func (m *Manager) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unlock, err := m.lockLocked(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for i, seq := range m.state.Sequences {
+		if seq.ID != id {
+			continue
+		}
+		m.state.Sequences = append(m.state.Sequences[:i], m.state.Sequences[i+1:]...)
+
+		kept := m.state.Enrollments[:0]
+		for _, en := range m.state.Enrollments {
+			if en.SequenceID != id {
+				kept = append(kept, en)
+			}
+		}
+		m.state.Enrollments = kept
+		return m.saveLocked()
+	}
+	return fmt.Errorf("sequência não encontrada: %s", id)
+}
+
+// RemoveEnrollments remove as inscrições para as quais match retorna true
+// (direito ao esquecimento e retenção). Retorna quantas foram removidas.
+func (m *Manager) RemoveEnrollments(match func(en Enrollment) bool) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unlock, err := m.lockLocked(true)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	kept := m.state.Enrollments[:0]
+	for _, en := range m.state.Enrollments {
+		if !match(*en) {
+			kept = append(kept, en)
+		}
+	}
+	removed := len(m.state.Enrollments) - len(kept)
+	m.state.Enrollments = kept
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, m.saveLocked()
+}
+
+// enroll inscreve o contato na primeira sequência ativa da conta, se ainda
+// não estiver inscrito em nenhuma. Retorna true se uma inscrição foi criada.
+func (m *Manager) enroll(en Enrollment) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unlock, err := m.lockLocked(true)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	key := strings.ToLower(en.LinkedInURL)
+	for _, existing := range m.state.Enrollments {
+		if strings.EqualFold(existing.Account, en.Account) && strings.ToLower(existing.LinkedInURL) == key {
+			return false, nil
+		}
+	}
+
+	for _, seq := range m.state.Sequences {
+		if !seq.Active || (seq.Account != "" && !strings.EqualFold(seq.Account, en.Account)) {
+			continue
+		}
+		en.SequenceID = seq.ID
+		m.state.Enrollments = append(m.state.Enrollments, &en)
+		return true, m.saveLocked()
+	}
+	return false, nil
+}
+
+// update aplica fn à inscrição (sequência + conta + perfil) e grava o estado
+func (m *Manager) update(target Enrollment, fn func(en *Enrollment)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	unlock, err := m.lockLocked(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for _, en := range m.state.Enrollments {
+		if en.SequenceID == target.SequenceID && strings.EqualFold(en.Account, target.Account) &&
+			strings.EqualFold(en.LinkedInURL, target.LinkedInURL) {
+			fn(en)
+			return m.saveLocked()
+		}
+	}
+	return fmt.Errorf("inscrição não encontrada: %s", target.LinkedInURL)
+}
+
+// get retorna cópia da sequência
+func (m *Manager) get(id string) (Sequence, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refreshLocked()
+
+	seq := m.findLocked(id)
+	if seq == nil {
+		return Sequence{}, false
+	}
+	cp := *seq
+	cp.Steps = append([]Step(nil), seq.Steps...)
+	return cp, true
+}
+
+func (m *Manager) findLocked(id string) *Sequence {
+	for _, seq := range m.state.Sequences {
+		if seq.ID == id {
+			return seq
+		}
+	}
+	return nil
+}
+
+// saveLocked grava o estado de forma atômica (arquivo temporário + rename);
+// deve ser chamado com a trava exclusiva de lockLocked
+func (m *Manager) saveLocked() error {
+	content, err := json.MarshalIndent(m.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar sequências: %v", err)
+	}
+
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return fmt.Errorf("erro ao gravar sequências: %v", err)
+	}
+	return os.Rename(tmp, m.path)
+}
@@ -0,0 +1,90 @@
+package sequences
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// chdirTemp roda o teste em um diretório vazio (data/sequences.json relativo)
+func chdirTemp(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func enrollment(slug string) Enrollment {
+	return Enrollment{Account: "vendas@empresa.com", LinkedInURL: "https://www.linkedin.com/in/" + slug, Name: slug, AcceptedAt: time.Now()}
+}
+
+func enrolledNames(m *Manager) map[string]bool {
+	names := map[string]bool{}
+	for _, en := range m.Enrollments() {
+		names[en.Name] = true
+	}
+	return names
+}
+
+// Servidor e CLI com o mesmo data/sequences.json
+func TestManagerSharedBetweenProcesses(t *testing.T) {
+	chdirTemp(t)
+
+	server, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	seq, err := server.Create("Boas-vindas", "", "0 | Olá {{.FirstName}}!\n3 | Tudo certo?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inSeq := func(slug string) Enrollment {
+		en := enrollment(slug)
+		en.SequenceID = seq.ID
+		return en
+	}
+	for _, slug := range []string{"ana", "bruno"} {
+		if ok, err := server.enroll(enrollment(slug)); err != nil || !ok {
+			t.Fatalf("enroll(%s) = %v, %v", slug, ok, err)
+		}
+	}
+
+	cli, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	removed, err := cli.RemoveEnrollments(func(en Enrollment) bool { return en.Name == "ana" })
+	if err != nil || removed != 1 {
+		t.Fatalf("RemoveEnrollments(ana) = %d, %v; esperado 1", removed, err)
+	}
+
+	// O servidor grava depois da remoção sem trazer ana de volta
+	if ok, err := server.enroll(enrollment("carla")); err != nil || !ok {
+		t.Fatalf("enroll(carla) = %v, %v", ok, err)
+	}
+	if err := server.update(inSeq("bruno"), func(en *Enrollment) { en.NextStep = 1 }); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.update(inSeq("ana"), func(en *Enrollment) { en.NextStep = 1 }); err == nil {
+		t.Error("update(ana) após remoção = nil, esperado inscrição não encontrada")
+	}
+
+	reloaded, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, m := range map[string]*Manager{"servidor": server, "cli": cli, "novo": reloaded} {
+		got := enrolledNames(m)
+		if len(got) != 2 || !got["bruno"] || !got["carla"] {
+			t.Errorf("inscrições vistas por %s = %v, esperado bruno e carla", name, got)
+		}
+	}
+	if due := cli.due("vendas@empresa.com", time.Now()); len(due) != 1 || due[0].Name != "carla" {
+		t.Errorf("due = %+v, esperado só carla (bruno já passou da primeira etapa)", due)
+	}
+}
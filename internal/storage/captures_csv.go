@@ -0,0 +1,298 @@
+package storage
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+// captureHeader colunas do CSV de contatos capturados. Novas colunas são
+// adicionadas ao final; arquivos antigos são migrados na inicialização.
+var captureHeader = []string{
+	"timestamp",
+	"user_email",
+	"profile_name",
+	"profile_title",
+	"company",
+	"location",
+	"linkedin_url",
+	"query",
+	"source",
+	"degree",
+	"mutual",
+	"score",
+	"score_rules",
+	"city",
+	"region",
+	"country",
+	"first_name",
+	"last_name",
+	"raw_name",
+	"run_id",
+}
+
+// CaptureLog registro (append-only) de cada contato capturado, base do funil
+type CaptureLog struct {
+	mu       sync.Mutex
+	filePath string
+}
+
+// NewCaptureLog cria o registro em data/captures.csv
+func NewCaptureLog() *CaptureLog {
+	if err := os.MkdirAll("data", 0755); err != nil {
+		panic(fmt.Sprintf("Erro ao criar diretório data: %v", err))
+	}
+	return NewCaptureLogAt(filepath.Join("data", "captures.csv"))
+}
+
+// NewCaptureLogAt abre o registro de capturas em outro arquivo (ex.: migração)
+func NewCaptureLogAt(filePath string) *CaptureLog {
+	l := &CaptureLog{filePath: filePath}
+
+	// Reparar linhas truncadas por uma escrita interrompida
+	if report, err := l.CheckIntegrity(); err != nil {
+		log.Printf("Aviso: erro ao verificar %s: %v", filePath, err)
+	} else if report.Invalid > 0 || report.Quarantined > 0 {
+		log.Printf("⚠️ Verificação de integridade: %s", report)
+	}
+
+	// Migrar arquivos gravados com um cabeçalho anterior
+	if err := l.migrateSchema(); err != nil {
+		log.Printf("Aviso: erro ao migrar %s: %v", l.filePath, err)
+	}
+	return l
+}
+
+// captureRow converte uma captura na linha CSV correspondente a captureHeader
+func captureRow(record crawler.CaptureRecord) []string {
+	return []string{
+		record.Timestamp.Format(time.RFC3339),
+		record.UserEmail,
+		record.Name,
+		record.Title,
+		record.Company,
+		record.Location,
+		record.LinkedIn,
+		record.Query,
+		record.Source,
+		strconv.Itoa(record.Degree),
+		strconv.Itoa(record.Mutual),
+		formatScore(record.Score),
+		strings.Join(record.ScoreRules, ";"),
+		record.City,
+		record.Region,
+		record.Country,
+		record.FirstName,
+		record.LastName,
+		record.RawName,
+		record.RunID,
+	}
+}
+
+// CheckIntegrity confere o CSV e move para data/quarantine/ as linhas
+// truncadas no final do arquivo (escrita interrompida)
+func (l *CaptureLog) CheckIntegrity() (IntegrityReport, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := lockFile(l.filePath, true)
+	if err != nil {
+		return IntegrityReport{File: l.filePath}, err
+	}
+	defer unlock()
+
+	return checkCSV(l.filePath, func(index map[string]int, row []string) bool {
+		_, ok := parseCaptureRow(index, row)
+		return ok
+	})
+}
+
+// Append adiciona uma captura ao CSV (com fsync)
+func (l *CaptureLog) Append(record crawler.CaptureRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := lockFile(l.filePath, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return appendCSV(l.filePath, captureHeader, captureRow(record))
+}
+
+// List lê todas as capturas (ordem de registro)
+func (l *CaptureLog) List() ([]crawler.CaptureRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := lockFile(l.filePath, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	captures, _, err := l.readAllLocked()
+	return captures, err
+}
+
+// readAllLocked lê as capturas e o cabeçalho do arquivo (l.mu deve estar travado)
+func (l *CaptureLog) readAllLocked() ([]crawler.CaptureRecord, []string, error) {
+	file, err := os.Open(l.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []crawler.CaptureRecord{}, nil, nil
+		}
+		return nil, nil, fmt.Errorf("erro ao abrir arquivo CSV: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := readCSVRows(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rows) == 0 {
+		return []crawler.CaptureRecord{}, nil, nil
+	}
+
+	index := make(map[string]int, len(rows[0]))
+	for i, col := range rows[0] {
+		index[col] = i
+	}
+
+	captures := make([]crawler.CaptureRecord, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		if capture, ok := parseCaptureRow(index, row); ok {
+			captures = append(captures, capture)
+		}
+	}
+	return captures, rows[0], nil
+}
+
+// parseCaptureRow converte uma linha CSV usando o índice das colunas do cabeçalho do arquivo
+func parseCaptureRow(index map[string]int, row []string) (crawler.CaptureRecord, bool) {
+	get := func(col string) string {
+		if i, ok := index[col]; ok && i < len(row) {
+			return row[i]
+		}
+		return ""
+	}
+	timestamp, err := time.Parse(time.RFC3339, get("timestamp"))
+	if err != nil {
+		return crawler.CaptureRecord{}, false
+	}
+	degree, _ := strconv.Atoi(get("degree"))
+	mutual, _ := strconv.Atoi(get("mutual"))
+	score, _ := strconv.ParseFloat(get("score"), 64)
+	return crawler.CaptureRecord{
+		Timestamp: timestamp,
+		UserEmail: get("user_email"),
+		RunID:     get("run_id"),
+		Contact: crawler.Contact{
+			Name:       get("profile_name"),
+			FirstName:  get("first_name"),
+			LastName:   get("last_name"),
+			RawName:    get("raw_name"),
+			Title:      get("profile_title"),
+			Company:    get("company"),
+			Location:   get("location"),
+			City:       get("city"),
+			Region:     get("region"),
+			Country:    get("country"),
+			LinkedIn:   get("linkedin_url"),
+			Query:      get("query"),
+			Source:     get("source"),
+			Degree:     degree,
+			Mutual:     mutual,
+			Score:      score,
+			ScoreRules: splitRules(get("score_rules")),
+		},
+	}, true
+}
+
+// migrateSchema regrava o CSV com o cabeçalho atual quando o arquivo foi
+// criado por uma versão anterior (colunas novas ficam vazias)
+func (l *CaptureLog) migrateSchema() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := lockFile(l.filePath, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	captures, header, err := l.readAllLocked()
+	if err != nil || header == nil || strings.Join(header, ",") == strings.Join(captureHeader, ",") {
+		return err
+	}
+
+	log.Printf("Migrando %s para o cabeçalho atual (%d capturas)", l.filePath, len(captures))
+	return l.rewriteLocked(captures)
+}
+
+// Delete remove as capturas para as quais match retorna true. Retorna
+// quantas foram removidas.
+func (l *CaptureLog) Delete(match func(record crawler.CaptureRecord) bool) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := lockFile(l.filePath, true)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	captures, _, err := l.readAllLocked()
+	if err != nil {
+		return 0, err
+	}
+
+	kept := captures[:0]
+	for _, capture := range captures {
+		if !match(capture) {
+			kept = append(kept, capture)
+		}
+	}
+	removed := len(captures) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, l.rewriteLocked(kept)
+}
+
+// rewriteLocked grava todas as capturas com o cabeçalho atual (arquivo
+// temporário + rename). Deve ser chamado com as travas exclusivas.
+func (l *CaptureLog) rewriteLocked(captures []crawler.CaptureRecord) error {
+	tmp := l.filePath + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo temporário: %v", err)
+	}
+	writer := csv.NewWriter(file)
+	writer.Write(captureHeader)
+	for _, capture := range captures {
+		writer.Write(captureRow(capture))
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		file.Close()
+		return fmt.Errorf("erro ao gravar CSV: %v", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("erro ao sincronizar CSV: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, l.filePath)
+}
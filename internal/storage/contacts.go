@@ -0,0 +1,385 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+// Filtro de contatos sem convite (ContactQuery.Status)
+const ContactStatusNone = "none"
+
+// ContactRecord contato capturado, um por perfil (URL normalizada), com o
+// histórico de quando e por onde apareceu
+type ContactRecord struct {
+	Key       string          `json:"key"`     // URL do perfil normalizada, em minúsculas
+	Contact   crawler.Contact `json:"contact"` // dados da captura mais recente
+	Accounts  []string        `json:"accounts,omitempty"`
+	Queries   []string        `json:"queries,omitempty"` // queries/empresas que trouxeram o perfil
+	Runs      []string        `json:"runs,omitempty"`    // execuções em que apareceu
+	Captures  int             `json:"captures"`          // vezes capturado
+	FirstSeen time.Time       `json:"first_seen"`
+	LastSeen  time.Time       `json:"last_seen"`
+
+	// Convite mais recente para o perfil (preenchido na consulta a partir dos convites)
+	InviteStatus string    `json:"invite_status,omitempty"`
+	InvitedAt    time.Time `json:"invited_at,omitempty"`
+}
+
+// merge soma uma captura ao contato
+func (r *ContactRecord) merge(capture crawler.CaptureRecord) {
+	if r.Captures == 0 || capture.Timestamp.Before(r.FirstSeen) {
+		r.FirstSeen = capture.Timestamp
+	}
+	if !capture.Timestamp.Before(r.LastSeen) {
+		r.LastSeen = capture.Timestamp
+		r.Contact = capture.Contact
+	}
+	r.Captures++
+	r.Accounts = appendUnique(r.Accounts, strings.ToLower(capture.UserEmail))
+	r.Queries = appendUnique(r.Queries, capture.Query)
+	r.Runs = appendUnique(r.Runs, capture.RunID)
+}
+
+// appendUnique acrescenta value se ainda não estiver na lista (vazio é ignorado)
+func appendUnique(list []string, value string) []string {
+	if value == "" {
+		return list
+	}
+	for _, v := range list {
+		if v == value {
+			return list
+		}
+	}
+	return append(list, value)
+}
+
+// ContactQuery filtros, ordenação e paginação da tabela de contatos
+type ContactQuery struct {
+	Search string // nome, cargo, empresa, localização ou URL
+	Status string // situação do convite (pending, accepted...), ContactStatusNone ou vazio = todos
+	Sort   string // last_seen (padrão), first_seen, name, company, score ou captures
+	Asc    bool
+	Page   int
+	Size   int
+}
+
+// ContactStore contatos capturados, consolidados por perfil
+type ContactStore interface {
+	UpsertContact(capture crawler.CaptureRecord) error
+	ListContacts(q ContactQuery) ([]ContactRecord, int, error)
+	DeleteContacts(match func(contact ContactRecord) bool) (int, error)
+}
+
+// queryContacts preenche a situação do convite e aplica filtros, ordenação e
+// paginação (comum aos backends)
+func queryContacts(contacts []ContactRecord, invites map[string]crawler.InviteRecord, q ContactQuery) ([]ContactRecord, int) {
+	search := strings.ToLower(strings.TrimSpace(q.Search))
+
+	filtered := make([]ContactRecord, 0, len(contacts))
+	for _, contact := range contacts {
+		if invite, ok := invites[contact.Key]; ok {
+			contact.InviteStatus, contact.InvitedAt = invite.Status, invite.Timestamp
+		}
+
+		switch {
+		case q.Status == ContactStatusNone && contact.InviteStatus != "":
+			continue
+		case q.Status != "" && q.Status != ContactStatusNone && contact.InviteStatus != q.Status:
+			continue
+		}
+		if search != "" {
+			c := contact.Contact
+			text := strings.ToLower(strings.Join([]string{c.Name, c.Title, c.Company, c.Location, c.Headline, contact.Key}, " "))
+			if !strings.Contains(text, search) {
+				continue
+			}
+		}
+		filtered = append(filtered, contact)
+	}
+
+	less := func(a, b ContactRecord) bool { return a.LastSeen.Before(b.LastSeen) }
+	switch q.Sort {
+	case "first_seen":
+		less = func(a, b ContactRecord) bool { return a.FirstSeen.Before(b.FirstSeen) }
+	case "name":
+		less = func(a, b ContactRecord) bool {
+			return strings.ToLower(a.Contact.Name) < strings.ToLower(b.Contact.Name)
+		}
+	case "company":
+		less = func(a, b ContactRecord) bool {
+			return strings.ToLower(a.Contact.Company) < strings.ToLower(b.Contact.Company)
+		}
+	case "score":
+		less = func(a, b ContactRecord) bool { return a.Contact.Score < b.Contact.Score }
+	case "captures":
+		less = func(a, b ContactRecord) bool { return a.Captures < b.Captures }
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if q.Asc {
+			return less(filtered[i], filtered[j])
+		}
+		return less(filtered[j], filtered[i])
+	})
+
+	total := len(filtered)
+	if q.Size <= 0 {
+		return filtered, total
+	}
+	start := q.Page * q.Size
+	if start >= total {
+		return []ContactRecord{}, total
+	}
+	end := start + q.Size
+	if end > total {
+		end = total
+	}
+	return filtered[start:end], total
+}
+
+// buildContacts consolida as capturas por perfil
+func buildContacts(captures []crawler.CaptureRecord) map[string]*ContactRecord {
+	contacts := map[string]*ContactRecord{}
+	for _, capture := range captures {
+		key := profileKey(capture.LinkedIn)
+		if key == "" {
+			continue
+		}
+		contact, ok := contacts[key]
+		if !ok {
+			contact = &ContactRecord{Key: key}
+			contacts[key] = contact
+		}
+		contact.merge(capture)
+	}
+	return contacts
+}
+
+// ContactLog contatos do backend CSV: mantidos em memória e gravados em
+// data/contacts.jsonl (um JSON por alteração; a última linha de cada perfil
+// prevalece e o arquivo é compactado na inicialização). Servidor e CLI
+// compartilham o arquivo: cada operação trava contacts.jsonl.lock e relê o
+// arquivo se outro processo o alterou.
+type ContactLog struct {
+	mu       sync.Mutex
+	filePath string
+	contacts map[string]*ContactRecord
+	seen     os.FileInfo // arquivo após a última leitura ou escrita deste processo
+}
+
+// NewContactLog carrega data/contacts.jsonl; na primeira vez, consolida o
+// histórico de capturas
+func NewContactLog(captures *CaptureLog) (*ContactLog, error) {
+	l := &ContactLog{filePath: filepath.Join("data", "contacts.jsonl"), contacts: map[string]*ContactRecord{}}
+
+	unlock, err := lockFile(l.filePath, true)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	lines, err := l.load()
+	if os.IsNotExist(err) {
+		history, err := captures.List()
+		if err != nil {
+			return nil, err
+		}
+		l.contacts = buildContacts(history)
+		if len(l.contacts) > 0 {
+			log.Printf("Consolidando %d contatos a partir de %d capturas", len(l.contacts), len(history))
+		}
+		return l, l.compact()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if lines > 2*len(l.contacts)+100 {
+		return l, l.compact()
+	}
+	return l, nil
+}
+
+// load lê o arquivo (última linha de cada perfil prevalece) e retorna o
+// número de linhas. Deve ser chamado com a trava do arquivo.
+func (l *ContactLog) load() (int, error) {
+	file, err := os.Open(l.filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	l.contacts = map[string]*ContactRecord{}
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64<<10), 4<<20)
+	for scanner.Scan() {
+		var contact ContactRecord
+		if json.Unmarshal(scanner.Bytes(), &contact) != nil || contact.Key == "" {
+			continue
+		}
+		lines++
+		l.contacts[contact.Key] = &contact
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("erro ao ler %s: %v", l.filePath, err)
+	}
+	l.markSeen()
+	return lines, nil
+}
+
+// markSeen registra o estado do arquivo após uma leitura ou escrita deste processo
+func (l *ContactLog) markSeen() {
+	if info, err := os.Stat(l.filePath); err == nil {
+		l.seen = info
+	}
+}
+
+// refresh relê o arquivo se ele mudou desde a última leitura ou escrita deste
+// processo (ex.: remoção feita pela CLI). Deve ser chamado com l.mu e a trava
+// do arquivo.
+func (l *ContactLog) refresh() error {
+	info, err := os.Stat(l.filePath)
+	if os.IsNotExist(err) {
+		l.contacts, l.seen = map[string]*ContactRecord{}, nil
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao ler %s: %v", l.filePath, err)
+	}
+	if l.seen != nil && os.SameFile(info, l.seen) && info.Size() == l.seen.Size() && info.ModTime().Equal(l.seen.ModTime()) {
+		return nil
+	}
+	_, err = l.load()
+	return err
+}
+
+// compact regrava o arquivo com uma linha por contato (temporário + rename).
+// Deve ser chamado com a trava exclusiva do arquivo.
+func (l *ContactLog) compact() error {
+	var buf strings.Builder
+	for _, contact := range l.sorted() {
+		line, err := json.Marshal(contact)
+		if err != nil {
+			return fmt.Errorf("erro ao serializar contato: %v", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	tmp := l.filePath + ".tmp"
+	if err := os.WriteFile(tmp, []byte(buf.String()), 0644); err != nil {
+		return fmt.Errorf("erro ao gravar contatos: %v", err)
+	}
+	if err := os.Rename(tmp, l.filePath); err != nil {
+		return err
+	}
+	l.markSeen()
+	return nil
+}
+
+// sorted contatos em ordem de primeira captura
+func (l *ContactLog) sorted() []ContactRecord {
+	out := make([]ContactRecord, 0, len(l.contacts))
+	for _, contact := range l.contacts {
+		out = append(out, *contact)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].FirstSeen.Before(out[j].FirstSeen) })
+	return out
+}
+
+// Upsert soma a captura ao contato do perfil e grava a nova versão
+func (l *ContactLog) Upsert(capture crawler.CaptureRecord) error {
+	key := profileKey(capture.LinkedIn)
+	if key == "" {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := lockFile(l.filePath, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := l.refresh(); err != nil {
+		return err
+	}
+
+	contact, ok := l.contacts[key]
+	if !ok {
+		contact = &ContactRecord{Key: key}
+		l.contacts[key] = contact
+	}
+	contact.merge(capture)
+
+	line, err := json.Marshal(contact)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar contato: %v", err)
+	}
+	if err := appendFile(l.filePath, append(line, '\n')); err != nil {
+		return fmt.Errorf("erro ao gravar contato: %v", err)
+	}
+	l.markSeen()
+	return nil
+}
+
+// Delete remove os contatos para os quais match retorna true e regrava o
+// arquivo (as versões antigas não ficam no histórico)
+func (l *ContactLog) Delete(match func(contact ContactRecord) bool) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := lockFile(l.filePath, true)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	if err := l.refresh(); err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for key, contact := range l.contacts {
+		if match(*contact) {
+			delete(l.contacts, key)
+			removed++
+		}
+	}
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, l.compact()
+}
+
+// List cópia de todos os contatos
+func (l *ContactLog) List() ([]ContactRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := lockFile(l.filePath, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if err := l.refresh(); err != nil {
+		return nil, err
+	}
+
+	out := l.sorted()
+	for i := range out {
+		out[i].Accounts = append([]string(nil), out[i].Accounts...)
+		out[i].Queries = append([]string(nil), out[i].Queries...)
+		out[i].Runs = append([]string(nil), out[i].Runs...)
+	}
+	return out, nil
+}
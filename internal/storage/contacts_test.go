@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+func contactCapture(slug string, at time.Time) crawler.CaptureRecord {
+	return crawler.CaptureRecord{Timestamp: at, UserEmail: "vendas@empresa.com", Contact: crawler.Contact{
+		Name: slug, LinkedIn: "https://www.linkedin.com/in/" + slug}}
+}
+
+func contactNames(t *testing.T, s Store) map[string]bool {
+	t.Helper()
+	contacts, _, err := s.ListContacts(ContactQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := map[string]bool{}
+	for _, c := range contacts {
+		names[c.Contact.Name] = true
+	}
+	return names
+}
+
+// Dois processos (servidor e CLI) com o mesmo data/contacts.jsonl
+func TestContactLogSharedBetweenStores(t *testing.T) {
+	chdirTemp(t)
+	now := time.Now()
+
+	a, err := NewCSVStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, slug := range []string{"alice", "bob", "carol"} {
+		if err := a.UpsertContact(contactCapture(slug, now)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b, err := NewCSVStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := b.DeleteContacts(func(c ContactRecord) bool { return c.Contact.Name == "alice" }); err != nil || n != 1 {
+		t.Fatalf("B.DeleteContacts(alice) = %d, %v; esperado 1", n, err)
+	}
+	if n, err := a.DeleteContacts(func(c ContactRecord) bool { return c.Contact.Name == "bob" }); err != nil || n != 1 {
+		t.Fatalf("A.DeleteContacts(bob) = %d, %v; esperado 1", n, err)
+	}
+	// A grava depois da remoção feita por B sem trazer alice de volta
+	if err := a.UpsertContact(contactCapture("dave", now.Add(time.Minute))); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewCSVStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, s := range map[string]Store{"A": a, "B": b, "novo": c} {
+		got := contactNames(t, s)
+		if len(got) != 2 || !got["carol"] || !got["dave"] {
+			t.Errorf("contatos vistos por %s = %v, esperado carol e dave", name, got)
+		}
+	}
+
+	// Nova captura de um perfil removido recomeça do zero
+	if err := b.UpsertContact(contactCapture("alice", now.Add(2*time.Minute))); err != nil {
+		t.Fatal(err)
+	}
+	contacts, _, _ := a.ListContacts(ContactQuery{Search: "alice"})
+	if len(contacts) != 1 || contacts[0].Captures != 1 {
+		t.Errorf("alice após nova captura = %+v, esperado 1 captura", contacts)
+	}
+}
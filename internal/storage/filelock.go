@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lockFile trava <path>.lock entre processos (servidor web e CLI) e retorna a
+// função que libera a trava. O arquivo de trava nunca é renomeado, então a
+// trava vale também durante regravações (arquivo temporário + rename).
+func lockFile(path string, exclusive bool) (func(), error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir trava de %s: %v", path, err)
+	}
+	if err := flock(f, exclusive); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("erro ao travar %s: %v", path, err)
+	}
+	return func() {
+		funlock(f)
+		f.Close()
+	}, nil
+}
+
+// LockFile trava <path>.lock entre processos para arquivos mantidos por
+// outros pacotes (ex.: data/sequences.json); ver lockFile
+func LockFile(path string, exclusive bool) (func(), error) {
+	return lockFile(path, exclusive)
+}
+
+// appendCSV acrescenta linhas ao CSV (cabeçalho se o arquivo estiver vazio) e
+// sincroniza com o disco. Se o arquivo não termina em quebra de linha (escrita
+// interrompida), o final truncado vai antes para a quarentena, para não
+// emendar com a nova linha. Deve ser chamado com a trava exclusiva.
+func appendCSV(path string, header []string, rows ...[]string) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir arquivo CSV: %v", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("erro ao obter info do arquivo: %v", err)
+	}
+
+	if info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := file.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			report, err := checkCSV(path, func(map[string]int, []string) bool { return true })
+			if err != nil {
+				return err
+			}
+			log.Printf("⚠️ Escrita interrompida reparada: %s", report)
+			if info, err = file.Stat(); err != nil {
+				return fmt.Errorf("erro ao obter info do arquivo: %v", err)
+			}
+		}
+	}
+
+	writer := csv.NewWriter(file)
+	if info.Size() == 0 {
+		writer.Write(header)
+	}
+	for _, row := range rows {
+		writer.Write(row)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("erro ao escrever registro: %v", err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("erro ao sincronizar arquivo CSV: %v", err)
+	}
+	return file.Close()
+}
+
+// readCSVRows lê o CSV linha a linha; linhas que não são CSV válido (ex.:
+// aspas soltas no meio do arquivo, mantidas por checkCSV) são puladas em vez
+// de invalidar o arquivo inteiro. A primeira linha devolvida é o cabeçalho.
+func readCSVRows(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // linhas antigas podem ter menos colunas
+
+	var rows [][]string
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if _, ok := err.(*csv.ParseError); ok {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler CSV: %v", err)
+		}
+		rows = append(rows, row)
+	}
+}
+
+// IntegrityReport resultado da verificação de um CSV na inicialização
+type IntegrityReport struct {
+	File           string
+	Rows           int    // linhas válidas
+	Invalid        int    // linhas inválidas no meio do arquivo (mantidas; ignoradas na leitura)
+	Quarantined    int    // linhas truncadas removidas do final do arquivo
+	QuarantineFile string // onde as linhas removidas foram guardadas
+}
+
+// String resumo para o log
+func (r IntegrityReport) String() string {
+	msg := fmt.Sprintf("%s: %d linhas válidas", r.File, r.Rows)
+	if r.Invalid > 0 {
+		msg += fmt.Sprintf(", %d inválidas ignoradas", r.Invalid)
+	}
+	if r.Quarantined > 0 {
+		msg += fmt.Sprintf(", %d truncadas no final movidas para %s", r.Quarantined, r.QuarantineFile)
+	}
+	return msg
+}
+
+// checkCSV confere o CSV e repara o final do arquivo: linhas incompletas após
+// a última linha válida (escrita interrompida) são guardadas em
+// data/quarantine/ e o arquivo é cortado na última linha completa. valid
+// confere o conteúdo de uma linha. Deve ser chamado com a trava exclusiva.
+func checkCSV(path string, valid func(index map[string]int, row []string) bool) (IntegrityReport, error) {
+	report := IntegrityReport{File: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return report, nil
+	}
+	if err != nil {
+		return report, fmt.Errorf("erro ao ler %s: %v", path, err)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1 // linhas antigas podem ter menos colunas
+
+	var header []string
+	var index map[string]int
+	var good int64 // fim da última linha válida
+	pending := 0   // linhas inválidas após a última válida
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if _, ok := err.(*csv.ParseError); err != nil && !ok {
+			return report, fmt.Errorf("erro ao ler %s: %v", path, err)
+		}
+		end := reader.InputOffset()
+		complete := err == nil && data[end-1] == '\n'
+
+		if header == nil {
+			if !complete {
+				pending++
+				break // cabeçalho truncado: nada a aproveitar
+			}
+			header, index, good = row, headerIndex(row), end
+			continue
+		}
+
+		// Linhas com mais colunas que o cabeçalho são uma linha truncada
+		// seguida de outra gravada na mesma linha do arquivo
+		if complete && len(row) <= len(header) && valid(index, row) {
+			report.Rows++
+			report.Invalid += pending
+			pending, good = 0, end
+		} else {
+			pending++
+		}
+	}
+
+	if good == int64(len(data)) {
+		report.Invalid += pending
+		return report, nil
+	}
+
+	// Guardar o final truncado e cortar o arquivo
+	dir := filepath.Join(filepath.Dir(path), "quarantine")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return report, fmt.Errorf("erro ao criar diretório de quarentena: %v", err)
+	}
+	base := filepath.Base(path)
+	report.QuarantineFile = filepath.Join(dir, fmt.Sprintf("%s-%s%s",
+		base[:len(base)-len(filepath.Ext(base))], time.Now().Format("20060102T150405"), filepath.Ext(base)))
+	tail := data[good:]
+	if tail[len(tail)-1] != '\n' {
+		tail = append(tail, '\n')
+	}
+	if err := appendFile(report.QuarantineFile, tail); err != nil {
+		return report, fmt.Errorf("erro ao gravar quarentena: %v", err)
+	}
+	if err := os.Truncate(path, good); err != nil {
+		return report, fmt.Errorf("erro ao reparar %s: %v", path, err)
+	}
+	report.Quarantined = pending
+	if report.Quarantined == 0 {
+		report.Quarantined = 1
+	}
+	return report, nil
+}
+
+// appendFile acrescenta o conteúdo ao arquivo (criando se não existir)
+func appendFile(path string, content []byte) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
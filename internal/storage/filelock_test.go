@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+// inviteLines convites de teste já no formato do CSV (com cabeçalho)
+func inviteLines(t *testing.T, names ...string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(inviteHeader)
+	for i, name := range names {
+		w.Write(InviteRow(crawler.InviteRecord{
+			Timestamp:   time.Date(2026, 10, 14, 9, i, 0, 0, time.UTC),
+			UserEmail:   "vendas@empresa.com",
+			ProfileName: name,
+			LinkedInURL: "https://www.linkedin.com/in/" + strings.ToLower(name),
+			Status:      crawler.InviteStatusPending,
+		}))
+	}
+	w.Flush()
+	return buf.String()
+}
+
+func validInvite(index map[string]int, row []string) bool {
+	_, ok := parseInviteRow(index, row)
+	return ok
+}
+
+func TestCheckCSVQuarantinesTruncatedTail(t *testing.T) {
+	chdirTemp(t)
+	path := filepath.Join("data", "invites.csv")
+	complete := inviteLines(t, "Ana", "Bruno")
+	tail := `2026-10-14T09:05:00Z,vendas@empresa.com,"Carla`
+	os.MkdirAll("data", 0755)
+	if err := os.WriteFile(path, []byte(complete+tail), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := checkCSV(path, validInvite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Rows != 2 || report.Invalid != 0 || report.Quarantined != 1 || report.QuarantineFile == "" {
+		t.Errorf("checkCSV = %+v, esperado 2 válidas e 1 truncada em quarentena", report)
+	}
+	if content, _ := os.ReadFile(path); string(content) != complete {
+		t.Errorf("arquivo após reparo =\n%s\nesperado cortado na última linha completa", content)
+	}
+	lines, err := QuarantineLines(func(string) bool { return true })
+	if err != nil || len(lines) != 1 || lines[0] != tail {
+		t.Errorf("QuarantineLines = %q, %v; esperado [%q]", lines, err, tail)
+	}
+
+	// Um novo convite começa em linha nova e é lido normalmente
+	s := NewInviteStorageAt(path)
+	if err := s.AppendInvite(crawler.InviteRecord{Timestamp: time.Now(), UserEmail: "vendas@empresa.com", ProfileName: "Davi"}); err != nil {
+		t.Fatal(err)
+	}
+	if invites, total, err := s.ListInvites(0, 10); err != nil || total != 3 || invites[2].ProfileName != "Davi" {
+		t.Errorf("ListInvites = %v, %d, %v; esperado Ana, Bruno e Davi", invites, total, err)
+	}
+}
+
+func TestAppendRepairsTruncatedTail(t *testing.T) {
+	chdirTemp(t)
+	path := filepath.Join("data", "invites.csv")
+	os.MkdirAll("data", 0755)
+	s := NewInviteStorageAt(path)
+	// Escrita interrompida depois da abertura do storage
+	if err := os.WriteFile(path, []byte(inviteLines(t, "Ana")+"2026-10-14T09:05:00Z,vend"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.AppendInvite(crawler.InviteRecord{Timestamp: time.Now(), UserEmail: "vendas@empresa.com", ProfileName: "Bruno"}); err != nil {
+		t.Fatal(err)
+	}
+	invites, total, err := s.ListInvites(0, 10)
+	if err != nil || total != 2 || invites[1].ProfileName != "Bruno" {
+		t.Errorf("ListInvites = %v, %d, %v; esperado Ana e Bruno", invites, total, err)
+	}
+	if lines, _ := QuarantineLines(func(string) bool { return true }); len(lines) != 1 {
+		t.Errorf("QuarantineLines = %q, esperado a linha truncada", lines)
+	}
+}
+
+func TestMalformedMiddleRowIsSkipped(t *testing.T) {
+	chdirTemp(t)
+	path := filepath.Join("data", "invites.csv")
+	lines := strings.SplitAfter(inviteLines(t, "Ana", "Bruno"), "\n")
+	// Aspas soltas no meio de um campo: CSV inválido, mas a linha termina
+	bad := "2026-10-14T09:03:00Z,vendas@empresa.com,Carla \"Cacá\" Dias,,,,https://www.linkedin.com/in/carla\n"
+	content := lines[0] + lines[1] + bad + lines[2]
+	os.MkdirAll("data", 0755)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := checkCSV(path, validInvite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Rows != 2 || report.Invalid != 1 || report.Quarantined != 0 {
+		t.Errorf("checkCSV = %+v, esperado 2 válidas, 1 inválida mantida e nada em quarentena", report)
+	}
+	if kept, _ := os.ReadFile(path); string(kept) != content {
+		t.Error("checkCSV alterou o arquivo com linha inválida no meio")
+	}
+
+	s := NewInviteStorageAt(path)
+	invites, total, err := s.ListInvites(0, 10)
+	if err != nil || total != 2 || invites[0].ProfileName != "Ana" || invites[1].ProfileName != "Bruno" {
+		t.Errorf("ListInvites = %v, %d, %v; esperado Ana e Bruno", invites, total, err)
+	}
+	if n, err := s.GetTotalCount(); err != nil || n != 2 {
+		t.Errorf("GetTotalCount = %d, %v; esperado 2", n, err)
+	}
+	if n, err := s.CountInvites("vendas@empresa.com", time.Time{}, time.Now()); err != nil || n != 2 {
+		t.Errorf("CountInvites = %d, %v; esperado 2", n, err)
+	}
+
+	// O mesmo vale para as capturas
+	capturePath := filepath.Join("data", "captures.csv")
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(captureHeader)
+	w.Write(captureRow(crawler.CaptureRecord{Timestamp: time.Now(), Contact: crawler.Contact{Name: "Ana"}}))
+	w.Flush()
+	buf.WriteString("2026-10-14T09:03:00Z,x\"y\n")
+	w.Write(captureRow(crawler.CaptureRecord{Timestamp: time.Now(), Contact: crawler.Contact{Name: "Bruno"}}))
+	w.Flush()
+	if err := os.WriteFile(capturePath, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	captures, err := NewCaptureLogAt(capturePath).List()
+	if err != nil || len(captures) != 2 || captures[1].Name != "Bruno" {
+		t.Errorf("CaptureLog.List = %v, %v; esperado Ana e Bruno", captures, err)
+	}
+}
+
+func TestScrubAndPurgeQuarantine(t *testing.T) {
+	chdirTemp(t)
+	os.MkdirAll(QuarantineDir, 0755)
+	old := filepath.Join(QuarantineDir, "invites-20260101T000000.csv")
+	recent := filepath.Join(QuarantineDir, "captures-20261014T090000.csv")
+	os.WriteFile(old, []byte("ana,linkedin.com/in/ana\nbruno,linkedin.com/in/bruno\n"), 0644)
+	os.WriteFile(recent, []byte("ana,linkedin.com/in/ana\n"), 0644)
+
+	isAna := func(line string) bool { return strings.Contains(line, "/in/ana") }
+	if lines, err := QuarantineLines(isAna); err != nil || len(lines) != 2 {
+		t.Errorf("QuarantineLines(ana) = %q, %v; esperado 2 linhas", lines, err)
+	}
+
+	removed, err := ScrubQuarantine(isAna)
+	if err != nil || removed != 2 {
+		t.Errorf("ScrubQuarantine = %d, %v; esperado 2", removed, err)
+	}
+	if _, err := os.Stat(recent); !os.IsNotExist(err) {
+		t.Error("arquivo de quarentena vazio não foi apagado")
+	}
+	if content, _ := os.ReadFile(old); string(content) != "bruno,linkedin.com/in/bruno\n" {
+		t.Errorf("quarentena após ScrubQuarantine = %q, esperado só a linha do bruno", content)
+	}
+
+	past := time.Now().Add(-48 * time.Hour)
+	os.Chtimes(old, past, past)
+	if n, err := PurgeQuarantine(time.Now().Add(-72 * time.Hour)); err != nil || n != 0 {
+		t.Errorf("PurgeQuarantine(72h) = %d, %v; esperado 0", n, err)
+	}
+	if n, err := PurgeQuarantine(time.Now().Add(-24 * time.Hour)); err != nil || n != 1 {
+		t.Errorf("PurgeQuarantine(24h) = %d, %v; esperado 1", n, err)
+	}
+}
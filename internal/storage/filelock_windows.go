@@ -0,0 +1,16 @@
+//go:build windows
+
+package storage
+
+import "os"
+
+// flock sem trava entre processos no Windows (a serialização dentro do
+// processo continua valendo)
+func flock(f *os.File, exclusive bool) error {
+	return nil
+}
+
+// funlock nada a liberar
+func funlock(f *os.File) error {
+	return nil
+}
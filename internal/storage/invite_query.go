@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+// InviteQuery filtros, ordenação e paginação da tabela de convites
+type InviteQuery struct {
+	Account  string    // conta que enviou (exata, sem diferenciar maiúsculas)
+	Query    string    // trecho da query, URL de busca ou slug da empresa
+	Company  string    // trecho da empresa
+	Location string    // trecho da localização
+	Status   string    // pending, accepted, withdrawn, expired ou vazio = todos
+	Search   string    // trecho do nome ou cargo
+	From     time.Time // envio a partir de (zero = sem limite)
+	To       time.Time // envio antes de (exclusivo; zero = sem limite)
+	Sort     string    // coluna do CSV (timestamp, profile_name, company, score...); vazio = ordem de envio
+	Asc      bool
+	Page     int
+	Size     int // 0 = todos
+}
+
+// InviteSortColumns colunas aceitas em InviteQuery.Sort
+var InviteSortColumns = []string{
+	"timestamp", "user_email", "profile_name", "profile_title", "company",
+	"location", "query", "source", "score", "status",
+}
+
+// match indica se o convite atende aos filtros
+func (q InviteQuery) match(invite crawler.InviteRecord) bool {
+	if q.Account != "" && !strings.EqualFold(invite.UserEmail, q.Account) {
+		return false
+	}
+	if q.Status != "" && invite.Status != q.Status {
+		return false
+	}
+	if !q.From.IsZero() && invite.Timestamp.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !invite.Timestamp.Before(q.To) {
+		return false
+	}
+	if !containsFold(invite.Query, q.Query) || !containsFold(invite.Company, q.Company) || !containsFold(invite.Location, q.Location) {
+		return false
+	}
+	if q.Search != "" {
+		text := strings.Join([]string{invite.ProfileName, invite.RawName, invite.ProfileTitle}, " ")
+		if !containsFold(text, q.Search) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsFold indica se s contém substr, sem diferenciar maiúsculas (vazio = sempre)
+func containsFold(s, substr string) bool {
+	substr = strings.TrimSpace(substr)
+	return substr == "" || strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// filterInvites aplica filtros, ordenação e paginação (comum aos backends);
+// invites deve estar em ordem de envio
+func filterInvites(invites []crawler.InviteRecord, q InviteQuery) ([]crawler.InviteRecord, int) {
+	filtered := make([]crawler.InviteRecord, 0, len(invites))
+	for _, invite := range invites {
+		if q.match(invite) {
+			filtered = append(filtered, invite)
+		}
+	}
+
+	var less func(a, b crawler.InviteRecord) bool
+	switch q.Sort {
+	case "timestamp":
+		less = func(a, b crawler.InviteRecord) bool { return a.Timestamp.Before(b.Timestamp) }
+	case "score":
+		less = func(a, b crawler.InviteRecord) bool { return a.Score < b.Score }
+	default:
+		if col := inviteSortText(q.Sort); col != nil {
+			less = func(a, b crawler.InviteRecord) bool {
+				return strings.ToLower(col(a)) < strings.ToLower(col(b))
+			}
+		}
+	}
+	switch {
+	case less != nil:
+		sort.SliceStable(filtered, func(i, j int) bool {
+			if q.Asc {
+				return less(filtered[i], filtered[j])
+			}
+			return less(filtered[j], filtered[i])
+		})
+	case !q.Asc:
+		// Sem coluna: ordem de envio, mais recentes primeiro
+		for i, j := 0, len(filtered)-1; i < j; i, j = i+1, j-1 {
+			filtered[i], filtered[j] = filtered[j], filtered[i]
+		}
+	}
+
+	total := len(filtered)
+	if q.Size <= 0 {
+		return filtered, total
+	}
+	start := q.Page * q.Size
+	if start >= total {
+		return []crawler.InviteRecord{}, total
+	}
+	end := start + q.Size
+	if end > total {
+		end = total
+	}
+	return filtered[start:end], total
+}
+
+// inviteSortText valor textual de uma coluna de ordenação (nil se desconhecida)
+func inviteSortText(column string) func(crawler.InviteRecord) string {
+	switch column {
+	case "user_email":
+		return func(r crawler.InviteRecord) string { return r.UserEmail }
+	case "profile_name":
+		return func(r crawler.InviteRecord) string { return r.ProfileName }
+	case "profile_title":
+		return func(r crawler.InviteRecord) string { return r.ProfileTitle }
+	case "company":
+		return func(r crawler.InviteRecord) string { return r.Company }
+	case "location":
+		return func(r crawler.InviteRecord) string { return r.Location }
+	case "query":
+		return func(r crawler.InviteRecord) string { return r.Query }
+	case "source":
+		return func(r crawler.InviteRecord) string { return r.Source }
+	case "status":
+		return func(r crawler.InviteRecord) string { return r.Status }
+	}
+	return nil
+}
@@ -3,18 +3,49 @@ package storage
 import (
 	"encoding/csv"
 	"fmt"
+	"log"
+	"math"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
 )
 
-// InviteStorage gerencia o armazenamento de convites em CSV
+// inviteHeader colunas atuais do CSV de convites. Novas colunas são sempre
+// adicionadas ao final; arquivos antigos são migrados na inicialização.
+var inviteHeader = []string{
+	"timestamp",
+	"user_email",
+	"profile_name",
+	"profile_title",
+	"company",
+	"location",
+	"linkedin_url",
+	"query",
+	"source",
+	"status",
+	"withdrawn_at",
+	"accepted_at",
+	"expired_at",
+	"score",
+	"score_rules",
+	"city",
+	"region",
+	"country",
+	"first_name",
+	"last_name",
+	"raw_name",
+}
+
+// InviteStorage gerencia o armazenamento de convites em CSV. Escritas são
+// serializadas no processo (mu) e entre processos (trava em invites.csv.lock).
 type InviteStorage struct {
+	mu       sync.Mutex // serializa appends e regravações
 	filePath string
-	writer   *csv.Writer
-	file     *os.File
 }
 
 // NewInviteStorage cria nova instância do storage
@@ -25,48 +56,38 @@ func NewInviteStorage() *InviteStorage {
 		panic(fmt.Sprintf("Erro ao criar diretório data: %v", err))
 	}
 
-	filePath := filepath.Join(dataDir, "invites.csv")
-	return &InviteStorage{
-		filePath: filePath,
-	}
+	return NewInviteStorageAt(filepath.Join(dataDir, "invites.csv"))
 }
 
-// AppendInvite adiciona um novo convite ao CSV
-func (s *InviteStorage) AppendInvite(record crawler.InviteRecord) error {
-	// Abrir arquivo para append (criar se não existir)
-	file, err := os.OpenFile(s.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("erro ao abrir arquivo CSV: %v", err)
+// NewInviteStorageAt abre o CSV de convites em outro arquivo (ex.: migração)
+func NewInviteStorageAt(filePath string) *InviteStorage {
+	s := &InviteStorage{
+		filePath: filePath,
 	}
-	defer file.Close()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
 
-	// Se arquivo está vazio, escrever cabeçalho
-	fileInfo, err := file.Stat()
-	if err != nil {
-		return fmt.Errorf("erro ao obter info do arquivo: %v", err)
+	// Reparar linhas truncadas por uma escrita interrompida
+	if report, err := s.CheckIntegrity(); err != nil {
+		log.Printf("Aviso: erro ao verificar %s: %v", filePath, err)
+	} else if report.Invalid > 0 || report.Quarantined > 0 {
+		log.Printf("⚠️ Verificação de integridade: %s", report)
 	}
 
-	if fileInfo.Size() == 0 {
-		header := []string{
-			"timestamp",
-			"user_email",
-			"profile_name",
-			"profile_title",
-			"company",
-			"location",
-			"linkedin_url",
-			"query",
-		}
-		if err := writer.Write(header); err != nil {
-			return fmt.Errorf("erro ao escrever cabeçalho: %v", err)
-		}
+	// Migrar arquivos gravados com um cabeçalho anterior
+	if err := s.migrateSchema(); err != nil {
+		log.Printf("Aviso: erro ao migrar %s: %v", filePath, err)
 	}
 
-	// Escrever registro
-	row := []string{
+	return s
+}
+
+// InviteHeader retorna as colunas do CSV de convites
+func InviteHeader() []string {
+	return append([]string(nil), inviteHeader...)
+}
+
+// InviteRow converte um convite na linha CSV correspondente a InviteHeader
+func InviteRow(record crawler.InviteRecord) []string {
+	return []string{
 		record.Timestamp.Format(time.RFC3339),
 		record.UserEmail,
 		record.ProfileName,
@@ -75,62 +96,331 @@ func (s *InviteStorage) AppendInvite(record crawler.InviteRecord) error {
 		record.Location,
 		record.LinkedInURL,
 		record.Query,
+		record.Source,
+		record.Status,
+		formatOptionalTime(record.WithdrawnAt),
+		formatOptionalTime(record.AcceptedAt),
+		formatOptionalTime(record.ExpiredAt),
+		formatScore(record.Score),
+		strings.Join(record.ScoreRules, ";"),
+		record.City,
+		record.Region,
+		record.Country,
+		record.FirstName,
+		record.LastName,
+		record.RawName,
 	}
+}
 
-	if err := writer.Write(row); err != nil {
-		return fmt.Errorf("erro ao escrever registro: %v", err)
+// formatScore formata a pontuação do lead sem casas decimais desnecessárias
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
+
+// splitRules separa as regras de pontuação gravadas com ";"
+func splitRules(value string) []string {
+	if value == "" {
+		return nil
 	}
+	return strings.Split(value, ";")
+}
 
-	return nil
+// formatOptionalTime formata datas opcionais (vazio quando zero)
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
 }
 
-// ListInvites lista convites com paginação
-func (s *InviteStorage) ListInvites(page, size int) ([]crawler.InviteRecord, int, error) {
+// parseInviteRow converte uma linha CSV usando o índice das colunas do cabeçalho do arquivo
+func parseInviteRow(index map[string]int, row []string) (crawler.InviteRecord, bool) {
+	get := func(col string) string {
+		if i, ok := index[col]; ok && i < len(row) {
+			return row[i]
+		}
+		return ""
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, get("timestamp"))
+	if err != nil {
+		return crawler.InviteRecord{}, false // Pular linhas com timestamp inválido
+	}
+
+	status := get("status")
+	if status == "" {
+		status = crawler.InviteStatusPending
+	}
+	withdrawnAt, _ := time.Parse(time.RFC3339, get("withdrawn_at"))
+	acceptedAt, _ := time.Parse(time.RFC3339, get("accepted_at"))
+	expiredAt, _ := time.Parse(time.RFC3339, get("expired_at"))
+	score, _ := strconv.ParseFloat(get("score"), 64)
+
+	return crawler.InviteRecord{
+		Timestamp:    timestamp,
+		UserEmail:    get("user_email"),
+		ProfileName:  get("profile_name"),
+		FirstName:    get("first_name"),
+		LastName:     get("last_name"),
+		RawName:      get("raw_name"),
+		ProfileTitle: get("profile_title"),
+		Company:      get("company"),
+		Location:     get("location"),
+		City:         get("city"),
+		Region:       get("region"),
+		Country:      get("country"),
+		LinkedInURL:  get("linkedin_url"),
+		Query:        get("query"),
+		Source:       get("source"),
+		Status:       status,
+		Score:        score,
+		ScoreRules:   splitRules(get("score_rules")),
+		AcceptedAt:   acceptedAt,
+		WithdrawnAt:  withdrawnAt,
+		ExpiredAt:    expiredAt,
+	}, true
+}
+
+// CheckIntegrity confere o CSV e move para data/quarantine/ as linhas
+// truncadas no final do arquivo (escrita interrompida)
+func (s *InviteStorage) CheckIntegrity() (IntegrityReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := lockFile(s.filePath, true)
+	if err != nil {
+		return IntegrityReport{File: s.filePath}, err
+	}
+	defer unlock()
+
+	return checkCSV(s.filePath, func(index map[string]int, row []string) bool {
+		_, ok := parseInviteRow(index, row)
+		return ok
+	})
+}
+
+// AppendInvite adiciona um novo convite ao CSV (com fsync)
+func (s *InviteStorage) AppendInvite(record crawler.InviteRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record.Status == "" {
+		record.Status = crawler.InviteStatusPending
+	}
+
+	unlock, err := lockFile(s.filePath, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return appendCSV(s.filePath, inviteHeader, InviteRow(record))
+}
+
+// readShared lê todos os convites com a trava compartilhada (não vê linhas
+// pela metade de um append em andamento)
+func (s *InviteStorage) readShared() ([]crawler.InviteRecord, error) {
+	unlock, err := lockFile(s.filePath, false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return s.readAll()
+}
+
+// readAll lê todos os convites do CSV (linhas inválidas são ignoradas)
+func (s *InviteStorage) readAll() ([]crawler.InviteRecord, error) {
 	// Abrir arquivo para leitura
 	file, err := os.Open(s.filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return []crawler.InviteRecord{}, 0, nil
+			return []crawler.InviteRecord{}, nil
 		}
-		return nil, 0, fmt.Errorf("erro ao abrir arquivo CSV: %v", err)
+		return nil, fmt.Errorf("erro ao abrir arquivo CSV: %v", err)
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
+	records, err := readCSVRows(file)
 	if err != nil {
-		return nil, 0, fmt.Errorf("erro ao ler CSV: %v", err)
+		return nil, err
 	}
 
-	// Pular cabeçalho
 	if len(records) == 0 {
-		return []crawler.InviteRecord{}, 0, nil
+		return []crawler.InviteRecord{}, nil
+	}
+
+	// Mapear colunas pelo cabeçalho do próprio arquivo
+	index := make(map[string]int, len(records[0]))
+	for i, col := range records[0] {
+		index[col] = i
 	}
 
 	// Converter registros
-	var invites []crawler.InviteRecord
+	invites := make([]crawler.InviteRecord, 0, len(records)-1)
 	for _, record := range records[1:] { // Pular cabeçalho
-		if len(record) < 8 {
-			continue // Pular linhas inválidas
+		if invite, ok := parseInviteRow(index, record); ok {
+			invites = append(invites, invite)
+		}
+	}
+
+	return invites, nil
+}
+
+// migrateSchema regrava o CSV com o cabeçalho atual quando o arquivo foi
+// criado por uma versão anterior (colunas novas ficam vazias)
+func (s *InviteStorage) migrateSchema() error {
+	file, err := os.Open(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	header, err := csv.NewReader(file).Read()
+	file.Close()
+	if err != nil || strings.Join(header, ",") == strings.Join(inviteHeader, ",") {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := lockFile(s.filePath, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	invites, err := s.readAll()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Migrando %s para o cabeçalho atual (%d convites)", s.filePath, len(invites))
+	return s.rewrite(invites)
+}
+
+// UpdateInvites aplica update a cada convite e regrava o arquivo se algum
+// foi alterado (update retorna true). Retorna quantos convites mudaram.
+func (s *InviteStorage) UpdateInvites(update func(record *crawler.InviteRecord) bool) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := lockFile(s.filePath, true)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	invites, err := s.readAll()
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for i := range invites {
+		if update(&invites[i]) {
+			changed++
 		}
+	}
+	if changed == 0 {
+		return 0, nil
+	}
+	return changed, s.rewrite(invites)
+}
 
-		timestamp, err := time.Parse(time.RFC3339, record[0])
-		if err != nil {
-			continue // Pular linhas com timestamp inválido
+// DeleteInvites remove os convites para os quais match retorna true (direito
+// ao esquecimento e retenção). Retorna quantos foram removidos.
+func (s *InviteStorage) DeleteInvites(match func(record crawler.InviteRecord) bool) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := lockFile(s.filePath, true)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	invites, err := s.readAll()
+	if err != nil {
+		return 0, err
+	}
+
+	kept := invites[:0]
+	for _, invite := range invites {
+		if !match(invite) {
+			kept = append(kept, invite)
 		}
+	}
+	removed := len(invites) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, s.rewrite(kept)
+}
+
+// MarkWithdrawn registra a retirada do convite pendente mais recente da conta
+// para o perfil. Retorna false se não há convite registrado para ele.
+func (s *InviteStorage) MarkWithdrawn(account, profileURL string, at time.Time) (bool, error) {
+	key := strings.ToLower(crawler.NormalizeProfileURL(profileURL))
+
+	// Localizar o convite mais recente (o arquivo está em ordem de envio)
+	latest, err := s.InvitesByURL(account)
+	if err != nil {
+		return false, err
+	}
+	target, ok := latest[key]
+	if !ok {
+		return false, nil
+	}
 
-		invite := crawler.InviteRecord{
-			Timestamp:    timestamp,
-			UserEmail:    record[1],
-			ProfileName:  record[2],
-			ProfileTitle: record[3],
-			Company:      record[4],
-			Location:     record[5],
-			LinkedInURL:  record[6],
-			Query:        record[7],
+	changed, err := s.UpdateInvites(func(record *crawler.InviteRecord) bool {
+		if !record.Timestamp.Equal(target.Timestamp) || strings.ToLower(crawler.NormalizeProfileURL(record.LinkedInURL)) != key {
+			return false
 		}
+		record.Status = crawler.InviteStatusWithdrawn
+		record.WithdrawnAt = at
+		return true
+	})
+	return changed > 0, err
+}
+
+// rewrite grava todos os convites de forma atômica (arquivo temporário + rename).
+// Deve ser chamado com as travas exclusivas.
+func (s *InviteStorage) rewrite(invites []crawler.InviteRecord) error {
+	tmp := s.filePath + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo temporário: %v", err)
+	}
+
+	writer := csv.NewWriter(file)
+	writer.Write(inviteHeader)
+	for _, invite := range invites {
+		writer.Write(InviteRow(invite))
+	}
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		file.Close()
+		return fmt.Errorf("erro ao escrever CSV: %v", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("erro ao sincronizar CSV: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.filePath)
+}
 
-		invites = append(invites, invite)
+// ListInvites lista convites com paginação
+func (s *InviteStorage) ListInvites(page, size int) ([]crawler.InviteRecord, int, error) {
+	invites, err := s.readShared()
+	if err != nil {
+		return nil, 0, err
 	}
 
 	total := len(invites)
@@ -150,8 +440,80 @@ func (s *InviteStorage) ListInvites(page, size int) ([]crawler.InviteRecord, int
 	return invites[start:end], total, nil
 }
 
+// SearchInvites lista convites com filtros, ordenação e paginação
+func (s *InviteStorage) SearchInvites(q InviteQuery) ([]crawler.InviteRecord, int, error) {
+	invites, err := s.readShared()
+	if err != nil {
+		return nil, 0, err
+	}
+	invites, total := filterInvites(invites, q)
+	return invites, total, nil
+}
+
+// InvitedURLs retorna o conjunto (em minúsculas) de perfis que já receberam convite
+func (s *InviteStorage) InvitedURLs() (map[string]bool, error) {
+	invites, _, err := s.ListInvites(0, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make(map[string]bool, len(invites))
+	for _, invite := range invites {
+		if invite.LinkedInURL != "" {
+			urls[strings.ToLower(crawler.NormalizeProfileURL(invite.LinkedInURL))] = true
+		}
+	}
+	return urls, nil
+}
+
+// InvitesByURL retorna o convite mais recente de cada perfil (URL normalizada
+// em minúsculas). Se account não for vazio, considera apenas convites da conta.
+func (s *InviteStorage) InvitesByURL(account string) (map[string]crawler.InviteRecord, error) {
+	invites, err := s.readShared()
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]crawler.InviteRecord, len(invites))
+	for _, invite := range invites {
+		if invite.LinkedInURL == "" {
+			continue
+		}
+		if account != "" && !strings.EqualFold(invite.UserEmail, account) {
+			continue
+		}
+		key := strings.ToLower(crawler.NormalizeProfileURL(invite.LinkedInURL))
+		if prev, ok := latest[key]; !ok || !invite.Timestamp.Before(prev.Timestamp) {
+			latest[key] = invite
+		}
+	}
+	return latest, nil
+}
+
+// CountInvites conta os convites da conta enviados em [from, to)
+func (s *InviteStorage) CountInvites(account string, from, to time.Time) (int, error) {
+	invites, err := s.readShared()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, invite := range invites {
+		if strings.EqualFold(invite.UserEmail, account) && !invite.Timestamp.Before(from) && invite.Timestamp.Before(to) {
+			count++
+		}
+	}
+	return count, nil
+}
+
 // GetTotalCount retorna o total de convites
 func (s *InviteStorage) GetTotalCount() (int, error) {
+	unlock, err := lockFile(s.filePath, false)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	file, err := os.Open(s.filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -161,10 +523,9 @@ func (s *InviteStorage) GetTotalCount() (int, error) {
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
-	if err != nil {
-		return 0, fmt.Errorf("erro ao ler CSV: %v", err)
+	records, err := readCSVRows(file)
+	if err != nil || len(records) == 0 {
+		return 0, err
 	}
 
 	// Retornar total (menos cabeçalho)
@@ -0,0 +1,238 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	// Banco de fusos embutido: o Windows e imagens mínimas não trazem zoneinfo
+	_ "time/tzdata"
+)
+
+// Janelas de contagem do limite semanal
+const (
+	WindowCalendarWeek = "calendar_week" // segunda 00:00 a domingo 23:59 no fuso da conta
+	WindowRolling7d    = "rolling_7d"    // últimos 7 dias a partir de agora
+)
+
+// DefaultWeeklyLimit limite semanal usado quando data/limits.json não define outro
+const DefaultWeeklyLimit = 200
+
+// LimitWindows ordem e descrição das janelas (formulário da UI)
+var LimitWindows = []struct {
+	ID    string
+	Title string
+}{
+	{WindowCalendarWeek, "Semana (segunda a domingo)"},
+	{WindowRolling7d, "Últimos 7 dias"},
+}
+
+// AccountLimits limites de convites de uma conta (Account vazio = padrão)
+type AccountLimits struct {
+	Account  string `json:"account,omitempty"`
+	Weekly   int    `json:"weekly"`
+	Daily    int    `json:"daily,omitempty"`    // 0 = sem limite diário
+	Window   string `json:"window,omitempty"`   // calendar_week (padrão) ou rolling_7d
+	Timezone string `json:"timezone,omitempty"` // nome IANA; vazio = fuso do servidor
+}
+
+// Validate confere valores, janela e fuso
+func (l AccountLimits) Validate() error {
+	if l.Weekly <= 0 {
+		return fmt.Errorf("limite semanal deve ser maior que zero")
+	}
+	if l.Daily < 0 {
+		return fmt.Errorf("limite diário não pode ser negativo")
+	}
+	if l.Daily > l.Weekly {
+		return fmt.Errorf("limite diário (%d) maior que o semanal (%d)", l.Daily, l.Weekly)
+	}
+	switch l.Window {
+	case "", WindowCalendarWeek, WindowRolling7d:
+	default:
+		return fmt.Errorf("janela desconhecida: %q", l.Window)
+	}
+	if l.Timezone != "" {
+		if _, err := time.LoadLocation(l.Timezone); err != nil {
+			return fmt.Errorf("fuso horário inválido: %q", l.Timezone)
+		}
+	}
+	return nil
+}
+
+// Location fuso da conta (servidor se vazio ou inválido)
+func (l AccountLimits) Location() *time.Location {
+	if l.Timezone != "" {
+		if loc, err := time.LoadLocation(l.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
+// Rolling indica se a janela semanal é móvel (últimos 7 dias)
+func (l AccountLimits) Rolling() bool {
+	return l.Window == WindowRolling7d
+}
+
+// WindowTitle descrição da janela para a UI
+func (l AccountLimits) WindowTitle() string {
+	for _, w := range LimitWindows {
+		if w.ID == l.Window {
+			return w.Title
+		}
+	}
+	return LimitWindows[0].Title
+}
+
+// limitsState conteúdo de data/limits.json (pode ser editado à mão com o servidor parado)
+type limitsState struct {
+	Default  AccountLimits   `json:"default"`
+	Accounts []AccountLimits `json:"accounts,omitempty"`
+}
+
+// Limits mantém os limites por conta em data/limits.json
+type Limits struct {
+	mu    sync.RWMutex
+	path  string
+	state limitsState
+}
+
+// NewLimits carrega os limites de data/limits.json (sem arquivo = 200/semana
+// na semana de calendário do fuso do servidor para todas as contas)
+func NewLimits() (*Limits, error) {
+	l := &Limits{
+		path:  filepath.Join("data", "limits.json"),
+		state: limitsState{Default: AccountLimits{Weekly: DefaultWeeklyLimit, Window: WindowCalendarWeek}},
+	}
+
+	content, err := os.ReadFile(l.path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("erro ao ler limites: %v", err)
+	}
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, &l.state); err != nil {
+			return nil, fmt.Errorf("erro ao interpretar limites: %v", err)
+		}
+	}
+
+	l.state.Default.Account = ""
+	if l.state.Default.Window == "" {
+		l.state.Default.Window = WindowCalendarWeek
+	}
+	if err := l.state.Default.Validate(); err != nil {
+		return nil, fmt.Errorf("limite padrão: %v", err)
+	}
+	for i, acc := range l.state.Accounts {
+		if err := acc.Validate(); err != nil {
+			return nil, fmt.Errorf("limite de %s: %v", acc.Account, err)
+		}
+		l.state.Accounts[i].Account = strings.ToLower(strings.TrimSpace(acc.Account))
+		if acc.Window == "" {
+			l.state.Accounts[i].Window = WindowCalendarWeek
+		}
+	}
+	return l, nil
+}
+
+// Get limites efetivos da conta (padrão quando a conta não tem configuração própria)
+func (l *Limits) Get(account string) AccountLimits {
+	key := strings.ToLower(strings.TrimSpace(account))
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, acc := range l.state.Accounts {
+		if acc.Account == key {
+			return acc
+		}
+	}
+	def := l.state.Default
+	def.Account = key
+	return def
+}
+
+// Default limites aplicados a contas sem configuração própria
+func (l *Limits) Default() AccountLimits {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.state.Default
+}
+
+// List retorna o padrão seguido das contas configuradas em ordem alfabética
+func (l *Limits) List() []AccountLimits {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	accounts := append([]AccountLimits(nil), l.state.Accounts...)
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Account < accounts[j].Account })
+	return append([]AccountLimits{l.state.Default}, accounts...)
+}
+
+// Set grava os limites da conta (Account vazio altera o padrão)
+func (l *Limits) Set(limits AccountLimits) error {
+	limits.Account = strings.ToLower(strings.TrimSpace(limits.Account))
+	limits.Timezone = strings.TrimSpace(limits.Timezone)
+	if limits.Window == "" {
+		limits.Window = WindowCalendarWeek
+	}
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if limits.Account == "" {
+		l.state.Default = limits
+		return l.saveLocked()
+	}
+	for i, acc := range l.state.Accounts {
+		if acc.Account == limits.Account {
+			l.state.Accounts[i] = limits
+			return l.saveLocked()
+		}
+	}
+	l.state.Accounts = append(l.state.Accounts, limits)
+	return l.saveLocked()
+}
+
+// Delete remove a configuração própria da conta (ela volta a usar o padrão)
+func (l *Limits) Delete(account string) error {
+	key := strings.ToLower(strings.TrimSpace(account))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, acc := range l.state.Accounts {
+		if acc.Account == key {
+			l.state.Accounts = append(l.state.Accounts[:i], l.state.Accounts[i+1:]...)
+			return l.saveLocked()
+		}
+	}
+	return fmt.Errorf("conta sem limite próprio: %s", account)
+}
+
+// saveLocked grava data/limits.json de forma atômica (l.mu deve estar travado)
+func (l *Limits) saveLocked() error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("erro ao criar diretório de limites: %v", err)
+	}
+
+	content, err := json.MarshalIndent(l.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar limites: %v", err)
+	}
+
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return fmt.Errorf("erro ao gravar limites: %v", err)
+	}
+	return os.Rename(tmp, l.path)
+}
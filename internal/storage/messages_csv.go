@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+// messageHeader colunas do CSV de mensagens de follow-up
+var messageHeader = []string{
+	"timestamp",
+	"user_email",
+	"linkedin_url",
+	"profile_name",
+	"sequence_id",
+	"step",
+	"status",
+	"text",
+	"error",
+}
+
+// MessageLog registro (append-only) das mensagens de follow-up em CSV
+type MessageLog struct {
+	mu       sync.Mutex
+	filePath string
+}
+
+// NewMessageLog cria o registro em data/messages.csv
+func NewMessageLog() *MessageLog {
+	if err := os.MkdirAll("data", 0755); err != nil {
+		panic(fmt.Sprintf("Erro ao criar diretório data: %v", err))
+	}
+	return &MessageLog{filePath: filepath.Join("data", "messages.csv")}
+}
+
+// Append adiciona uma mensagem ao CSV
+func (l *MessageLog) Append(record crawler.MessageRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := lockFile(l.filePath, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	file, err := os.OpenFile(l.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir arquivo CSV: %v", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("erro ao obter info do arquivo: %v", err)
+	}
+	if info.Size() == 0 {
+		if err := writer.Write(messageHeader); err != nil {
+			return fmt.Errorf("erro ao escrever cabeçalho: %v", err)
+		}
+	}
+
+	row := []string{
+		record.Timestamp.Format(time.RFC3339),
+		record.UserEmail,
+		record.LinkedInURL,
+		record.ProfileName,
+		record.SequenceID,
+		strconv.Itoa(record.Step),
+		record.Status,
+		record.Text,
+		record.Error,
+	}
+	if err := writer.Write(row); err != nil {
+		return fmt.Errorf("erro ao escrever registro: %v", err)
+	}
+	return nil
+}
+
+// List lê todas as mensagens (ordem de registro)
+func (l *MessageLog) List() ([]crawler.MessageRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.listLocked()
+}
+
+// listLocked lê o CSV (l.mu deve estar travado)
+func (l *MessageLog) listLocked() ([]crawler.MessageRecord, error) {
+	file, err := os.Open(l.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []crawler.MessageRecord{}, nil
+		}
+		return nil, fmt.Errorf("erro ao abrir arquivo CSV: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := readCSVRows(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []crawler.MessageRecord{}, nil
+	}
+
+	index := make(map[string]int, len(rows[0]))
+	for i, col := range rows[0] {
+		index[col] = i
+	}
+
+	messages := make([]crawler.MessageRecord, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		get := func(col string) string {
+			if i, ok := index[col]; ok && i < len(row) {
+				return row[i]
+			}
+			return ""
+		}
+		timestamp, err := time.Parse(time.RFC3339, get("timestamp"))
+		if err != nil {
+			continue
+		}
+		step, _ := strconv.Atoi(get("step"))
+		messages = append(messages, crawler.MessageRecord{
+			Timestamp:   timestamp,
+			UserEmail:   get("user_email"),
+			LinkedInURL: get("linkedin_url"),
+			ProfileName: get("profile_name"),
+			SequenceID:  get("sequence_id"),
+			Step:        step,
+			Status:      get("status"),
+			Text:        get("text"),
+			Error:       get("error"),
+		})
+	}
+	return messages, nil
+}
+
+// Delete remove as mensagens para as quais match retorna true e regrava o
+// CSV (arquivo temporário + rename). Retorna quantas foram removidas.
+func (l *MessageLog) Delete(match func(record crawler.MessageRecord) bool) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := lockFile(l.filePath, true)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	messages, err := l.listLocked()
+	if err != nil {
+		return 0, err
+	}
+
+	var buf strings.Builder
+	writer := csv.NewWriter(&buf)
+	writer.Write(messageHeader)
+	removed := 0
+	for _, m := range messages {
+		if match(m) {
+			removed++
+			continue
+		}
+		writer.Write([]string{
+			m.Timestamp.Format(time.RFC3339),
+			m.UserEmail,
+			m.LinkedInURL,
+			m.ProfileName,
+			m.SequenceID,
+			strconv.Itoa(m.Step),
+			m.Status,
+			m.Text,
+			m.Error,
+		})
+	}
+	writer.Flush()
+	if removed == 0 {
+		return 0, nil
+	}
+
+	tmp := l.filePath + ".tmp"
+	if err := os.WriteFile(tmp, []byte(buf.String()), 0644); err != nil {
+		return 0, fmt.Errorf("erro ao gravar mensagens: %v", err)
+	}
+	return removed, os.Rename(tmp, l.filePath)
+}
+
+// Recent retorna as últimas n mensagens, da mais recente para a mais antiga
+func (l *MessageLog) Recent(n int) ([]crawler.MessageRecord, error) {
+	messages, err := l.List()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]crawler.MessageRecord, 0, n)
+	for i := len(messages) - 1; i >= 0 && len(out) < n; i-- {
+		out = append(out, messages[i])
+	}
+	return out, nil
+}
+
+// ForContact retorna as mensagens registradas para o perfil
+func (l *MessageLog) ForContact(profileURL string) ([]crawler.MessageRecord, error) {
+	messages, err := l.List()
+	if err != nil {
+		return nil, err
+	}
+
+	key := strings.ToLower(crawler.NormalizeProfileURL(profileURL))
+	var out []crawler.MessageRecord
+	for _, m := range messages {
+		if strings.ToLower(crawler.NormalizeProfileURL(m.LinkedInURL)) == key {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
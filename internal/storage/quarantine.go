@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// QuarantineDir linhas truncadas removidas dos CSVs na verificação de integridade
+var QuarantineDir = filepath.Join("data", "quarantine")
+
+// quarantineFiles arquivos da quarentena
+func quarantineFiles() ([]string, error) {
+	entries, err := os.ReadDir(QuarantineDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler quarentena: %v", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			files = append(files, filepath.Join(QuarantineDir, entry.Name()))
+		}
+	}
+	return files, nil
+}
+
+// QuarantineLines linhas da quarentena para as quais match retorna true
+func QuarantineLines(match func(line string) bool) ([]string, error) {
+	files, err := quarantineFiles()
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler %s: %v", path, err)
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			if line != "" && match(line) {
+				out = append(out, line)
+			}
+		}
+	}
+	return out, nil
+}
+
+// ScrubQuarantine remove da quarentena as linhas para as quais match retorna
+// true (arquivos que ficam vazios são apagados). Retorna quantas linhas saíram.
+func ScrubQuarantine(match func(line string) bool) (int, error) {
+	files, err := quarantineFiles()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return removed, fmt.Errorf("erro ao ler %s: %v", path, err)
+		}
+
+		var kept []string
+		n := 0
+		for _, line := range strings.Split(string(content), "\n") {
+			switch {
+			case line == "":
+			case match(line):
+				n++
+			default:
+				kept = append(kept, line)
+			}
+		}
+		if n == 0 {
+			continue
+		}
+
+		if len(kept) == 0 {
+			err = os.Remove(path)
+		} else {
+			tmp := path + ".tmp"
+			if err = os.WriteFile(tmp, []byte(strings.Join(kept, "\n")+"\n"), 0644); err == nil {
+				err = os.Rename(tmp, path)
+			}
+		}
+		if err != nil {
+			return removed, fmt.Errorf("erro ao regravar %s: %v", path, err)
+		}
+		removed += n
+	}
+	return removed, nil
+}
+
+// PurgeQuarantine apaga os arquivos da quarentena modificados antes de before.
+// Retorna quantos arquivos foram apagados.
+func PurgeQuarantine(before time.Time) (int, error) {
+	files, err := quarantineFiles()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Before(before) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, fmt.Errorf("erro ao apagar %s: %v", path, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// QuerySets gerencia conjuntos de queries salvos com nome (data/query_sets/<nome>.txt)
+type QuerySets struct {
+	dir string
+}
+
+var querySetNameRx = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// NewQuerySets cria nova instância do armazenamento de conjuntos de queries
+func NewQuerySets() *QuerySets {
+	dir := filepath.Join("data", "query_sets")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		panic(fmt.Sprintf("Erro ao criar diretório de conjuntos de queries: %v", err))
+	}
+	return &QuerySets{dir: dir}
+}
+
+// NormalizeQuerySetName converte o nome informado em nome de arquivo seguro
+func NormalizeQuerySetName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(name, " ", "-")
+	return strings.Trim(querySetNameRx.ReplaceAllString(name, ""), "-")
+}
+
+// Save grava (ou substitui) o conjunto de queries com o nome informado
+func (q *QuerySets) Save(name string, content []byte) (string, error) {
+	slug := NormalizeQuerySetName(name)
+	if slug == "" {
+		return "", fmt.Errorf("nome de conjunto inválido: %q", name)
+	}
+
+	if err := os.WriteFile(filepath.Join(q.dir, slug+".txt"), content, 0644); err != nil {
+		return "", fmt.Errorf("erro ao salvar conjunto de queries: %v", err)
+	}
+	return slug, nil
+}
+
+// Load lê o conteúdo do conjunto de queries (interpretado como o arquivo
+// enviado: crawler.ParseQueries ou crawler.ParseProfileURLs)
+func (q *QuerySets) Load(name string) (string, error) {
+	slug := NormalizeQuerySetName(name)
+	content, err := os.ReadFile(filepath.Join(q.dir, slug+".txt"))
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler conjunto de queries '%s': %v", name, err)
+	}
+	return string(content), nil
+}
+
+// List retorna os nomes dos conjuntos salvos em ordem alfabética
+func (q *QuerySets) List() ([]string, error) {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar conjuntos de queries: %v", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".txt") {
+			names = append(names, strings.TrimSuffix(e.Name(), ".txt"))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
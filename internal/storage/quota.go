@@ -0,0 +1,260 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// reservationTTL validade de uma reserva não confirmada nem liberada (processo
+// que caiu entre a reserva e o clique não prende a vaga para sempre)
+const reservationTTL = 10 * time.Minute
+
+// Reservation vaga reservada para um convite ainda não registrado
+type Reservation struct {
+	ID        string    `json:"id"`
+	Account   string    `json:"account"`
+	PID       int       `json:"pid"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SentInvite convite enviado por um processo, mantido em data/quota.json
+// pela retenção do contador para que os demais processos o contem
+type SentInvite struct {
+	ID      string    `json:"id"`
+	Account string    `json:"account"`
+	Owner   string    `json:"owner"` // instância de Quota que enviou
+	At      time.Time `json:"at"`
+	Saved   bool      `json:"saved"` // false = envio não registrado no armazenamento
+}
+
+// quotaLedger conteúdo de data/quota.json
+type quotaLedger struct {
+	Reservations []Reservation `json:"reservations"`
+	Sent         []SentInvite  `json:"sent,omitempty"`
+}
+
+// Quota reserva vagas dos limites de convites antes do clique em Conectar.
+// O uso de uma conta vem do WeeklyCounter do processo (convites lidos na
+// criação mais os somados com Add), dos convites que outros processos
+// enviaram depois dessa leitura, dos convites enviados mas não registrados
+// no armazenamento e das reservas em aberto, todos em data/quota.json.
+// Reserva, confirmação e liberação acontecem com a trava
+// data/quota.json.lock, então execuções em paralelo no mesmo processo ou em
+// processos diferentes nunca ultrapassam o limite juntas, sem reler os
+// convites a cada reserva.
+type Quota struct {
+	mu      sync.Mutex
+	path    string
+	owner   string
+	counter *WeeklyCounter
+}
+
+// NewQuota cria o serviço de cotas sobre o contador do processo (os convites
+// registrados devem ser somados ao contador com Add antes do Commit)
+func NewQuota(counter *WeeklyCounter) *Quota {
+	return &Quota{path: filepath.Join("data", "quota.json"), owner: uuid.New().String(), counter: counter}
+}
+
+// QuotaSlot vaga reservada: Commit depois que o convite foi registrado no
+// armazenamento, Keep se foi enviado mas o registro falhou, Release se o
+// convite não foi enviado. Só a primeira chamada vale.
+type QuotaSlot struct {
+	ID      string
+	Account string
+
+	quota *Quota
+	once  sync.Once
+}
+
+// Commit confirma a vaga (o convite está no armazenamento e no contador)
+func (s *QuotaSlot) Commit() error {
+	return s.finish(true, true)
+}
+
+// Keep mantém a vaga ocupada pela janela inteira (convite enviado, mas o
+// registro no armazenamento falhou)
+func (s *QuotaSlot) Keep() error {
+	return s.finish(true, false)
+}
+
+// Release devolve a vaga (clique ou confirmação do convite falhou)
+func (s *QuotaSlot) Release() error {
+	return s.finish(false, false)
+}
+
+// finish encerra a reserva uma única vez
+func (s *QuotaSlot) finish(sent, saved bool) error {
+	var err error
+	s.once.Do(func() { err = s.quota.settle(s, sent, saved) })
+	return err
+}
+
+// Reserve reserva uma vaga para a conta. Sem vaga (limite semanal ou diário
+// atingido) retorna slot nil e o uso atual; com vaga, o uso já inclui a reserva.
+func (q *Quota) Reserve(account string) (*QuotaSlot, InviteUsage, error) {
+	now := time.Now()
+	limits := q.counter.Limits().Get(account)
+	weekStart, today := windowStarts(limits, now)
+	usage := InviteUsage{Limits: limits, WindowStart: weekStart}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	unlock, err := q.lock()
+	if err != nil {
+		return nil, usage, err
+	}
+	defer unlock()
+
+	ledger, err := q.load()
+	if err != nil {
+		return nil, usage, err
+	}
+	ledger.prune(now)
+
+	counted := q.counter.usageAt(account, now)
+	usage.Week, usage.Today = counted.Week, counted.Today
+	for _, sent := range ledger.Sent {
+		if !strings.EqualFold(sent.Account, account) || !q.uncounted(sent) {
+			continue
+		}
+		if !sent.At.Before(weekStart) {
+			usage.Week++
+		}
+		if !sent.At.Before(today) {
+			usage.Today++
+		}
+	}
+	for _, r := range ledger.Reservations {
+		if strings.EqualFold(r.Account, account) {
+			usage.Week++
+			usage.Today++
+		}
+	}
+
+	if !usage.CanSend() {
+		return nil, usage, q.save(ledger)
+	}
+
+	r := Reservation{
+		ID:        uuid.New().String(),
+		Account:   strings.ToLower(account),
+		PID:       os.Getpid(),
+		CreatedAt: now,
+		ExpiresAt: now.Add(reservationTTL),
+	}
+	ledger.Reservations = append(ledger.Reservations, r)
+	if err := q.save(ledger); err != nil {
+		return nil, usage, err
+	}
+
+	usage.Week++
+	usage.Today++
+	return &QuotaSlot{ID: r.ID, Account: r.Account, quota: q}, usage, nil
+}
+
+// uncounted indica se o convite enviado falta no contador deste processo:
+// enviado por outro processo depois da leitura do armazenamento, ou não
+// registrado (nesse caso só o contador de quem enviou o conhece)
+func (q *Quota) uncounted(sent SentInvite) bool {
+	if sent.Owner == q.owner {
+		return false
+	}
+	return !sent.Saved || !sent.At.Before(q.counter.loadedAt)
+}
+
+// settle apaga a reserva do registro (já expirada = nada a apagar) e, se o
+// convite foi enviado, guarda o envio para os demais processos
+func (q *Quota) settle(s *QuotaSlot, sent, saved bool) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	unlock, err := q.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	ledger, err := q.load()
+	if err != nil {
+		return err
+	}
+	ledger.prune(time.Now())
+
+	kept := ledger.Reservations[:0]
+	for _, r := range ledger.Reservations {
+		if r.ID != s.ID {
+			kept = append(kept, r)
+		}
+	}
+	ledger.Reservations = kept
+	if sent {
+		ledger.Sent = append(ledger.Sent, SentInvite{ID: s.ID, Account: s.Account, Owner: q.owner, At: time.Now(), Saved: saved})
+	}
+	return q.save(ledger)
+}
+
+// lock trava data/quota.json entre processos
+func (q *Quota) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(q.path), 0755); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório de cotas: %v", err)
+	}
+	return lockFile(q.path, true)
+}
+
+// load lê o registro de reservas (deve ser chamado com a trava)
+func (q *Quota) load() (quotaLedger, error) {
+	var ledger quotaLedger
+	content, err := os.ReadFile(q.path)
+	if err != nil && !os.IsNotExist(err) {
+		return ledger, fmt.Errorf("erro ao ler reservas de convites: %v", err)
+	}
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, &ledger); err != nil {
+			return ledger, fmt.Errorf("erro ao interpretar reservas de convites: %v", err)
+		}
+	}
+	return ledger, nil
+}
+
+// save grava o registro de reservas de forma atômica (deve ser chamado com a trava)
+func (q *Quota) save(ledger quotaLedger) error {
+	content, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar reservas de convites: %v", err)
+	}
+
+	tmp := q.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return fmt.Errorf("erro ao gravar reservas de convites: %v", err)
+	}
+	return os.Rename(tmp, q.path)
+}
+
+// prune descarta reservas expiradas e envios mais antigos que a retenção do contador
+func (l *quotaLedger) prune(now time.Time) {
+	kept := l.Reservations[:0]
+	for _, r := range l.Reservations {
+		if now.Before(r.ExpiresAt) {
+			kept = append(kept, r)
+		}
+	}
+	l.Reservations = kept
+
+	oldest := now.Add(-counterRetention)
+	sent := l.Sent[:0]
+	for _, s := range l.Sent {
+		if s.At.After(oldest) {
+			sent = append(sent, s)
+		}
+	}
+	l.Sent = sent
+}
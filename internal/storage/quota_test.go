@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+// chdirTemp roda o teste em um diretório vazio (o armazenamento CSV usa data/)
+func chdirTemp(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func TestQuotaReserve(t *testing.T) {
+	chdirTemp(t)
+	store, err := NewCSVStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const account = "Vendas@Empresa.com"
+	limits := &Limits{state: limitsState{Default: AccountLimits{Weekly: 3, Daily: 1, Window: WindowRolling7d}}}
+
+	// Convite de ontem conta na semana, não no dia
+	yesterday := time.Now().AddDate(0, 0, -1).Add(-time.Hour)
+	if err := store.AppendInvite(crawler.InviteRecord{Timestamp: yesterday, UserEmail: "vendas@empresa.com", LinkedInURL: "https://www.linkedin.com/in/ana"}); err != nil {
+		t.Fatal(err)
+	}
+	counter, err := NewWeeklyCounter(store, limits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	quota := NewQuota(counter)
+
+	first, usage, err := quota.Reserve(account)
+	if err != nil || first == nil {
+		t.Fatalf("Reserve = %v, %v; esperado vaga", first, err)
+	}
+	if usage.Week != 2 || usage.Today != 1 {
+		t.Errorf("uso após reserva = (semana %d, hoje %d), esperado (2, 1)", usage.Week, usage.Today)
+	}
+
+	// Convite registrado e vaga confirmada: passa a contar pelo contador
+	invite := crawler.InviteRecord{Timestamp: time.Now(), UserEmail: account, LinkedInURL: "https://www.linkedin.com/in/bruno"}
+	if err := store.AppendInvite(invite); err != nil {
+		t.Fatal(err)
+	}
+	counter.Add(invite)
+	if err := first.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Limite diário (1) atingido
+	if slot, usage, err := quota.Reserve(account); err != nil || slot != nil || usage.Today != 1 {
+		t.Errorf("Reserve no limite diário = %v, hoje %d, %v; esperado sem vaga, hoje 1", slot, usage.Today, err)
+	}
+}
+
+func TestQuotaReservationCountsUntilReleased(t *testing.T) {
+	chdirTemp(t)
+	store, err := NewCSVStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const account = "vendas@empresa.com"
+	counter, err := NewWeeklyCounter(store, &Limits{state: limitsState{Default: AccountLimits{Weekly: 1, Window: WindowCalendarWeek}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	quota := NewQuota(counter)
+
+	// Reserva sem Commit nem Release (registro do convite falhou) continua contando
+	slot, _, err := quota.Reserve(account)
+	if err != nil || slot == nil {
+		t.Fatalf("Reserve = %v, %v; esperado vaga", slot, err)
+	}
+	if other, usage, _ := quota.Reserve(account); other != nil || usage.Week != 1 {
+		t.Errorf("Reserve com reserva em aberto = %v, semana %d; esperado sem vaga, semana 1", other, usage.Week)
+	}
+
+	if err := slot.Release(); err != nil {
+		t.Fatal(err)
+	}
+	if again, _, err := quota.Reserve(account); err != nil || again == nil {
+		t.Errorf("Reserve após Release = %v, %v; esperado vaga", again, err)
+	}
+}
+
+func TestQuotaSharedBetweenProcesses(t *testing.T) {
+	chdirTemp(t)
+	store, err := NewCSVStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const account = "vendas@empresa.com"
+	limits := &Limits{state: limitsState{Default: AccountLimits{Weekly: 2, Window: WindowCalendarWeek}}}
+	newQuota := func() (*WeeklyCounter, *Quota) {
+		counter, err := NewWeeklyCounter(store, limits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return counter, NewQuota(counter)
+	}
+	serverCounter, server := newQuota()
+	cliCounter, cli := newQuota()
+
+	// A CLI envia e registra um convite que o contador do servidor não leu
+	slot, _, err := cli.Reserve(account)
+	if err != nil || slot == nil {
+		t.Fatalf("Reserve na CLI = %v, %v; esperado vaga", slot, err)
+	}
+	invite := crawler.InviteRecord{Timestamp: time.Now(), UserEmail: account, LinkedInURL: "https://www.linkedin.com/in/ana"}
+	if err := store.AppendInvite(invite); err != nil {
+		t.Fatal(err)
+	}
+	cliCounter.Add(invite)
+	if err := slot.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// O servidor conta o convite da CLI; o seu próprio é enviado mas o registro falha
+	slot, usage, err := server.Reserve(account)
+	if err != nil || slot == nil || usage.Week != 2 {
+		t.Fatalf("Reserve no servidor = %v, semana %d, %v; esperado vaga, semana 2", slot, usage.Week, err)
+	}
+	serverCounter.Add(crawler.InviteRecord{Timestamp: time.Now(), UserEmail: account})
+	if err := slot.Keep(); err != nil {
+		t.Fatal(err)
+	}
+
+	if other, usage, err := cli.Reserve(account); err != nil || other != nil || usage.Week != 2 {
+		t.Errorf("Reserve na CLI após Keep = %v, semana %d, %v; esperado sem vaga, semana 2", other, usage.Week, err)
+	}
+
+	// Reiniciado, o servidor relê o convite registrado e mantém o não registrado
+	_, restarted := newQuota()
+	if other, usage, err := restarted.Reserve(account); err != nil || other != nil || usage.Week != 2 {
+		t.Errorf("Reserve após reinício = %v, semana %d, %v; esperado sem vaga, semana 2", other, usage.Week, err)
+	}
+}
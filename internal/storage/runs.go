@@ -0,0 +1,243 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+// RunRecord resumo de uma execução do crawler. Config, Queries e Warnings
+// formam o relatório da execução (/runs/:id/report); execuções gravadas antes
+// deles existirem vêm sem essas informações.
+type RunRecord struct {
+	ID         string    `json:"id"`
+	Account    string    `json:"account"`
+	Label      string    `json:"label"`
+	Mode       string    `json:"mode"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Captured   int       `json:"captured"`
+	Invites    int       `json:"invites"`
+
+	Config   *crawler.RunConfig   `json:"config,omitempty"`
+	Queries  []crawler.QueryStats `json:"queries,omitempty"`
+	Warnings []string             `json:"warnings,omitempty"`
+}
+
+// Stats contadores por query e avisos no formato do motor
+func (r RunRecord) Stats() crawler.RunStats {
+	return crawler.RunStats{Queries: r.Queries, Warnings: r.Warnings}
+}
+
+// Duration duração da execução (zero se ainda não terminou)
+func (r RunRecord) Duration() time.Duration {
+	if r.StartedAt.IsZero() || r.FinishedAt.IsZero() {
+		return 0
+	}
+	return r.FinishedAt.Sub(r.StartedAt).Round(time.Second)
+}
+
+// RunLog histórico de execuções em data/runs.jsonl (um JSON por linha; a
+// última linha de cada ID prevalece)
+type RunLog struct {
+	mu       sync.Mutex
+	filePath string
+}
+
+// NewRunLog cria o histórico em data/runs.jsonl
+func NewRunLog() *RunLog {
+	return NewRunLogAt(filepath.Join("data", "runs.jsonl"))
+}
+
+// NewRunLogAt cria o histórico em um arquivo específico (ex.: migração)
+func NewRunLogAt(filePath string) *RunLog {
+	return &RunLog{filePath: filePath}
+}
+
+// Save acrescenta (ou substitui) a execução no histórico
+func (l *RunLog) Save(run RunRecord) error {
+	line, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar execução: %v", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.filePath), 0755); err != nil {
+		return fmt.Errorf("erro ao criar diretório de execuções: %v", err)
+	}
+	unlock, err := lockFile(l.filePath, true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	file, err := os.OpenFile(l.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir histórico de execuções: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("erro ao gravar execução: %v", err)
+	}
+	return nil
+}
+
+// Get retorna a execução pelo ID (última versão gravada)
+func (l *RunLog) Get(id string) (RunRecord, bool, error) {
+	runs, err := l.List(0)
+	if err != nil {
+		return RunRecord{}, false, err
+	}
+	for _, run := range runs {
+		if run.ID == id {
+			return run, true, nil
+		}
+	}
+	return RunRecord{}, false, nil
+}
+
+// List retorna as últimas n execuções (n <= 0 = todas), da mais recente para a mais antiga
+func (l *RunLog) List(n int) ([]RunRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	runs, err := l.listLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]RunRecord, 0, len(runs))
+	for i := len(runs) - 1; i >= 0 && (n <= 0 || len(out) < n); i-- {
+		out = append(out, runs[i])
+	}
+	return out, nil
+}
+
+// Delete remove do histórico as execuções que atendem ao critério
+func (l *RunLog) Delete(match func(run RunRecord) bool) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := lockFile(l.filePath, true)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	runs, err := l.listLocked()
+	if err != nil {
+		return 0, err
+	}
+	kept := runs[:0]
+	for _, run := range runs {
+		if !match(run) {
+			kept = append(kept, run)
+		}
+	}
+	removed := len(runs) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, l.rewriteLocked(kept)
+}
+
+// Update altera as execuções no histórico; update devolve true quando mudou a execução
+func (l *RunLog) Update(update func(run *RunRecord) bool) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := lockFile(l.filePath, true)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	runs, err := l.listLocked()
+	if err != nil {
+		return 0, err
+	}
+	changed := 0
+	for i := range runs {
+		if update(&runs[i]) {
+			changed++
+		}
+	}
+	if changed == 0 {
+		return 0, nil
+	}
+	return changed, l.rewriteLocked(runs)
+}
+
+// listLocked lê o histórico, uma execução por ID na ordem de criação (l.mu deve estar travado)
+func (l *RunLog) listLocked() ([]RunRecord, error) {
+	file, err := os.Open(l.filePath)
+	if os.IsNotExist(err) {
+		return []RunRecord{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir histórico de execuções: %v", err)
+	}
+	defer file.Close()
+
+	var order []string
+	runs := map[string]RunRecord{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64<<10), 4<<20)
+	for scanner.Scan() {
+		var run RunRecord
+		if json.Unmarshal(scanner.Bytes(), &run) != nil || run.ID == "" {
+			continue
+		}
+		if _, ok := runs[run.ID]; !ok {
+			order = append(order, run.ID)
+		}
+		runs[run.ID] = run
+	}
+
+	out := make([]RunRecord, 0, len(order))
+	for _, id := range order {
+		out = append(out, runs[id])
+	}
+	return out, scanner.Err()
+}
+
+// rewriteLocked regrava o histórico compactado (uma linha por execução) via
+// arquivo temporário + rename, sincronizado com o disco (l.mu deve estar travado)
+func (l *RunLog) rewriteLocked(runs []RunRecord) error {
+	tmp := l.filePath + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo temporário: %v", err)
+	}
+	writer := bufio.NewWriter(file)
+	for _, run := range runs {
+		line, err := json.Marshal(run)
+		if err != nil {
+			file.Close()
+			return fmt.Errorf("erro ao serializar execução: %v", err)
+		}
+		writer.Write(append(line, '\n'))
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("erro ao gravar histórico de execuções: %v", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("erro ao sincronizar histórico de execuções: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, l.filePath)
+}
@@ -0,0 +1,758 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+
+	_ "modernc.org/sqlite" // driver "sqlite" (Go puro, sem cgo)
+)
+
+// SQLiteStore implementação em banco SQLite. Convites e capturas usam as
+// mesmas colunas dos CSVs (mais ts e url_key, indexadas por conta/data e
+// por perfil); execuções são gravadas como JSON.
+type SQLiteStore struct {
+	mu sync.Mutex // serializa regravações (UpdateInvites)
+	db *sql.DB
+}
+
+// NewSQLiteStore abre (ou cria) o banco e aplica o esquema atual
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório do banco: %v", err)
+	}
+
+	// secure_delete: linhas apagadas (direito ao esquecimento) são zeradas no arquivo
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=secure_delete(1)")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir banco %s: %v", path, err)
+	}
+	// Uma conexão: escritas de vários goroutines não disputam o lock do arquivo
+	db.SetMaxOpenConns(1)
+
+	s := &SQLiteStore{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("erro ao preparar banco %s: %v", path, err)
+	}
+	return s, nil
+}
+
+// migrate cria as tabelas, acrescenta colunas novas dos CSVs e cria os índices
+func (s *SQLiteStore) migrate() error {
+	for _, stmt := range []string{
+		`CREATE TABLE IF NOT EXISTS invites (id INTEGER PRIMARY KEY AUTOINCREMENT, ts INTEGER NOT NULL, url_key TEXT NOT NULL DEFAULT '')`,
+		`CREATE TABLE IF NOT EXISTS captures (id INTEGER PRIMARY KEY AUTOINCREMENT, ts INTEGER NOT NULL, url_key TEXT NOT NULL DEFAULT '')`,
+		`CREATE TABLE IF NOT EXISTS contacts (key TEXT PRIMARY KEY, first_seen INTEGER NOT NULL, last_seen INTEGER NOT NULL, data TEXT NOT NULL)`,
+		`CREATE TABLE IF NOT EXISTS runs (id TEXT PRIMARY KEY, account TEXT NOT NULL DEFAULT '' COLLATE NOCASE, started_at INTEGER NOT NULL DEFAULT 0, data TEXT NOT NULL)`,
+	} {
+		if _, err := s.db.Exec(stmt); err != nil {
+			return err
+		}
+	}
+
+	if err := s.addColumns("invites", inviteHeader); err != nil {
+		return err
+	}
+	if err := s.addColumns("captures", captureHeader); err != nil {
+		return err
+	}
+
+	for _, stmt := range []string{
+		`CREATE INDEX IF NOT EXISTS idx_invites_account_ts ON invites (user_email, ts)`,
+		`CREATE INDEX IF NOT EXISTS idx_invites_url ON invites (url_key)`,
+		`CREATE INDEX IF NOT EXISTS idx_captures_account_ts ON captures (user_email, ts)`,
+		`CREATE INDEX IF NOT EXISTS idx_captures_url ON captures (url_key)`,
+		`CREATE INDEX IF NOT EXISTS idx_contacts_last_seen ON contacts (last_seen)`,
+		`CREATE INDEX IF NOT EXISTS idx_runs_started ON runs (started_at)`,
+	} {
+		if _, err := s.db.Exec(stmt); err != nil {
+			return err
+		}
+	}
+
+	// Bancos criados antes da tabela de contatos: consolidar as capturas
+	var contacts, captures int
+	s.db.QueryRow(`SELECT COUNT(*) FROM contacts`).Scan(&contacts)
+	s.db.QueryRow(`SELECT COUNT(*) FROM captures`).Scan(&captures)
+	if contacts == 0 && captures > 0 {
+		return s.rebuildContacts()
+	}
+	return nil
+}
+
+// addColumns acrescenta as colunas do cabeçalho que a tabela ainda não tem
+func (s *SQLiteStore) addColumns(table string, header []string) error {
+	rows, err := s.db.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return err
+	}
+	existing := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, col := range header {
+		if existing[col] {
+			continue
+		}
+		def := col + ` TEXT NOT NULL DEFAULT ''`
+		if col == "user_email" {
+			def += " COLLATE NOCASE"
+		}
+		if _, err := s.db.Exec("ALTER TABLE " + table + " ADD COLUMN " + def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execer sql.DB ou sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// profileKey chave de um perfil (URL normalizada em minúsculas)
+func profileKey(url string) string {
+	if url == "" {
+		return ""
+	}
+	return strings.ToLower(crawler.NormalizeProfileURL(url))
+}
+
+// insertRow grava uma linha com as colunas do cabeçalho
+func insertRow(ex execer, table string, header []string, ts time.Time, url string, row []string) error {
+	cols := append([]string{"ts", "url_key"}, header...)
+	args := []interface{}{ts.Unix(), profileKey(url)}
+	for _, value := range row {
+		args = append(args, value)
+	}
+	query := "INSERT INTO " + table + " (" + strings.Join(cols, ", ") + ") VALUES (?" + strings.Repeat(", ?", len(cols)-1) + ")"
+	_, err := ex.Exec(query, args...)
+	return err
+}
+
+// headerIndex índice das colunas (mesmo formato usado na leitura dos CSVs)
+func headerIndex(header []string) map[string]int {
+	index := make(map[string]int, len(header))
+	for i, col := range header {
+		index[col] = i
+	}
+	return index
+}
+
+// queryRows lê id e as colunas do cabeçalho de cada linha
+func (s *SQLiteStore) queryRows(table string, header []string, where string, args ...interface{}) ([]int64, [][]string, error) {
+	rows, err := s.db.Query("SELECT id, "+strings.Join(header, ", ")+" FROM "+table+" "+where, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao consultar %s: %v", table, err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	var out [][]string
+	for rows.Next() {
+		var id int64
+		row := make([]string, len(header))
+		dest := []interface{}{&id}
+		for i := range row {
+			dest = append(dest, &row[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, nil, fmt.Errorf("erro ao ler %s: %v", table, err)
+		}
+		ids = append(ids, id)
+		out = append(out, row)
+	}
+	return ids, out, rows.Err()
+}
+
+// queryInvites lê convites (e seus ids) com o filtro/ordem indicados
+func (s *SQLiteStore) queryInvites(where string, args ...interface{}) ([]int64, []crawler.InviteRecord, error) {
+	ids, rows, err := s.queryRows("invites", inviteHeader, where, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	index := headerIndex(inviteHeader)
+	invites := make([]crawler.InviteRecord, 0, len(rows))
+	kept := make([]int64, 0, len(rows))
+	for i, row := range rows {
+		if invite, ok := parseInviteRow(index, row); ok {
+			invites = append(invites, invite)
+			kept = append(kept, ids[i])
+		}
+	}
+	return kept, invites, nil
+}
+
+// AppendInvite adiciona um novo convite
+func (s *SQLiteStore) AppendInvite(record crawler.InviteRecord) error {
+	if record.Status == "" {
+		record.Status = crawler.InviteStatusPending
+	}
+	if err := insertRow(s.db, "invites", inviteHeader, record.Timestamp, record.LinkedInURL, InviteRow(record)); err != nil {
+		return fmt.Errorf("erro ao gravar convite: %v", err)
+	}
+	return nil
+}
+
+// ImportInvites grava vários convites em uma única transação (migração)
+func (s *SQLiteStore) ImportInvites(records []crawler.InviteRecord) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if record.Status == "" {
+			record.Status = crawler.InviteStatusPending
+		}
+		if err := insertRow(tx, "invites", inviteHeader, record.Timestamp, record.LinkedInURL, InviteRow(record)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("erro ao importar convite: %v", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ListInvites lista convites com paginação (ordem de envio)
+func (s *SQLiteStore) ListInvites(page, size int) ([]crawler.InviteRecord, int, error) {
+	total, err := s.GetTotalCount()
+	if err != nil {
+		return nil, 0, err
+	}
+	if page*size >= total {
+		return []crawler.InviteRecord{}, total, nil
+	}
+
+	_, invites, err := s.queryInvites("ORDER BY id LIMIT ? OFFSET ?", size, page*size)
+	if err != nil {
+		return nil, 0, err
+	}
+	return invites, total, nil
+}
+
+// SearchInvites lista convites com filtros, ordenação e paginação. Conta e
+// período usam os índices; os demais filtros são aplicados como no CSV.
+func (s *SQLiteStore) SearchInvites(q InviteQuery) ([]crawler.InviteRecord, int, error) {
+	var conds []string
+	var args []interface{}
+	if q.Account != "" {
+		conds, args = append(conds, "user_email = ?"), append(args, q.Account)
+	}
+	if !q.From.IsZero() {
+		conds, args = append(conds, "ts >= ?"), append(args, q.From.Unix())
+	}
+	if !q.To.IsZero() {
+		conds, args = append(conds, "ts < ?"), append(args, q.To.Unix())
+	}
+	where := "ORDER BY id"
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ") + " " + where
+	}
+
+	_, invites, err := s.queryInvites(where, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	invites, total := filterInvites(invites, q)
+	return invites, total, nil
+}
+
+// GetTotalCount retorna o total de convites
+func (s *SQLiteStore) GetTotalCount() (int, error) {
+	var total int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM invites`).Scan(&total); err != nil {
+		return 0, fmt.Errorf("erro ao contar convites: %v", err)
+	}
+	return total, nil
+}
+
+// CountInvites conta os convites da conta enviados em [from, to)
+func (s *SQLiteStore) CountInvites(account string, from, to time.Time) (int, error) {
+	var count int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM invites WHERE user_email = ? AND ts >= ? AND ts < ?`,
+		account, from.Unix(), to.Unix()).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao contar convites: %v", err)
+	}
+	return count, nil
+}
+
+// InvitedURLs retorna o conjunto (em minúsculas) de perfis que já receberam convite
+func (s *SQLiteStore) InvitedURLs() (map[string]bool, error) {
+	rows, err := s.db.Query(`SELECT DISTINCT url_key FROM invites WHERE url_key <> ''`)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar convites: %v", err)
+	}
+	defer rows.Close()
+
+	urls := map[string]bool{}
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("erro ao ler convites: %v", err)
+		}
+		urls[key] = true
+	}
+	return urls, rows.Err()
+}
+
+// InvitesByURL retorna o convite mais recente de cada perfil (URL normalizada
+// em minúsculas). Se account não for vazio, considera apenas convites da conta.
+func (s *SQLiteStore) InvitesByURL(account string) (map[string]crawler.InviteRecord, error) {
+	where, args := "WHERE url_key <> ''", []interface{}{}
+	if account != "" {
+		where += " AND user_email = ?"
+		args = append(args, account)
+	}
+	_, invites, err := s.queryInvites(where+" ORDER BY ts, id", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]crawler.InviteRecord, len(invites))
+	for _, invite := range invites {
+		latest[profileKey(invite.LinkedInURL)] = invite
+	}
+	return latest, nil
+}
+
+// UpdateInvites aplica update a cada convite e grava os que foram alterados
+// (update retorna true). Retorna quantos convites mudaram.
+func (s *SQLiteStore) UpdateInvites(update func(record *crawler.InviteRecord) bool) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, invites, err := s.queryInvites("ORDER BY id")
+	if err != nil {
+		return 0, err
+	}
+
+	assignments := make([]string, 0, len(inviteHeader)+2)
+	for _, col := range append([]string{"ts", "url_key"}, inviteHeader...) {
+		assignments = append(assignments, col+" = ?")
+	}
+	query := "UPDATE invites SET " + strings.Join(assignments, ", ") + " WHERE id = ?"
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	changed := 0
+	for i := range invites {
+		if !update(&invites[i]) {
+			continue
+		}
+		args := []interface{}{invites[i].Timestamp.Unix(), profileKey(invites[i].LinkedInURL)}
+		for _, value := range InviteRow(invites[i]) {
+			args = append(args, value)
+		}
+		if _, err := tx.Exec(query, append(args, ids[i])...); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("erro ao atualizar convite: %v", err)
+		}
+		changed++
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("erro ao atualizar convites: %v", err)
+	}
+	return changed, nil
+}
+
+// MarkWithdrawn registra a retirada do convite mais recente da conta para o
+// perfil. Retorna false se não há convite registrado para ele.
+func (s *SQLiteStore) MarkWithdrawn(account, profileURL string, at time.Time) (bool, error) {
+	where, args := "WHERE url_key = ?", []interface{}{profileKey(profileURL)}
+	if account != "" {
+		where += " AND user_email = ?"
+		args = append(args, account)
+	}
+
+	var id int64
+	err := s.db.QueryRow(`SELECT id FROM invites `+where+` ORDER BY ts DESC, id DESC LIMIT 1`, args...).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("erro ao consultar convite: %v", err)
+	}
+
+	_, err = s.db.Exec(`UPDATE invites SET status = ?, withdrawn_at = ? WHERE id = ?`,
+		crawler.InviteStatusWithdrawn, formatOptionalTime(at), id)
+	if err != nil {
+		return false, fmt.Errorf("erro ao atualizar convite: %v", err)
+	}
+	return true, nil
+}
+
+// DeleteInvites remove os convites para os quais match retorna true
+func (s *SQLiteStore) DeleteInvites(match func(record crawler.InviteRecord) bool) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, invites, err := s.queryInvites("ORDER BY id")
+	if err != nil {
+		return 0, err
+	}
+	var remove []interface{}
+	for i, invite := range invites {
+		if match(invite) {
+			remove = append(remove, ids[i])
+		}
+	}
+	return len(remove), s.deleteRows("DELETE FROM invites WHERE id = ?", remove)
+}
+
+// deleteRows executa a remoção para cada chave em uma transação e descarrega
+// o WAL, para que as linhas apagadas não fiquem em disco
+func (s *SQLiteStore) deleteRows(query string, keys []interface{}) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, err := tx.Exec(query, key); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("erro ao remover registro: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`PRAGMA wal_checkpoint(TRUNCATE)`)
+	return err
+}
+
+// AppendCapture adiciona uma captura
+func (s *SQLiteStore) AppendCapture(record crawler.CaptureRecord) error {
+	if err := insertRow(s.db, "captures", captureHeader, record.Timestamp, record.LinkedIn, captureRow(record)); err != nil {
+		return fmt.Errorf("erro ao gravar captura: %v", err)
+	}
+	return nil
+}
+
+// ImportCaptures grava várias capturas em uma única transação (migração)
+func (s *SQLiteStore) ImportCaptures(records []crawler.CaptureRecord) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := insertRow(tx, "captures", captureHeader, record.Timestamp, record.LinkedIn, captureRow(record)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("erro ao importar captura: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return s.rebuildContacts()
+}
+
+// ListCaptures lê todas as capturas (ordem de registro)
+func (s *SQLiteStore) ListCaptures() ([]crawler.CaptureRecord, error) {
+	_, rows, err := s.queryRows("captures", captureHeader, "ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+
+	index := headerIndex(captureHeader)
+	captures := make([]crawler.CaptureRecord, 0, len(rows))
+	for _, row := range rows {
+		if capture, ok := parseCaptureRow(index, row); ok {
+			captures = append(captures, capture)
+		}
+	}
+	return captures, nil
+}
+
+// DeleteCaptures remove as capturas para as quais match retorna true
+func (s *SQLiteStore) DeleteCaptures(match func(record crawler.CaptureRecord) bool) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, rows, err := s.queryRows("captures", captureHeader, "ORDER BY id")
+	if err != nil {
+		return 0, err
+	}
+	index := headerIndex(captureHeader)
+	var remove []interface{}
+	for i, row := range rows {
+		if capture, ok := parseCaptureRow(index, row); ok && match(capture) {
+			remove = append(remove, ids[i])
+		}
+	}
+	return len(remove), s.deleteRows("DELETE FROM captures WHERE id = ?", remove)
+}
+
+// rebuildContacts refaz a tabela de contatos a partir de todas as capturas
+func (s *SQLiteStore) rebuildContacts() error {
+	captures, err := s.ListCaptures()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM contacts`); err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, contact := range buildContacts(captures) {
+		if err := saveContact(tx, contact); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// saveContact grava (ou substitui) o contato
+func saveContact(ex execer, contact *ContactRecord) error {
+	data, err := json.Marshal(contact)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar contato: %v", err)
+	}
+	_, err = ex.Exec(`INSERT OR REPLACE INTO contacts (key, first_seen, last_seen, data) VALUES (?, ?, ?, ?)`,
+		contact.Key, contact.FirstSeen.Unix(), contact.LastSeen.Unix(), string(data))
+	if err != nil {
+		return fmt.Errorf("erro ao gravar contato: %v", err)
+	}
+	return nil
+}
+
+// UpsertContact soma a captura ao contato do perfil
+func (s *SQLiteStore) UpsertContact(capture crawler.CaptureRecord) error {
+	key := profileKey(capture.LinkedIn)
+	if key == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	contact := &ContactRecord{Key: key}
+	var data string
+	err := s.db.QueryRow(`SELECT data FROM contacts WHERE key = ?`, key).Scan(&data)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("erro ao consultar contato: %v", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal([]byte(data), contact); err != nil {
+			return fmt.Errorf("erro ao ler contato: %v", err)
+		}
+	}
+	contact.merge(capture)
+	return saveContact(s.db, contact)
+}
+
+// ListContacts lista os contatos com a situação do convite mais recente
+func (s *SQLiteStore) ListContacts(q ContactQuery) ([]ContactRecord, int, error) {
+	rows, err := s.db.Query(`SELECT data FROM contacts ORDER BY first_seen`)
+	if err != nil {
+		return nil, 0, fmt.Errorf("erro ao consultar contatos: %v", err)
+	}
+	var all []ContactRecord
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			rows.Close()
+			return nil, 0, fmt.Errorf("erro ao ler contatos: %v", err)
+		}
+		var contact ContactRecord
+		if json.Unmarshal([]byte(data), &contact) == nil {
+			all = append(all, contact)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	invites, err := s.InvitesByURL("")
+	if err != nil {
+		return nil, 0, err
+	}
+	contacts, total := queryContacts(all, invites, q)
+	return contacts, total, nil
+}
+
+// DeleteContacts remove os contatos para os quais match retorna true
+func (s *SQLiteStore) DeleteContacts(match func(contact ContactRecord) bool) (int, error) {
+	contacts, _, err := s.ListContacts(ContactQuery{})
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var remove []interface{}
+	for _, contact := range contacts {
+		if match(contact) {
+			remove = append(remove, contact.Key)
+		}
+	}
+	return len(remove), s.deleteRows("DELETE FROM contacts WHERE key = ?", remove)
+}
+
+// SaveRun grava (ou substitui) a execução
+func (s *SQLiteStore) SaveRun(run RunRecord) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar execução: %v", err)
+	}
+	_, err = s.db.Exec(`INSERT OR REPLACE INTO runs (id, account, started_at, data) VALUES (?, ?, ?, ?)`,
+		run.ID, run.Account, run.StartedAt.Unix(), string(data))
+	if err != nil {
+		return fmt.Errorf("erro ao gravar execução: %v", err)
+	}
+	return nil
+}
+
+// ImportRuns grava várias execuções em uma única transação (migração)
+func (s *SQLiteStore) ImportRuns(runs []RunRecord) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		data, err := json.Marshal(run)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("erro ao serializar execução: %v", err)
+		}
+		_, err = tx.Exec(`INSERT OR REPLACE INTO runs (id, account, started_at, data) VALUES (?, ?, ?, ?)`,
+			run.ID, run.Account, run.StartedAt.Unix(), string(data))
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("erro ao importar execução: %v", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ListRuns retorna as últimas n execuções (n <= 0 = todas), da mais recente para a mais antiga
+func (s *SQLiteStore) ListRuns(n int) ([]RunRecord, error) {
+	if n <= 0 {
+		n = -1 // sem limite
+	}
+	rows, err := s.db.Query(`SELECT data FROM runs ORDER BY started_at DESC, rowid DESC LIMIT ?`, n)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar execuções: %v", err)
+	}
+	defer rows.Close()
+
+	runs := []RunRecord{}
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("erro ao ler execuções: %v", err)
+		}
+		var run RunRecord
+		if json.Unmarshal([]byte(data), &run) == nil {
+			runs = append(runs, run)
+		}
+	}
+	return runs, rows.Err()
+}
+
+// GetRun busca a execução pelo ID
+func (s *SQLiteStore) GetRun(id string) (RunRecord, bool, error) {
+	var data string
+	err := s.db.QueryRow(`SELECT data FROM runs WHERE id = ?`, id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return RunRecord{}, false, nil
+	}
+	if err != nil {
+		return RunRecord{}, false, fmt.Errorf("erro ao consultar execução: %v", err)
+	}
+	var run RunRecord
+	if err := json.Unmarshal([]byte(data), &run); err != nil {
+		return RunRecord{}, false, fmt.Errorf("erro ao interpretar execução: %v", err)
+	}
+	return run, true, nil
+}
+
+// DeleteRuns remove as execuções que atendem ao critério
+func (s *SQLiteStore) DeleteRuns(match func(run RunRecord) bool) (int, error) {
+	runs, err := s.ListRuns(0)
+	if err != nil {
+		return 0, err
+	}
+	var remove []interface{}
+	for _, run := range runs {
+		if match(run) {
+			remove = append(remove, run.ID)
+		}
+	}
+	return len(remove), s.deleteRows("DELETE FROM runs WHERE id = ?", remove)
+}
+
+// UpdateRuns altera as execuções em uma transação; update devolve true quando mudou a execução
+func (s *SQLiteStore) UpdateRuns(update func(run *RunRecord) bool) (int, error) {
+	runs, err := s.ListRuns(0)
+	if err != nil {
+		return 0, err
+	}
+	var changed []RunRecord
+	for i := range runs {
+		if update(&runs[i]) {
+			changed = append(changed, runs[i])
+		}
+	}
+	if len(changed) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	for _, run := range changed {
+		data, err := json.Marshal(run)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("erro ao serializar execução: %v", err)
+		}
+		if _, err := tx.Exec(`UPDATE runs SET data = ? WHERE id = ?`, string(data), run.ID); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("erro ao gravar execução: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	_, err = s.db.Exec(`PRAGMA wal_checkpoint(TRUNCATE)`)
+	return len(changed), err
+}
+
+// Close fecha o banco
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
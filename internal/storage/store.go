@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+// Backends de armazenamento (variável STORAGE_BACKEND)
+const (
+	BackendCSV    = "csv"    // data/invites.csv, data/captures.csv e data/runs.jsonl (padrão)
+	BackendSQLite = "sqlite" // banco único em SQLITE_PATH (padrão data/crawler.db)
+)
+
+// DefaultSQLitePath banco usado quando SQLITE_PATH não está definido
+var DefaultSQLitePath = filepath.Join("data", "crawler.db")
+
+// Store persistência de convites, contatos capturados e execuções
+type Store interface {
+	// Convites (ordem de envio)
+	AppendInvite(record crawler.InviteRecord) error
+	ListInvites(page, size int) ([]crawler.InviteRecord, int, error)
+	SearchInvites(q InviteQuery) ([]crawler.InviteRecord, int, error)
+	GetTotalCount() (int, error)
+	CountInvites(account string, from, to time.Time) (int, error)
+	InvitedURLs() (map[string]bool, error)
+	InvitesByURL(account string) (map[string]crawler.InviteRecord, error)
+	UpdateInvites(update func(record *crawler.InviteRecord) bool) (int, error)
+	MarkWithdrawn(account, profileURL string, at time.Time) (bool, error)
+	DeleteInvites(match func(record crawler.InviteRecord) bool) (int, error)
+
+	// Contatos capturados (ordem de captura) e consolidados por perfil
+	AppendCapture(record crawler.CaptureRecord) error
+	ListCaptures() ([]crawler.CaptureRecord, error)
+	DeleteCaptures(match func(record crawler.CaptureRecord) bool) (int, error)
+	ContactStore
+
+	// Execuções do crawler
+	SaveRun(run RunRecord) error
+	ListRuns(n int) ([]RunRecord, error)
+	GetRun(id string) (RunRecord, bool, error)
+	DeleteRuns(match func(run RunRecord) bool) (int, error)
+	UpdateRuns(update func(run *RunRecord) bool) (int, error)
+
+	Close() error
+}
+
+// CSVStore implementação em arquivos (formato original, compatível com as
+// planilhas existentes)
+type CSVStore struct {
+	*InviteStorage
+	captures *CaptureLog
+	contacts *ContactLog
+	runs     *RunLog
+}
+
+// NewCSVStore abre os arquivos em data/
+func NewCSVStore() (*CSVStore, error) {
+	captures := NewCaptureLog()
+	contacts, err := NewContactLog(captures)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar contatos: %v", err)
+	}
+	return &CSVStore{
+		InviteStorage: NewInviteStorage(),
+		captures:      captures,
+		contacts:      contacts,
+		runs:          NewRunLog(),
+	}, nil
+}
+
+// AppendCapture adiciona uma captura a data/captures.csv
+func (s *CSVStore) AppendCapture(record crawler.CaptureRecord) error {
+	return s.captures.Append(record)
+}
+
+// ListCaptures lê todas as capturas
+func (s *CSVStore) ListCaptures() ([]crawler.CaptureRecord, error) {
+	return s.captures.List()
+}
+
+// DeleteCaptures remove capturas de data/captures.csv
+func (s *CSVStore) DeleteCaptures(match func(record crawler.CaptureRecord) bool) (int, error) {
+	return s.captures.Delete(match)
+}
+
+// UpsertContact soma a captura ao contato do perfil (data/contacts.jsonl)
+func (s *CSVStore) UpsertContact(capture crawler.CaptureRecord) error {
+	return s.contacts.Upsert(capture)
+}
+
+// ListContacts lista os contatos com a situação do convite mais recente
+func (s *CSVStore) ListContacts(q ContactQuery) ([]ContactRecord, int, error) {
+	invites, err := s.InvitesByURL("")
+	if err != nil {
+		return nil, 0, err
+	}
+	all, err := s.contacts.List()
+	if err != nil {
+		return nil, 0, err
+	}
+	contacts, total := queryContacts(all, invites, q)
+	return contacts, total, nil
+}
+
+// DeleteContacts remove contatos de data/contacts.jsonl
+func (s *CSVStore) DeleteContacts(match func(contact ContactRecord) bool) (int, error) {
+	return s.contacts.Delete(match)
+}
+
+// SaveRun registra a execução em data/runs.jsonl
+func (s *CSVStore) SaveRun(run RunRecord) error {
+	return s.runs.Save(run)
+}
+
+// ListRuns retorna as últimas n execuções
+func (s *CSVStore) ListRuns(n int) ([]RunRecord, error) {
+	return s.runs.List(n)
+}
+
+// GetRun busca a execução em data/runs.jsonl
+func (s *CSVStore) GetRun(id string) (RunRecord, bool, error) {
+	return s.runs.Get(id)
+}
+
+// DeleteRuns remove execuções de data/runs.jsonl
+func (s *CSVStore) DeleteRuns(match func(run RunRecord) bool) (int, error) {
+	return s.runs.Delete(match)
+}
+
+// UpdateRuns altera execuções de data/runs.jsonl
+func (s *CSVStore) UpdateRuns(update func(run *RunRecord) bool) (int, error) {
+	return s.runs.Update(update)
+}
+
+// Close nada a liberar (os arquivos são abertos a cada operação)
+func (s *CSVStore) Close() error {
+	return nil
+}
+
+// Open abre o backend indicado (csv ou sqlite; vazio = csv). path é o
+// arquivo do banco SQLite (vazio = DefaultSQLitePath).
+func Open(backend, path string) (Store, error) {
+	switch strings.ToLower(strings.TrimSpace(backend)) {
+	case "", BackendCSV:
+		return NewCSVStore()
+	case BackendSQLite:
+		if path == "" {
+			path = DefaultSQLitePath
+		}
+		return NewSQLiteStore(path)
+	}
+	return nil, fmt.Errorf("backend de armazenamento desconhecido: %s (use %s ou %s)", backend, BackendCSV, BackendSQLite)
+}
+
+// OpenFromEnv abre o backend configurado em STORAGE_BACKEND e SQLITE_PATH
+func OpenFromEnv() (Store, error) {
+	return Open(os.Getenv("STORAGE_BACKEND"), os.Getenv("SQLITE_PATH"))
+}
@@ -0,0 +1,292 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+// TestStoreContract executa os mesmos cenários nos dois backends pela interface Store
+func TestStoreContract(t *testing.T) {
+	backends := []struct {
+		name string
+		open func(t *testing.T) Store
+	}{
+		{"csv", func(t *testing.T) Store {
+			s, err := NewCSVStore()
+			if err != nil {
+				t.Fatal(err)
+			}
+			return s
+		}},
+		{"sqlite", func(t *testing.T) Store {
+			s, err := NewSQLiteStore(filepath.Join("data", "crawler.db"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return s
+		}},
+	}
+
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			chdirTemp(t)
+			store := b.open(t)
+			defer store.Close()
+
+			t.Run("convites", func(t *testing.T) { testStoreInvites(t, store) })
+			t.Run("capturas e contatos", func(t *testing.T) { testStoreContacts(t, store) })
+			t.Run("execuções", func(t *testing.T) { testStoreRuns(t, store) })
+		})
+	}
+}
+
+func testStoreInvites(t *testing.T, store Store) {
+	base := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	invites := []crawler.InviteRecord{
+		{Timestamp: base, UserEmail: "vendas@empresa.com", ProfileName: "Ana Souza", Company: "Acme",
+			LinkedInURL: "https://www.linkedin.com/in/ana-souza/", Query: "cto", Status: crawler.InviteStatusPending, Score: 7.5},
+		{Timestamp: base.Add(time.Hour), UserEmail: "Vendas@Empresa.com", ProfileName: "Bruno Lima", Company: "Beta",
+			LinkedInURL: "https://www.linkedin.com/in/bruno-lima", Query: "cto", Status: crawler.InviteStatusPending},
+		{Timestamp: base.Add(24 * time.Hour), UserEmail: "rh@empresa.com", ProfileName: "Ana Souza", Company: "Acme",
+			LinkedInURL: "https://www.linkedin.com/in/Ana-Souza", Query: "rh", Status: crawler.InviteStatusPending},
+	}
+	for _, invite := range invites {
+		if err := store.AppendInvite(invite); err != nil {
+			t.Fatalf("AppendInvite = %v", err)
+		}
+	}
+
+	if n, err := store.GetTotalCount(); err != nil || n != 3 {
+		t.Errorf("GetTotalCount = %d, %v; esperado 3", n, err)
+	}
+	page, total, err := store.ListInvites(1, 2)
+	if err != nil || total != 3 || len(page) != 1 || page[0].UserEmail != "rh@empresa.com" {
+		t.Errorf("ListInvites(1, 2) = %v, %d, %v; esperado só o terceiro convite de 3", page, total, err)
+	}
+	if got := page[0]; !got.Timestamp.Equal(invites[2].Timestamp) || got.ProfileName != "Ana Souza" {
+		t.Errorf("convite lido = %+v, esperado %+v", got, invites[2])
+	}
+
+	counts := []struct {
+		account  string
+		from, to time.Time
+		want     int
+	}{
+		{"vendas@empresa.com", base, base.Add(2 * time.Hour), 2},
+		{"VENDAS@empresa.com", base.Add(time.Hour), base.Add(2 * time.Hour), 1},
+		{"vendas@empresa.com", base, base.Add(time.Hour), 1}, // to é exclusivo
+		{"rh@empresa.com", base, base.Add(48 * time.Hour), 1},
+		{"outra@empresa.com", base, base.Add(48 * time.Hour), 0},
+	}
+	for _, c := range counts {
+		if n, err := store.CountInvites(c.account, c.from, c.to); err != nil || n != c.want {
+			t.Errorf("CountInvites(%s, %s, %s) = %d, %v; esperado %d", c.account, c.from.Format(time.Kitchen), c.to.Format(time.Kitchen), n, err, c.want)
+		}
+	}
+
+	urls, err := store.InvitedURLs()
+	if err != nil || len(urls) != 2 || !urls["https://www.linkedin.com/in/ana-souza"] {
+		t.Errorf("InvitedURLs = %v, %v; esperado ana-souza e bruno-lima normalizados", urls, err)
+	}
+	byURL, err := store.InvitesByURL("")
+	if err != nil || byURL["https://www.linkedin.com/in/ana-souza"].UserEmail != "rh@empresa.com" {
+		t.Errorf("InvitesByURL(\"\") = %v, %v; esperado o convite mais recente da ana-souza (rh)", byURL, err)
+	}
+	byURL, err = store.InvitesByURL("vendas@empresa.com")
+	if err != nil || len(byURL) != 2 || byURL["https://www.linkedin.com/in/ana-souza"].UserEmail != "vendas@empresa.com" {
+		t.Errorf("InvitesByURL(vendas) = %v, %v; esperado os 2 convites da conta", byURL, err)
+	}
+
+	found, total, err := store.SearchInvites(InviteQuery{Account: "vendas@empresa.com", Company: "acm"})
+	if err != nil || total != 1 || found[0].ProfileName != "Ana Souza" {
+		t.Errorf("SearchInvites(vendas, acm) = %v, %d, %v; esperado Ana Souza", found, total, err)
+	}
+
+	changed, err := store.UpdateInvites(func(r *crawler.InviteRecord) bool {
+		if r.Query != "cto" {
+			return false
+		}
+		r.Status = crawler.InviteStatusAccepted
+		return true
+	})
+	if err != nil || changed != 2 {
+		t.Errorf("UpdateInvites = %d, %v; esperado 2", changed, err)
+	}
+	if _, total, _ := store.SearchInvites(InviteQuery{Status: crawler.InviteStatusAccepted}); total != 2 {
+		t.Errorf("%d convites aceitos após UpdateInvites, esperado 2", total)
+	}
+
+	at := base.Add(72 * time.Hour)
+	if ok, err := store.MarkWithdrawn("rh@empresa.com", "https://www.linkedin.com/in/Ana-Souza/", at); err != nil || !ok {
+		t.Errorf("MarkWithdrawn = %v, %v; esperado true", ok, err)
+	}
+	if ok, _ := store.MarkWithdrawn("rh@empresa.com", "https://www.linkedin.com/in/ninguem", at); ok {
+		t.Error("MarkWithdrawn de perfil sem convite = true, esperado false")
+	}
+	withdrawn, _, _ := store.SearchInvites(InviteQuery{Status: crawler.InviteStatusWithdrawn})
+	if len(withdrawn) != 1 || withdrawn[0].UserEmail != "rh@empresa.com" || !withdrawn[0].WithdrawnAt.Equal(at) {
+		t.Errorf("convites retirados = %+v, esperado o convite da conta rh", withdrawn)
+	}
+
+	removed, err := store.DeleteInvites(func(r crawler.InviteRecord) bool { return r.ProfileName == "Bruno Lima" })
+	if err != nil || removed != 1 {
+		t.Errorf("DeleteInvites = %d, %v; esperado 1", removed, err)
+	}
+	if n, _ := store.GetTotalCount(); n != 2 {
+		t.Errorf("GetTotalCount após DeleteInvites = %d, esperado 2", n)
+	}
+}
+
+func testStoreContacts(t *testing.T, store Store) {
+	base := time.Date(2026, 10, 13, 9, 0, 0, 0, time.UTC)
+	captures := []crawler.CaptureRecord{
+		{Timestamp: base, UserEmail: "vendas@empresa.com", RunID: "r1", Contact: crawler.Contact{
+			Name: "Ana Souza", Title: "CTO", Company: "Acme", LinkedIn: "https://www.linkedin.com/in/ana-souza/", Query: "cto"}},
+		{Timestamp: base.Add(time.Hour), UserEmail: "rh@empresa.com", RunID: "r2", Contact: crawler.Contact{
+			Name: "Ana Souza", Title: "CEO", Company: "Acme", LinkedIn: "https://www.linkedin.com/in/ana-souza", Query: "rh"}},
+		{Timestamp: base.Add(2 * time.Hour), UserEmail: "vendas@empresa.com", RunID: "r2", Contact: crawler.Contact{
+			Name: "Carla Dias", Title: "CFO", Company: "Gama", LinkedIn: "https://www.linkedin.com/in/carla-dias", Query: "cfo"}},
+	}
+	for _, capture := range captures {
+		if err := store.AppendCapture(capture); err != nil {
+			t.Fatalf("AppendCapture = %v", err)
+		}
+		if err := store.UpsertContact(capture); err != nil {
+			t.Fatalf("UpsertContact = %v", err)
+		}
+	}
+
+	listed, err := store.ListCaptures()
+	if err != nil || len(listed) != 3 || listed[2].Name != "Carla Dias" || listed[1].RunID != "r2" {
+		t.Errorf("ListCaptures = %+v, %v; esperado as 3 capturas em ordem", listed, err)
+	}
+
+	contacts, total, err := store.ListContacts(ContactQuery{})
+	if err != nil || total != 2 {
+		t.Fatalf("ListContacts = %d contatos, %v; esperado 2", total, err)
+	}
+	// Mais recente primeiro; a ana-souza ainda tem o convite retirado da conta rh
+	if contacts[0].Key != "https://www.linkedin.com/in/carla-dias" || contacts[1].Key != "https://www.linkedin.com/in/ana-souza" {
+		t.Fatalf("ListContacts = %s, %s; esperado carla-dias, ana-souza", contacts[0].Key, contacts[1].Key)
+	}
+	ana := contacts[1]
+	if ana.Captures != 2 || ana.Contact.Title != "CEO" || len(ana.Accounts) != 2 || len(ana.Runs) != 2 ||
+		!ana.FirstSeen.Equal(base) || !ana.LastSeen.Equal(base.Add(time.Hour)) || ana.InviteStatus != crawler.InviteStatusWithdrawn {
+		t.Errorf("contato ana-souza = %+v", ana)
+	}
+	if _, total, _ := store.ListContacts(ContactQuery{Status: ContactStatusNone}); total != 1 {
+		t.Errorf("ListContacts(sem convite) = %d, esperado 1", total)
+	}
+	if found, total, _ := store.ListContacts(ContactQuery{Search: "gama"}); total != 1 || found[0].Contact.Name != "Carla Dias" {
+		t.Errorf("ListContacts(gama) = %+v, esperado Carla Dias", found)
+	}
+
+	removed, err := store.DeleteContacts(func(c ContactRecord) bool { return c.Contact.Name == "Carla Dias" })
+	if err != nil || removed != 1 {
+		t.Errorf("DeleteContacts = %d, %v; esperado 1", removed, err)
+	}
+	removed, err = store.DeleteCaptures(func(r crawler.CaptureRecord) bool { return r.Name == "Carla Dias" })
+	if err != nil || removed != 1 {
+		t.Errorf("DeleteCaptures = %d, %v; esperado 1", removed, err)
+	}
+	if _, total, _ := store.ListContacts(ContactQuery{}); total != 1 {
+		t.Errorf("ListContacts após remoção = %d, esperado 1", total)
+	}
+	if listed, _ := store.ListCaptures(); len(listed) != 2 {
+		t.Errorf("ListCaptures após remoção = %d, esperado 2", len(listed))
+	}
+}
+
+func testStoreRuns(t *testing.T, store Store) {
+	base := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	runs := []RunRecord{
+		{ID: "r1", Account: "vendas@empresa.com", Status: "running", StartedAt: base},
+		{ID: "r2", Account: "rh@empresa.com", Status: "done", StartedAt: base.Add(time.Hour), Captured: 4, Invites: 2,
+			Config:  &crawler.RunConfig{Mode: "search"},
+			Queries: []crawler.QueryStats{{Query: "rh", Captured: 4}}},
+		// a mesma execução gravada de novo substitui a anterior
+		{ID: "r1", Account: "vendas@empresa.com", Status: "failed", Error: "sem login", StartedAt: base, FinishedAt: base.Add(time.Minute)},
+	}
+	for _, run := range runs {
+		if err := store.SaveRun(run); err != nil {
+			t.Fatalf("SaveRun = %v", err)
+		}
+	}
+
+	listed, err := store.ListRuns(0)
+	if err != nil || len(listed) != 2 || listed[0].ID != "r2" || listed[1].ID != "r1" {
+		t.Fatalf("ListRuns(0) = %+v, %v; esperado r2, r1", listed, err)
+	}
+	if listed[1].Status != "failed" || listed[1].Error != "sem login" {
+		t.Errorf("r1 = %+v, esperado a última versão gravada", listed[1])
+	}
+	if latest, _ := store.ListRuns(1); len(latest) != 1 || latest[0].ID != "r2" {
+		t.Errorf("ListRuns(1) = %+v, esperado só r2", latest)
+	}
+
+	run, ok, err := store.GetRun("r2")
+	if err != nil || !ok || run.Config == nil || run.Config.Mode != "search" || len(run.Queries) != 1 || run.Invites != 2 {
+		t.Errorf("GetRun(r2) = %+v, %v, %v", run, ok, err)
+	}
+	if _, ok, _ := store.GetRun("r9"); ok {
+		t.Error("GetRun(r9) encontrou execução inexistente")
+	}
+
+	changed, err := store.UpdateRuns(func(run *RunRecord) bool {
+		if run.Account != "rh@empresa.com" {
+			return false
+		}
+		run.Account = ""
+		return true
+	})
+	if err != nil || changed != 1 {
+		t.Errorf("UpdateRuns = %d, %v; esperado 1", changed, err)
+	}
+	if run, _, _ := store.GetRun("r2"); run.Account != "" {
+		t.Errorf("r2 após UpdateRuns = %+v, esperado conta vazia", run)
+	}
+
+	removed, err := store.DeleteRuns(func(run RunRecord) bool { return run.ID == "r1" })
+	if err != nil || removed != 1 {
+		t.Errorf("DeleteRuns = %d, %v; esperado 1", removed, err)
+	}
+	if listed, _ := store.ListRuns(0); len(listed) != 1 || listed[0].ID != "r2" {
+		t.Errorf("ListRuns após DeleteRuns = %+v, esperado só r2", listed)
+	}
+}
+
+func TestSQLiteImportRuns(t *testing.T) {
+	chdirTemp(t)
+	runLog := NewRunLogAt(filepath.Join("data", "runs.jsonl"))
+	base := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	for _, run := range []RunRecord{
+		{ID: "r1", Status: "running", StartedAt: base},
+		{ID: "r2", Status: "done", StartedAt: base.Add(time.Hour), Captured: 3},
+		{ID: "r1", Status: "done", StartedAt: base, Invites: 1},
+	} {
+		if err := runLog.Save(run); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runs, err := runLog.List(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := NewSQLiteStore(filepath.Join("data", "crawler.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.ImportRuns(runs); err != nil {
+		t.Fatalf("ImportRuns = %v", err)
+	}
+
+	imported, err := db.ListRuns(0)
+	if err != nil || len(imported) != 2 || imported[0].ID != "r2" || imported[1].Status != "done" || imported[1].Invites != 1 {
+		t.Errorf("ListRuns após ImportRuns = %+v, %v; esperado r2 e a última versão de r1", imported, err)
+	}
+}
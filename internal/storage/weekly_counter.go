@@ -2,58 +2,198 @@ package storage
 
 import (
 	"fmt"
+	"math"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
 )
 
-// WeeklyCounter gerencia contadores semanais de convites
+// WeeklyCounter gerencia contadores de convites por conta. Os convites são
+// carregados uma única vez (na criação) e cada novo convite é somado com Add,
+// então "convites da semana" não relê o armazenamento. A janela (semana de
+// calendário ou últimos 7 dias), o fuso e os limites vêm da configuração de
+// cada conta (data/limits.json).
+//
+// Os convites ficam agregados em blocos de 15 minutos (UTC): todo fuso tem
+// deslocamento múltiplo de 15 minutos, então o início do dia e da semana de
+// qualquer conta cai na borda de um bloco, e mudar o fuso da conta não exige
+// reagrupar. Blocos mais antigos que counterRetention são descartados.
 type WeeklyCounter struct {
-	storage *InviteStorage
+	mu       sync.RWMutex
+	sent     map[string]map[int64]int // conta (minúsculas) → bloco → convites
+	limits   *Limits
+	loadedAt time.Time // instante da leitura do armazenamento (convites posteriores só entram por Add)
 }
 
-// NewWeeklyCounter cria nova instância do contador
-func NewWeeklyCounter(storage *InviteStorage) *WeeklyCounter {
-	return &WeeklyCounter{
-		storage: storage,
+const (
+	// counterBucket tamanho do bloco de contagem
+	counterBucket = 15 * time.Minute
+	// counterRetention maior janela consultada (7 dias, a semana de calendário
+	// nunca é maior) com margem de um dia para diferenças de fuso
+	counterRetention = 8 * 24 * time.Hour
+)
+
+// InviteUsage uso dos limites de uma conta no momento da consulta
+type InviteUsage struct {
+	Limits      AccountLimits
+	Week        int       // convites na janela semanal
+	Today       int       // convites hoje (fuso da conta)
+	WindowStart time.Time // início da janela semanal
+}
+
+// Remaining convites que ainda cabem nos limites semanal e diário
+func (u InviteUsage) Remaining() int {
+	remaining := u.Limits.Weekly - u.Week
+	if u.Limits.Daily > 0 && u.Limits.Daily-u.Today < remaining {
+		remaining = u.Limits.Daily - u.Today
+	}
+	if remaining < 0 {
+		return 0
 	}
+	return remaining
 }
 
-// CountThisWeek conta convites da semana atual para um usuário
-func (wc *WeeklyCounter) CountThisWeek(userEmail string) (int, error) {
-	// Obter início da semana (segunda-feira)
-	now := time.Now()
-	weekStart := getWeekStart(now)
-	weekEnd := weekStart.AddDate(0, 0, 7)
+// CanSend indica se a conta ainda pode enviar convites
+func (u InviteUsage) CanSend() bool {
+	return u.Remaining() > 0
+}
+
+// Reason motivo do bloqueio ("" se a conta ainda pode enviar)
+func (u InviteUsage) Reason() string {
+	switch {
+	case u.Week >= u.Limits.Weekly:
+		return fmt.Sprintf("limite semanal atingido (%d/%d)", u.Week, u.Limits.Weekly)
+	case u.Limits.Daily > 0 && u.Today >= u.Limits.Daily:
+		return fmt.Sprintf("limite diário atingido (%d/%d)", u.Today, u.Limits.Daily)
+	}
+	return ""
+}
+
+// Percentage uso da janela semanal em porcentagem
+func (u InviteUsage) Percentage() float64 {
+	return float64(u.Week) / float64(u.Limits.Weekly) * 100
+}
 
-	// Listar todos os convites
-	invites, _, err := wc.storage.ListInvites(0, 10000) // Buscar todos
+// NewWeeklyCounter cria o contador a partir dos convites já registrados
+// (limits nil = 200/semana na semana de calendário do fuso do servidor)
+func NewWeeklyCounter(storage Store, limits *Limits) (*WeeklyCounter, error) {
+	if limits == nil {
+		limits = &Limits{state: limitsState{Default: AccountLimits{Weekly: DefaultWeeklyLimit, Window: WindowCalendarWeek}}}
+	}
+	wc := &WeeklyCounter{sent: map[string]map[int64]int{}, limits: limits, loadedAt: time.Now()}
+
+	invites, _, err := storage.ListInvites(0, math.MaxInt32)
 	if err != nil {
-		return 0, fmt.Errorf("erro ao listar convites: %v", err)
+		return nil, fmt.Errorf("erro ao carregar convites: %v", err)
+	}
+	for _, invite := range invites {
+		wc.add(invite.UserEmail, invite.Timestamp, wc.loadedAt)
+	}
+	return wc, nil
+}
+
+// Limits configuração de limites usada pelo contador
+func (wc *WeeklyCounter) Limits() *Limits {
+	return wc.limits
+}
+
+// Add soma um convite recém-registrado ao contador
+func (wc *WeeklyCounter) Add(invite crawler.InviteRecord) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	wc.add(invite.UserEmail, invite.Timestamp, time.Now())
+}
+
+// add soma o convite ao bloco do seu horário e descarta os blocos da conta
+// anteriores à retenção (wc.mu deve estar travado ou o contador ainda não
+// compartilhado)
+func (wc *WeeklyCounter) add(account string, at, now time.Time) {
+	oldest := bucketOf(now.Add(-counterRetention))
+	if bucketOf(at) < oldest {
+		return
 	}
 
-	// Contar convites da semana
+	key := strings.ToLower(account)
+	buckets := wc.sent[key]
+	if buckets == nil {
+		buckets = map[int64]int{}
+		wc.sent[key] = buckets
+	}
+	buckets[bucketOf(at)]++
+
+	for bucket := range buckets {
+		if bucket < oldest {
+			delete(buckets, bucket)
+		}
+	}
+}
+
+// bucketOf bloco de contagem do instante
+func bucketOf(t time.Time) int64 {
+	return t.Unix() / int64(counterBucket/time.Second)
+}
+
+// countSince convites da conta a partir do bloco de start (wc.mu deve estar
+// travado); na janela móvel o bloco parcial do início conta inteiro, o que
+// só erra para o lado seguro
+func (wc *WeeklyCounter) countSince(key string, start time.Time) int {
+	first := bucketOf(start)
 	count := 0
-	for _, invite := range invites {
-		if invite.UserEmail == userEmail &&
-			invite.Timestamp.After(weekStart) &&
-			invite.Timestamp.Before(weekEnd) {
-			count++
+	for bucket, n := range wc.sent[key] {
+		if bucket >= first {
+			count += n
 		}
 	}
+	return count
+}
 
-	return count, nil
+// Usage calcula o uso dos limites da conta agora
+func (wc *WeeklyCounter) Usage(userEmail string) InviteUsage {
+	return wc.usageAt(userEmail, time.Now())
 }
 
-// CanSendInvite verifica se usuário pode enviar convite (limite 200/semana)
-func (wc *WeeklyCounter) CanSendInvite(userEmail string) (bool, int, error) {
-	count, err := wc.CountThisWeek(userEmail)
-	if err != nil {
-		return false, 0, err
+// usageAt calcula o uso dos limites da conta no instante informado
+func (wc *WeeklyCounter) usageAt(userEmail string, now time.Time) InviteUsage {
+	limits := wc.limits.Get(userEmail)
+	weekStart, today := windowStarts(limits, now)
+	usage := InviteUsage{Limits: limits, WindowStart: weekStart}
+
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+
+	key := strings.ToLower(userEmail)
+	usage.Week = wc.countSince(key, usage.WindowStart)
+	usage.Today = wc.countSince(key, today)
+	return usage
+}
+
+// windowStarts início da janela semanal e do dia atual no fuso da conta (a
+// janela semanal nunca começa depois do dia atual)
+func windowStarts(limits AccountLimits, now time.Time) (week, day time.Time) {
+	now = now.In(limits.Location())
+	day = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if limits.Rolling() {
+		return now.AddDate(0, 0, -7), day
 	}
+	return getWeekStart(now), day
+}
+
+// CountThisWeek conta convites da janela semanal configurada para um usuário
+func (wc *WeeklyCounter) CountThisWeek(userEmail string) (int, error) {
+	return wc.Usage(userEmail).Week, nil
+}
 
-	return count < 200, count, nil
+// CanSendInvite verifica se usuário pode enviar convite (limites semanal e
+// diário da conta); retorna também os convites da janela semanal
+func (wc *WeeklyCounter) CanSendInvite(userEmail string) (bool, int, error) {
+	usage := wc.Usage(userEmail)
+	return usage.CanSend(), usage.Week, nil
 }
 
-// getWeekStart retorna o início da semana (segunda-feira)
+// getWeekStart retorna o início da semana (segunda-feira 00:00 no fuso de t)
 func getWeekStart(t time.Time) time.Time {
 	weekday := int(t.Weekday())
 	if weekday == 0 { // Domingo
@@ -69,20 +209,17 @@ func getWeekStart(t time.Time) time.Time {
 
 // GetWeeklyStats retorna estatísticas da semana para um usuário
 func (wc *WeeklyCounter) GetWeeklyStats(userEmail string) (map[string]interface{}, error) {
-	count, err := wc.CountThisWeek(userEmail)
-	if err != nil {
-		return nil, err
-	}
-
-	limit := 200
-	remaining := limit - count
-	percentage := float64(count) / float64(limit) * 100
+	usage := wc.Usage(userEmail)
 
 	return map[string]interface{}{
-		"count":      count,
-		"limit":      limit,
-		"remaining":  remaining,
-		"percentage": percentage,
-		"can_send":   count < limit,
+		"count":       usage.Week,
+		"limit":       usage.Limits.Weekly,
+		"today":       usage.Today,
+		"daily_limit": usage.Limits.Daily,
+		"window":      usage.Limits.Window,
+		"timezone":    usage.Limits.Location().String(),
+		"remaining":   usage.Remaining(),
+		"percentage":  usage.Percentage(),
+		"can_send":    usage.CanSend(),
 	}, nil
 }
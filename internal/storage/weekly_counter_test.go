@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+)
+
+func TestWeeklyCounterUsage(t *testing.T) {
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+	// quarta-feira 10:00 em Kolkata (UTC+5:30)
+	now := time.Date(2026, 10, 14, 10, 0, 0, 0, kolkata)
+
+	tests := []struct {
+		name   string
+		limits AccountLimits
+		sent   []time.Time
+		week   int
+		today  int
+	}{
+		{
+			"semana de calendário no fuso da conta",
+			AccountLimits{Weekly: 100, Window: WindowCalendarWeek, Timezone: "Asia/Kolkata"},
+			[]time.Time{
+				time.Date(2026, 10, 11, 23, 59, 0, 0, kolkata), // domingo anterior
+				time.Date(2026, 10, 12, 0, 0, 0, 0, kolkata),   // segunda 00:00
+				time.Date(2026, 10, 13, 23, 50, 0, 0, kolkata),
+				time.Date(2026, 10, 14, 0, 10, 0, 0, kolkata),
+				time.Date(2026, 10, 14, 9, 0, 0, 0, kolkata),
+			},
+			4, 2,
+		},
+		{
+			"janela móvel de 7 dias",
+			AccountLimits{Weekly: 100, Window: WindowRolling7d, Timezone: "Asia/Kolkata"},
+			[]time.Time{
+				now.AddDate(0, 0, -8),
+				now.AddDate(0, 0, -7).Add(-time.Hour),
+				now.AddDate(0, 0, -6),
+				now.Add(-time.Hour),
+			},
+			2, 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wc := &WeeklyCounter{
+				sent:   map[string]map[int64]int{},
+				limits: &Limits{state: limitsState{Default: tt.limits}},
+			}
+			for _, at := range tt.sent {
+				wc.add("Conta@Exemplo.com", at, now)
+			}
+
+			usage := wc.usageAt("conta@exemplo.com", now)
+			if usage.Week != tt.week || usage.Today != tt.today {
+				t.Errorf("usageAt = (semana %d, hoje %d), esperado (semana %d, hoje %d)",
+					usage.Week, usage.Today, tt.week, tt.today)
+			}
+		})
+	}
+}
+
+func TestWeeklyCounterPrunesOldBuckets(t *testing.T) {
+	wc := &WeeklyCounter{sent: map[string]map[int64]int{}, limits: &Limits{}}
+	now := time.Now()
+
+	for days := 0; days < 60; days++ {
+		wc.add("conta@exemplo.com", now.AddDate(0, 0, -days), now.AddDate(0, 0, -days))
+	}
+	wc.Add(crawler.InviteRecord{UserEmail: "conta@exemplo.com", Timestamp: now})
+
+	oldest := bucketOf(now.Add(-counterRetention))
+	for bucket := range wc.sent["conta@exemplo.com"] {
+		if bucket < oldest {
+			t.Errorf("bloco %d anterior à retenção (%d) não foi descartado", bucket, oldest)
+		}
+	}
+	if got := len(wc.sent["conta@exemplo.com"]); got > 9 {
+		t.Errorf("%d blocos retidos, esperado no máximo 9", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
 )
 
 // SSEEvent representa um evento SSE
@@ -82,19 +83,29 @@ func (b *SSEBroker) PublishEvent(event SSEEvent) {
 	}
 }
 
-// PublishMetrics publica métricas de contadores
-func (b *SSEBroker) PublishMetrics(capturedSession, invitesWeek int) {
+// PublishMetrics publica métricas de contadores e limites da conta
+func (b *SSEBroker) PublishMetrics(capturedSession int, usage storage.InviteUsage) {
 	event := SSEEvent{
 		Type: "metrics",
-		Data: map[string]interface{}{
-			"captured_session": capturedSession,
-			"invites_week":     invitesWeek,
-			"invites_limit":    200,
-		},
+		Data: MetricsData(capturedSession, usage),
 	}
 	b.PublishEvent(event)
 }
 
+// MetricsData corpo do evento metrics (também devolvido por GET /metrics)
+func MetricsData(capturedSession int, usage storage.InviteUsage) map[string]interface{} {
+	return map[string]interface{}{
+		"captured_session": capturedSession,
+		"invites_week":     usage.Week,
+		"invites_limit":    usage.Limits.Weekly,
+		"invites_today":    usage.Today,
+		"daily_limit":      usage.Limits.Daily,
+		"window":           usage.Limits.WindowTitle(),
+		"can_send":         usage.CanSend(),
+		"limit_reason":     usage.Reason(),
+	}
+}
+
 // PublishInvite publica evento de convite enviado
 func (b *SSEBroker) PublishInvite(invite crawler.InviteRecord) {
 	event := SSEEvent{
@@ -106,8 +117,11 @@ func (b *SSEBroker) PublishInvite(invite crawler.InviteRecord) {
 			"title":        invite.ProfileTitle,
 			"company":      invite.Company,
 			"location":     invite.Location,
+			"region":       invite.Region,
+			"country":      invite.Country,
 			"linkedin_url": invite.LinkedInURL,
 			"query":        invite.Query,
+			"source":       invite.Source,
 		},
 	}
 	b.PublishEvent(event)
@@ -136,6 +150,15 @@ func (b *SSEBroker) PublishError(message string) {
 	b.PublishEvent(event)
 }
 
+// PublishJobs avisa os clientes que o painel de execuções mudou
+func (b *SSEBroker) PublishJobs() {
+	event := SSEEvent{
+		Type: "jobs",
+		Data: map[string]interface{}{},
+	}
+	b.PublishEvent(event)
+}
+
 // FormatSSEMessage formata mensagem SSE
 func FormatSSEMessage(event SSEEvent) string {
 	data, err := json.Marshal(event)
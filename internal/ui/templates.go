@@ -5,14 +5,32 @@ import (
 	"html/template"
 	"strings"
 
+	"github.com/your-org/linkedin-visible-crawler/internal/analytics"
 	"github.com/your-org/linkedin-visible-crawler/internal/crawler"
+	"github.com/your-org/linkedin-visible-crawler/internal/orchestrator"
+	"github.com/your-org/linkedin-visible-crawler/internal/privacy"
+	"github.com/your-org/linkedin-visible-crawler/internal/scheduler"
+	"github.com/your-org/linkedin-visible-crawler/internal/sequences"
+	"github.com/your-org/linkedin-visible-crawler/internal/storage"
+	"github.com/your-org/linkedin-visible-crawler/internal/webhooks"
 )
 
 // Templates contém todos os templates HTML
 type Templates struct {
-	home     *template.Template
-	invites  *template.Template
-	partials map[string]*template.Template
+	home      *template.Template
+	invites   *template.Template
+	contacts  *template.Template
+	jobs      *template.Template
+	schedules *template.Template
+	sequences *template.Template
+	analytics *template.Template
+	scoring   *template.Template
+	webhooks  *template.Template
+	privacy   *template.Template
+	limits    *template.Template
+	runs      *template.Template
+	runReport *template.Template
+	partials  map[string]*template.Template
 }
 
 // NewTemplates cria nova instância dos templates
@@ -27,6 +45,37 @@ func NewTemplates() *Templates {
 	// Template de convites
 	tmpl.invites = template.Must(template.New("invites").Parse(invitesTemplate))
 
+	// Tabela de contatos capturados
+	tmpl.contacts = template.Must(template.New("contacts").Parse(contactsTemplate))
+
+	// Template do painel de execuções
+	tmpl.jobs = template.Must(template.New("jobs").Parse(jobsTemplate))
+
+	// Template de agendamentos
+	tmpl.schedules = template.Must(template.New("schedules").Parse(schedulesTemplate))
+
+	// Template de sequências de follow-up
+	tmpl.sequences = template.Must(template.New("sequences").Parse(sequencesTemplate))
+
+	// Página de analytics do funil
+	tmpl.analytics = template.Must(template.New("analytics").Parse(analyticsTemplate))
+
+	// Editor do modelo de pontuação de leads
+	tmpl.scoring = template.Must(template.New("scoring").Parse(scoringTemplate))
+
+	// Webhooks de eventos
+	tmpl.webhooks = template.Must(template.New("webhooks").Parse(webhooksTemplate))
+
+	// Privacidade (pedidos de acesso, remoção e retenção)
+	tmpl.privacy = template.Must(template.New("privacy").Parse(privacyTemplate))
+
+	// Limites de convites por conta
+	tmpl.limits = template.Must(template.New("limits").Parse(limitsTemplate))
+
+	// Histórico de execuções e relatório de cada execução
+	tmpl.runs = template.Must(template.New("runs").Parse(runsTemplate))
+	tmpl.runReport = template.Must(template.New("run-report").Parse(runReportTemplate))
+
 	// Partials
 	tmpl.partials["invites-table"] = template.Must(template.New("invites-table").Parse(invitesTablePartial))
 	tmpl.partials["progress-bar"] = template.Must(template.New("progress-bar").Parse(progressBarPartial))
@@ -43,14 +92,28 @@ func (t *Templates) RenderHome(data map[string]interface{}) (string, error) {
 	return buf.String(), nil
 }
 
-// RenderInvites renderiza a tabela de convites
-func (t *Templates) RenderInvites(invites []crawler.InviteRecord, total int, page int, pageSize int) (string, error) {
+// RenderInvites renderiza uma página da tabela de convites. filter são os
+// filtros aplicados (query string), repetidos nos links de exportação.
+func (t *Templates) RenderInvites(invites []crawler.InviteRecord, total int, q storage.InviteQuery, filter string) (string, error) {
+	first, last := 0, q.Page*q.Size+len(invites)
+	if len(invites) > 0 {
+		first = q.Page*q.Size + 1
+	}
+	if filter != "" {
+		filter = "?" + filter
+	}
 	data := map[string]interface{}{
-		"Invites":    invites,
-		"Total":      total,
-		"Page":       page,
-		"PageSize":   pageSize,
-		"TotalPages": (total + pageSize - 1) / pageSize,
+		"Invites":  invites,
+		"Total":    total,
+		"Page":     q.Page,
+		"First":    first,
+		"Last":     last,
+		"Prev":     q.Page - 1,
+		"Next":     q.Page + 1,
+		"HasPrev":  q.Page > 0,
+		"HasNext":  last < total,
+		"Filter":   template.URL(filter),
+		"Filtered": q.Account != "" || q.Query != "" || q.Company != "" || q.Location != "" || q.Status != "" || q.Search != "" || !q.From.IsZero() || !q.To.IsZero(),
 	}
 
 	var buf strings.Builder
@@ -60,6 +123,207 @@ func (t *Templates) RenderInvites(invites []crawler.InviteRecord, total int, pag
 	return buf.String(), nil
 }
 
+// RenderContacts renderiza uma página da tabela de contatos capturados
+func (t *Templates) RenderContacts(contacts []storage.ContactRecord, total int, q storage.ContactQuery) (string, error) {
+	first, last := 0, q.Page*q.Size+len(contacts)
+	if len(contacts) > 0 {
+		first = q.Page*q.Size + 1
+	}
+	data := map[string]interface{}{
+		"Contacts": contacts,
+		"Total":    total,
+		"First":    first,
+		"Last":     last,
+		"Prev":     q.Page - 1,
+		"Next":     q.Page + 1,
+		"HasPrev":  q.Page > 0,
+		"HasNext":  last < total,
+		"Filtered": q.Search != "" || q.Status != "",
+	}
+
+	var buf strings.Builder
+	if err := t.contacts.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderJobs renderiza o painel de execuções do orquestrador
+func (t *Templates) RenderJobs(snapshot orchestrator.Snapshot) (string, error) {
+	var buf strings.Builder
+	if err := t.jobs.Execute(&buf, snapshot); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderSchedules renderiza formulário e tabela de agendamentos
+func (t *Templates) RenderSchedules(schedules []scheduler.Schedule, querySets []string, errMsg string) (string, error) {
+	data := map[string]interface{}{
+		"Schedules": schedules,
+		"QuerySets": querySets,
+		"Error":     errMsg,
+	}
+
+	var buf strings.Builder
+	if err := t.schedules.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// sequenceRow sequência com a contagem de inscrições por situação
+type sequenceRow struct {
+	sequences.Sequence
+	InProgress int
+	Replied    int
+	Finished   int
+}
+
+// RenderSequences renderiza formulário, sequências e últimas mensagens
+func (t *Templates) RenderSequences(seqs []sequences.Sequence, enrollments []sequences.Enrollment, messages []crawler.MessageRecord, errMsg string) (string, error) {
+	rows := make([]sequenceRow, 0, len(seqs))
+	names := make(map[string]string, len(seqs))
+	for _, seq := range seqs {
+		row := sequenceRow{Sequence: seq}
+		for _, en := range enrollments {
+			if en.SequenceID != seq.ID {
+				continue
+			}
+			switch en.StopReason {
+			case sequences.StopReplied:
+				row.Replied++
+			case sequences.StopFinished:
+				row.Finished++
+			default:
+				row.InProgress++
+			}
+		}
+		rows = append(rows, row)
+		names[seq.ID] = seq.Name
+	}
+
+	data := map[string]interface{}{
+		"Sequences":     rows,
+		"Messages":      messages,
+		"SequenceNames": names,
+		"Error":         errMsg,
+	}
+
+	var buf strings.Builder
+	if err := t.sequences.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderAnalytics renderiza a página do funil com os filtros de data aplicados
+func (t *Templates) RenderAnalytics(report analytics.Report, from, to string) (string, error) {
+	data := map[string]interface{}{
+		"Report": report,
+		"From":   from,
+		"To":     to,
+	}
+
+	var buf strings.Builder
+	if err := t.analytics.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderScoring renderiza o editor do modelo de pontuação
+func (t *Templates) RenderScoring(model string, active bool, errMsg string) (string, error) {
+	data := map[string]interface{}{
+		"Model":  model,
+		"Active": active,
+		"Error":  errMsg,
+	}
+
+	var buf strings.Builder
+	if err := t.scoring.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderWebhooks renderiza formulário, inscrições e últimas entregas
+func (t *Templates) RenderWebhooks(subs []webhooks.Subscription, deliveries []webhooks.Delivery, created *webhooks.Subscription, errMsg string) (string, error) {
+	data := map[string]interface{}{
+		"Subscriptions": subs,
+		"Deliveries":    deliveries,
+		"Events":        webhooks.Events,
+		"Created":       created,
+		"Error":         errMsg,
+	}
+
+	var buf strings.Builder
+	if err := t.webhooks.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderPrivacy renderiza o painel de privacidade com os últimos registros de auditoria
+func (t *Templates) RenderPrivacy(audit []privacy.AuditRecord, retentionDays int, msg, errMsg string) (string, error) {
+	data := map[string]interface{}{
+		"Audit":     audit,
+		"Retention": retentionDays,
+		"Message":   msg,
+		"Error":     errMsg,
+	}
+
+	var buf strings.Builder
+	if err := t.privacy.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderLimits renderiza formulário, limites (padrão primeiro) e uso atual de cada conta
+func (t *Templates) RenderLimits(usages []storage.InviteUsage, errMsg string) (string, error) {
+	data := map[string]interface{}{
+		"Usages":  usages,
+		"Windows": storage.LimitWindows,
+		"Error":   errMsg,
+	}
+
+	var buf strings.Builder
+	if err := t.limits.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderRuns renderiza o histórico de execuções (account = filtro aplicado)
+func (t *Templates) RenderRuns(runs []storage.RunRecord, account string) (string, error) {
+	data := map[string]interface{}{
+		"Runs":    runs,
+		"Account": account,
+	}
+
+	var buf strings.Builder
+	if err := t.runs.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderRunReport renderiza a página do relatório de uma execução
+func (t *Templates) RenderRunReport(run storage.RunRecord, totals crawler.QueryStats) (string, error) {
+	data := map[string]interface{}{
+		"Run":      run,
+		"Totals":   totals,
+		"Outcomes": crawler.AttemptOutcomes,
+	}
+
+	var buf strings.Builder
+	if err := t.runReport.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 // RenderPartial renderiza um partial específico
 func (t *Templates) RenderPartial(name string, data interface{}) (string, error) {
 	partial, exists := t.partials[name]
@@ -105,6 +369,7 @@ const homeTemplate = `<!DOCTYPE html>
                     <h1 class="text-xl font-bold">LinkedIn Visible Crawler</h1>
                 </div>
                 <div class="flex items-center space-x-4">
+                    <a href="/analytics" class="text-sm hover:underline">📈 Analytics</a>
                     <span class="text-sm opacity-75">Crawler de perfis visíveis</span>
                 </div>
             </div>
@@ -144,7 +409,7 @@ const homeTemplate = `<!DOCTYPE html>
 
             <!-- Card 2: Queries -->
             <div class="bg-white rounded-lg shadow-md p-6">
-                <h2 class="text-lg font-semibold text-gray-900 mb-4">📝 Queries (.txt)</h2>
+                <h2 class="text-lg font-semibold text-gray-900 mb-4">📝 Queries ou Perfis (.txt)</h2>
                 
                 <!-- Upload de arquivo -->
                 <form hx-post="/upload/queries" hx-encoding="multipart/form-data" hx-target="#queries-status" hx-swap="innerHTML" class="mb-4">
@@ -154,6 +419,10 @@ const homeTemplate = `<!DOCTYPE html>
                             <input type="file" name="queries_file" accept=".txt" required
                                    class="mt-1 block w-full text-sm text-gray-500 file:mr-4 file:py-2 file:px-4 file:rounded-md file:border-0 file:text-sm file:font-semibold file:bg-linkedin file:text-white hover:file:bg-blue-700">
                         </div>
+                        <div>
+                            <input type="text" name="query_set_name" placeholder="Salvar como conjunto (opcional)"
+                                   class="block w-full rounded-md border-gray-300 shadow-sm text-sm focus:border-linkedin focus:ring-linkedin">
+                        </div>
                         <button type="submit" 
                                 class="w-full bg-green-600 text-white py-2 px-4 rounded-md hover:bg-green-700 focus:outline-none focus:ring-2 focus:ring-green-500 focus:ring-offset-2">
                             Enviar arquivo
@@ -163,10 +432,12 @@ const homeTemplate = `<!DOCTYPE html>
 
                 <!-- Ou colar texto -->
                 <div class="border-t pt-4">
-                    <label class="block text-sm font-medium text-gray-700 mb-2">Ou cole as queries (uma por linha)</label>
+                    <label class="block text-sm font-medium text-gray-700 mb-2">Ou cole as queries (uma por linha: palavras-chave ou URL de busca do LinkedIn)</label>
                     <form hx-post="/upload/queries-text" hx-target="#queries-status" hx-swap="innerHTML">
-                        <textarea name="queries_text" rows="4" placeholder="grupo boticário vendas&#10;startup tecnologia&#10;consultor financeiro"
+                        <textarea name="queries_text" rows="4" placeholder="grupo boticário vendas&#10;startup tecnologia&#10;https://www.linkedin.com/search/results/people/?keywords=cfo&amp;network=%5B%22S%22%5D"
                                   class="block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin"></textarea>
+                        <input type="text" name="query_set_name" placeholder="Salvar como conjunto (opcional)"
+                               class="mt-2 block w-full rounded-md border-gray-300 shadow-sm text-sm focus:border-linkedin focus:ring-linkedin">
                         <button type="submit" 
                                 class="mt-2 w-full bg-blue-600 text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-blue-500 focus:ring-offset-2">
                             Usar texto
@@ -185,9 +456,27 @@ const homeTemplate = `<!DOCTYPE html>
                 
                 <form hx-post="/run" hx-target="#execution-status" hx-swap="innerHTML">
                     <div class="space-y-4">
+                        <div>
+                            <label class="block text-sm font-medium text-gray-700">Modo</label>
+                            <select name="mode"
+                                    class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                                <option value="search">Busca (queries)</option>
+                                <option value="profiles">Lista de perfis (URLs /in/)</option>
+                                <option value="company">Empresa (aba Pessoas)</option>
+                            </select>
+                        </div>
+                        <div class="space-y-2 border rounded-md p-3 bg-gray-50">
+                            <p class="text-xs text-gray-500">Somente no modo Empresa</p>
+                            <input type="text" name="company" placeholder="URL ou slug da empresa (ex.: grupoboticario)"
+                                   class="block w-full rounded-md border-gray-300 shadow-sm text-sm focus:border-linkedin focus:ring-linkedin">
+                            <input type="text" name="company_keywords" placeholder="Cargo/palavra-chave (ex.: vendas)"
+                                   class="block w-full rounded-md border-gray-300 shadow-sm text-sm focus:border-linkedin focus:ring-linkedin">
+                            <input type="text" name="company_location" placeholder="Localização (ex.: São Paulo)"
+                                   class="block w-full rounded-md border-gray-300 shadow-sm text-sm focus:border-linkedin focus:ring-linkedin">
+                        </div>
                         <div>
                             <label class="block text-sm font-medium text-gray-700">Max Cards por página</label>
-                            <input type="number" name="max_cards" value="60" min="1" max="100"
+                            <input type="number" name="max_cards" value="60" min="1" max="1000"
                                    class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
                         </div>
                         <div>
@@ -195,6 +484,16 @@ const homeTemplate = `<!DOCTYPE html>
                             <input type="number" name="max_connects" value="10" min="1" max="10"
                                    class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
                         </div>
+                        <div>
+                            <label class="block text-sm font-medium text-gray-700">Max Convites na execução (0 = sem limite)</label>
+                            <input type="number" name="max_invites" value="0" min="0"
+                                   class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                        </div>
+                        <div>
+                            <label class="block text-sm font-medium text-gray-700">Páginas por query</label>
+                            <input type="number" name="max_pages" value="1" min="1" max="100"
+                                   class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                        </div>
                         
                         <!-- Opção de modo headless -->
                         <div class="flex items-center">
@@ -235,10 +534,11 @@ const homeTemplate = `<!DOCTYPE html>
                     <div class="text-sm text-gray-600">Convites esta semana</div>
                 </div>
                 
-                <!-- Limite semanal -->
+                <!-- Limite semanal (configurável por conta) -->
                 <div class="text-center">
-                    <div class="text-2xl font-bold text-gray-600">200</div>
+                    <div class="text-2xl font-bold text-gray-600" id="invites-limit">{{.Limits.Weekly}}</div>
                     <div class="text-sm text-gray-600">Limite semanal</div>
+                    <div class="text-xs text-gray-500" id="invites-window">{{.Limits.WindowTitle}}{{if .Limits.Daily}} · {{.Limits.Daily}}/dia{{end}}</div>
                 </div>
             </div>
 
@@ -246,7 +546,7 @@ const homeTemplate = `<!DOCTYPE html>
             <div class="mb-6">
                 <div class="flex justify-between text-sm text-gray-600 mb-2">
                     <span>Progresso semanal</span>
-                    <span id="progress-text">0 / 200</span>
+                    <span id="progress-text">0 / {{.Limits.Weekly}}</span>
                 </div>
                 <div class="w-full bg-gray-200 rounded-full h-2.5">
                     <div id="progress-bar" class="bg-green-600 h-2.5 rounded-full transition-all duration-300" style="width: 0%"></div>
@@ -259,20 +559,315 @@ const homeTemplate = `<!DOCTYPE html>
             </div>
         </div>
 
+        <!-- Painel de Execuções (todas as contas) -->
+        <div class="mt-8 bg-white rounded-lg shadow-md p-6">
+            <h2 class="text-lg font-semibold text-gray-900 mb-4">🧵 Execuções</h2>
+
+            <div id="jobs-table" hx-get="/jobs" hx-trigger="load, every 5s">
+                <!-- Painel será carregado via HTMX -->
+            </div>
+        </div>
+
+        <!-- Histórico de execuções (relatórios) -->
+        <div class="mt-8 bg-white rounded-lg shadow-md p-6">
+            <h2 class="text-lg font-semibold text-gray-900 mb-4">📜 Histórico de Execuções</h2>
+
+            <div id="runs-panel" hx-get="/runs" hx-trigger="load">
+                <!-- Painel será carregado via HTMX -->
+            </div>
+        </div>
+
+        <!-- Agendamentos -->
+        <div class="mt-8 bg-white rounded-lg shadow-md p-6">
+            <h2 class="text-lg font-semibold text-gray-900 mb-4">⏰ Agendamentos</h2>
+
+            <div id="schedules-panel" hx-get="/schedules" hx-trigger="load">
+                <!-- Painel será carregado via HTMX -->
+            </div>
+        </div>
+
+        <!-- Sequências de follow-up pós-aceitação -->
+        <div class="mt-8 bg-white rounded-lg shadow-md p-6">
+            <h2 class="text-lg font-semibold text-gray-900 mb-4">✉️ Follow-up Pós-Aceitação</h2>
+
+            <form hx-post="/sequences/run" hx-target="#followup-status" hx-swap="innerHTML"
+                  class="flex flex-wrap items-end gap-4 mb-4">
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Máx. mensagens na execução</label>
+                    <input type="number" name="max_messages" value="20" min="1" max="100"
+                           class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                </div>
+                <label class="flex items-center text-sm text-gray-700">
+                    <input type="checkbox" name="headless_mode" class="h-4 w-4 text-linkedin focus:ring-linkedin border-gray-300 rounded mr-2">
+                    Modo Headless
+                </label>
+                <button type="submit"
+                        class="bg-linkedin text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-linkedin focus:ring-offset-2">
+                    Executar follow-ups agora
+                </button>
+            </form>
+            <div id="followup-status" class="mb-4">
+                <!-- Status será atualizado via HTMX -->
+            </div>
+
+            <div id="sequences-panel" hx-get="/sequences" hx-trigger="load">
+                <!-- Painel será carregado via HTMX -->
+            </div>
+        </div>
+
+        <!-- Pontuação de leads -->
+        <div class="mt-8 bg-white rounded-lg shadow-md p-6">
+            <h2 class="text-lg font-semibold text-gray-900 mb-4">🎯 Pontuação de Leads</h2>
+
+            <div id="scoring-panel" hx-get="/scoring" hx-trigger="load">
+                <!-- Painel será carregado via HTMX -->
+            </div>
+        </div>
+
+        <!-- Limites de convites por conta -->
+        <div class="mt-8 bg-white rounded-lg shadow-md p-6">
+            <h2 class="text-lg font-semibold text-gray-900 mb-4">🚦 Limites por Conta</h2>
+
+            <div id="limits-panel" hx-get="/limits" hx-trigger="load">
+                <!-- Painel será carregado via HTMX -->
+            </div>
+        </div>
+
+        <!-- Webhooks de eventos -->
+        <div class="mt-8 bg-white rounded-lg shadow-md p-6">
+            <h2 class="text-lg font-semibold text-gray-900 mb-4">🔔 Webhooks</h2>
+
+            <div id="webhooks-panel" hx-get="/webhooks" hx-trigger="load">
+                <!-- Painel será carregado via HTMX -->
+            </div>
+        </div>
+
+        <!-- Privacidade: pedidos de acesso e remoção -->
+        <div class="mt-8 bg-white rounded-lg shadow-md p-6">
+            <h2 class="text-lg font-semibold text-gray-900 mb-4">🛡️ Privacidade</h2>
+
+            <div id="privacy-panel" hx-get="/privacy" hx-trigger="load">
+                <!-- Painel será carregado via HTMX -->
+            </div>
+        </div>
+
+        <!-- Manutenção: retirar convites pendentes antigos -->
+        <div class="mt-8 bg-white rounded-lg shadow-md p-6">
+            <h2 class="text-lg font-semibold text-gray-900 mb-4">🧹 Manutenção de Convites</h2>
+
+            <form hx-post="/maintenance/sync" hx-target="#maintenance-status" hx-swap="innerHTML"
+                  class="flex flex-wrap items-end gap-4 mb-6 pb-6 border-b">
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Conexões recentes a ler</label>
+                    <input type="number" name="max_connections" value="200" min="10" max="2000"
+                           class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                </div>
+                <label class="flex items-center text-sm text-gray-700">
+                    <input type="checkbox" name="headless_mode" class="h-4 w-4 text-linkedin focus:ring-linkedin border-gray-300 rounded mr-2">
+                    Modo Headless
+                </label>
+                <button type="submit"
+                        class="bg-linkedin text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-linkedin focus:ring-offset-2">
+                    🔄 Sincronizar status (aceitos/expirados)
+                </button>
+            </form>
+
+            <h3 class="text-md font-semibold text-gray-900 mb-2">Retirar convites pendentes</h3>
+
+            <form hx-post="/maintenance/withdraw" hx-target="#maintenance-status" hx-swap="innerHTML"
+                  hx-confirm="Retirar os convites pendentes que atendem aos filtros?"
+                  class="grid grid-cols-1 md:grid-cols-5 gap-4">
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Mais antigos que</label>
+                    <input type="text" name="older_than" value="21d" placeholder="21d, 3w"
+                           class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Query (opcional)</label>
+                    <input type="text" name="query"
+                           class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Empresa (opcional)</label>
+                    <input type="text" name="company"
+                           class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Máximo (0 = sem limite)</label>
+                    <input type="number" name="max_withdrawals" value="0" min="0"
+                           class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                </div>
+                <div class="flex flex-col justify-end space-y-2">
+                    <label class="flex items-center text-sm text-gray-700">
+                        <input type="checkbox" name="headless_mode" class="h-4 w-4 text-linkedin focus:ring-linkedin border-gray-300 rounded mr-2">
+                        Modo Headless
+                    </label>
+                    <button type="submit"
+                            class="w-full bg-yellow-600 text-white py-2 px-4 rounded-md hover:bg-yellow-700 focus:outline-none focus:ring-2 focus:ring-yellow-500 focus:ring-offset-2">
+                        Retirar convites
+                    </button>
+                </div>
+            </form>
+            <p class="mt-2 text-xs text-gray-500">Filtros de query/empresa consideram apenas convites enviados pelo crawler. Retiradas ficam registradas no CSV de convites.</p>
+
+            <div id="maintenance-status" class="mt-4">
+                <!-- Status será atualizado via HTMX -->
+            </div>
+        </div>
+
         <!-- Tabela de Convites -->
         <div class="mt-8 bg-white rounded-lg shadow-md p-6">
             <div class="flex justify-between items-center mb-4">
                 <h2 class="text-lg font-semibold text-gray-900">📋 Convites Enviados</h2>
-                <a href="/export/invites.csv" 
-                   class="bg-linkedin text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-linkedin focus:ring-offset-2">
-                    Exportar CSV
-                </a>
+                <div id="invites-export" class="flex items-center space-x-2">
+                    <!-- Links de exportação (com os filtros da tabela) carregados via HTMX -->
+                </div>
             </div>
+            <p class="mb-4 text-xs text-gray-500">
+                Contatos capturados (inclusive não convidados):
+                <a href="/export/contacts.csv" class="text-linkedin underline">CSV</a> ·
+                <a href="/export/contacts.xlsx" class="text-linkedin underline">XLSX</a> ·
+                <a href="/export/contacts.json" class="text-linkedin underline">JSON</a> ·
+                <a href="/export/contacts.jsonl" class="text-linkedin underline">JSONL</a> ·
+                <a href="/export/contacts.vcf" class="text-linkedin underline">vCard</a>.
+                Colunas e idioma do cabeçalho: <code>?columns=name,title,company&amp;lang=en</code>
+            </p>
             
+            <form id="invites-filters" hx-get="/invites" hx-target="#invites-table" hx-swap="innerHTML"
+                  hx-trigger="submit, change from:#invites-filters select"
+                  class="grid grid-cols-1 md:grid-cols-4 gap-4 mb-4">
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Nome ou cargo</label>
+                    <input type="text" name="q" placeholder="Buscar"
+                           class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Conta (email)</label>
+                    <input type="text" name="account" placeholder="Todas"
+                           class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Query</label>
+                    <input type="text" name="query" placeholder="Contém"
+                           class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Empresa</label>
+                    <input type="text" name="company" placeholder="Contém"
+                           class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Localização</label>
+                    <input type="text" name="location" placeholder="Contém"
+                           class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                </div>
+                <div class="flex space-x-2">
+                    <div class="w-1/2">
+                        <label class="block text-sm font-medium text-gray-700">De</label>
+                        <input type="date" name="from"
+                               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                    </div>
+                    <div class="w-1/2">
+                        <label class="block text-sm font-medium text-gray-700">Até</label>
+                        <input type="date" name="to"
+                               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                    </div>
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Status</label>
+                    <select name="status"
+                            class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                        <option value="">Todos</option>
+                        <option value="pending">Pendente</option>
+                        <option value="accepted">Aceito</option>
+                        <option value="withdrawn">Retirado</option>
+                        <option value="expired">Expirado</option>
+                    </select>
+                </div>
+                <div class="flex items-end space-x-2">
+                    <select name="sort" title="Ordenar por"
+                            class="block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                        <option value="timestamp">Data/Hora</option>
+                        <option value="user_email">Usuário</option>
+                        <option value="profile_name">Nome</option>
+                        <option value="profile_title">Cargo</option>
+                        <option value="company">Empresa</option>
+                        <option value="location">Localização</option>
+                        <option value="query">Query</option>
+                        <option value="source">Origem</option>
+                        <option value="score">Pontuação</option>
+                        <option value="status">Status</option>
+                    </select>
+                    <select name="order" title="Ordem"
+                            class="block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                        <option value="desc">Decrescente</option>
+                        <option value="asc">Crescente</option>
+                    </select>
+                    <button type="submit"
+                            class="bg-linkedin text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-linkedin focus:ring-offset-2">
+                        Filtrar
+                    </button>
+                </div>
+            </form>
+
             <div id="invites-table" hx-get="/invites" hx-trigger="load">
                 <!-- Tabela será carregada via HTMX -->
             </div>
         </div>
+
+        <!-- Contatos capturados (convidados ou não) -->
+        <div class="mt-8 bg-white rounded-lg shadow-md p-6">
+            <h2 class="text-lg font-semibold text-gray-900 mb-4">👥 Contatos Capturados</h2>
+
+            <form id="contacts-filters" hx-get="/contacts" hx-target="#contacts-table" hx-swap="innerHTML"
+                  hx-trigger="submit, change from:#contacts-filters select"
+                  class="grid grid-cols-1 md:grid-cols-5 gap-4 mb-4">
+                <div class="md:col-span-2">
+                    <label class="block text-sm font-medium text-gray-700">Buscar</label>
+                    <input type="text" name="q" placeholder="Nome, cargo, empresa, localização ou URL"
+                           class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Convite</label>
+                    <select name="status"
+                            class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                        <option value="">Todos</option>
+                        <option value="none">Sem convite</option>
+                        <option value="pending">Pendente</option>
+                        <option value="accepted">Aceito</option>
+                        <option value="withdrawn">Retirado</option>
+                        <option value="expired">Expirado</option>
+                    </select>
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Ordenar por</label>
+                    <select name="sort"
+                            class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                        <option value="last_seen">Última captura</option>
+                        <option value="first_seen">Primeira captura</option>
+                        <option value="name">Nome</option>
+                        <option value="company">Empresa</option>
+                        <option value="score">Pontuação</option>
+                        <option value="captures">Capturas</option>
+                    </select>
+                </div>
+                <div class="flex items-end space-x-2">
+                    <select name="order"
+                            class="block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                        <option value="desc">Decrescente</option>
+                        <option value="asc">Crescente</option>
+                    </select>
+                    <button type="submit"
+                            class="bg-linkedin text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-linkedin focus:ring-offset-2">
+                        Filtrar
+                    </button>
+                </div>
+            </form>
+
+            <div id="contacts-table" hx-get="/contacts" hx-trigger="load">
+                <!-- Tabela será carregada via HTMX -->
+            </div>
+        </div>
     </div>
 
     <!-- SSE para atualizações em tempo real -->
@@ -304,6 +899,9 @@ const homeTemplate = `<!DOCTYPE html>
                     case 'error':
                         addLogLine('❌ ' + data.data.message);
                         break;
+                    case 'jobs':
+                        htmx.ajax('GET', '/jobs', {target: '#jobs-table'});
+                        break;
                     default:
                         console.log('Tipo de evento desconhecido:', data.type);
                 }
@@ -327,8 +925,11 @@ const homeTemplate = `<!DOCTYPE html>
             const progressText = document.getElementById('progress-text');
             const percentage = (data.invites_week / data.invites_limit) * 100;
             
-            progressBar.style.width = percentage + '%';
+            progressBar.style.width = Math.min(percentage, 100) + '%';
             progressText.textContent = data.invites_week + ' / ' + data.invites_limit;
+            document.getElementById('invites-limit').textContent = data.invites_limit;
+            document.getElementById('invites-window').textContent = data.window +
+                (data.daily_limit > 0 ? ' · ' + data.invites_today + '/' + data.daily_limit + ' hoje' : '');
             
             // Mudar cor baseado no limite
             if (percentage >= 90) {
@@ -341,9 +942,10 @@ const homeTemplate = `<!DOCTYPE html>
             
             // Desabilitar botão se limite atingido
             const startButton = document.getElementById('start-crawler');
-            if (data.invites_week >= data.invites_limit) {
+            if (!data.can_send) {
                 startButton.disabled = true;
-                startButton.textContent = 'Limite semanal atingido';
+                startButton.textContent = data.invites_today >= data.daily_limit && data.daily_limit > 0
+                    ? 'Limite diário atingido' : 'Limite semanal atingido';
             } else {
                 startButton.disabled = false;
                 startButton.textContent = 'Iniciar Crawler';
@@ -372,14 +974,19 @@ const homeTemplate = `<!DOCTYPE html>
             }
         }
 
+        // Recarregar tabela de convites mantendo filtros e página atual
+        function refreshInvites() {
+            const params = new URLSearchParams(new FormData(document.getElementById('invites-filters')));
+            const current = document.querySelector('#invites-table [data-page]');
+            if (current) {
+                params.set('page', current.dataset.page);
+            }
+            htmx.ajax('GET', '/invites?' + params.toString(), {target: '#invites-table'});
+        }
+
         // Adicionar convite à tabela
         function addInviteToTable(data) {
-            // Recarregar tabela via HTMX
-            htmx.trigger('#invites-table', 'refresh');
-            // Também forçar atualização manual
-            setTimeout(() => {
-                htmx.ajax('GET', '/invites', {target: '#invites-table'});
-            }, 500);
+            setTimeout(refreshInvites, 500);
         }
 
         // Carregar métricas iniciais
@@ -422,17 +1029,26 @@ const homeTemplate = `<!DOCTYPE html>
             htmx.trigger('#queries-status', 'check-status');
             
             // Atualizar tabela automaticamente a cada 5 segundos
-            setInterval(() => {
-                htmx.ajax('GET', '/invites', {target: '#invites-table'});
-            }, 5000);
+            setInterval(refreshInvites, 5000);
         });
     </script>
 </body>
 </html>`
 
 // Template da tabela de convites
-const invitesTemplate = `{{if .Invites}}
-<div class="overflow-x-auto">
+const invitesTemplate = `<div id="invites-export" hx-swap-oob="true" class="flex items-center space-x-2">
+    {{if .Filtered}}<span class="text-xs text-gray-500">Com os filtros:</span>{{end}}
+    <a href="/export/invites.csv{{.Filter}}"
+       class="bg-linkedin text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-linkedin focus:ring-offset-2">
+        Exportar CSV
+    </a>
+    <a href="/export/invites.xlsx{{.Filter}}" class="text-sm text-linkedin underline">XLSX</a>
+    <a href="/export/invites.json{{.Filter}}" class="text-sm text-linkedin underline">JSON</a>
+    <a href="/export/invites.jsonl{{.Filter}}" class="text-sm text-linkedin underline">JSONL</a>
+    <a href="/export/invites.vcf{{.Filter}}" class="text-sm text-linkedin underline">vCard</a>
+</div>
+{{if .Invites}}
+<div class="overflow-x-auto" data-page="{{.Page}}">
     <table class="min-w-full divide-y divide-gray-200">
         <thead class="bg-gray-50">
             <tr>
@@ -443,6 +1059,9 @@ const invitesTemplate = `{{if .Invites}}
                 <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Empresa</th>
                 <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Localização</th>
                 <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Query</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Origem</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Pontuação</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Status</th>
             </tr>
         </thead>
         <tbody class="bg-white divide-y divide-gray-200">
@@ -455,6 +1074,14 @@ const invitesTemplate = `{{if .Invites}}
                 <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Company}}</td>
                 <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Location}}</td>
                 <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Query}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{if eq .Source "company"}}Empresa{{else if eq .Source "profiles"}}Lista de perfis{{else if eq .Source "search"}}Busca{{end}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900" title="{{range $i, $r := .ScoreRules}}{{if $i}}, {{end}}{{$r}}{{end}}">{{if or .Score .ScoreRules}}{{printf "%.1f" .Score}}{{else}}—{{end}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm">
+                    {{if eq .Status "accepted"}}<span class="text-green-600 font-semibold">Aceito</span> <span class="text-gray-500">{{.AcceptedAt.Format "02/01/2006"}}</span>
+                    {{else if eq .Status "withdrawn"}}<span class="text-gray-600 font-semibold">Retirado</span> <span class="text-gray-500">{{.WithdrawnAt.Format "02/01/2006"}}</span>
+                    {{else if eq .Status "expired"}}<span class="text-red-600 font-semibold">Expirado</span> <span class="text-gray-500">{{.ExpiredAt.Format "02/01/2006"}}</span>
+                    {{else}}<span class="text-yellow-600 font-semibold">Pendente</span>{{end}}
+                </td>
             </tr>
             {{end}}
         </tbody>
@@ -462,28 +1089,30 @@ const invitesTemplate = `{{if .Invites}}
 </div>
 
 <!-- Paginação -->
-{{if gt .TotalPages 1}}
 <div class="mt-4 flex justify-between items-center">
     		<div class="text-sm text-gray-700">
-			Mostrando {{.Page}} a {{.PageSize}} de {{.Total}} resultados
+			Mostrando {{.First}} a {{.Last}} de {{.Total}} resultados
 		</div>
 		<div class="flex space-x-2">
-			{{if gt .Page 0}}
-			<button hx-get="/invites?page={{.Page}}" hx-target="#invites-table" 
+			{{if .HasPrev}}
+			<button hx-get="/invites?page={{.Prev}}" hx-include="#invites-filters" hx-target="#invites-table" 
 					class="px-3 py-2 border border-gray-300 rounded-md text-sm font-medium text-gray-700 bg-white hover:bg-gray-50">
 				Anterior
 			</button>
 			{{end}}
-			{{if lt .Page .TotalPages}}
-			<button hx-get="/invites?page={{.Page}}" hx-target="#invites-table" 
+			{{if .HasNext}}
+			<button hx-get="/invites?page={{.Next}}" hx-include="#invites-filters" hx-target="#invites-table" 
 					class="px-3 py-2 border border-gray-300 rounded-md text-sm font-medium text-gray-700 bg-white hover:bg-gray-50">
 				Próxima
 			</button>
 			{{end}}
 		</div>
 </div>
-{{end}}
 
+{{else if .Filtered}}
+<div class="text-center py-8 text-gray-500">
+    <p>Nenhum convite encontrado com esses filtros.</p>
+</div>
 {{else}}
 <div class="text-center py-8 text-gray-500">
     <p>Nenhum convite enviado ainda.</p>
@@ -491,6 +1120,978 @@ const invitesTemplate = `{{if .Invites}}
 </div>
 {{end}}`
 
+// Template da tabela de contatos capturados (filtros do formulário
+// #contacts-filters são mantidos na paginação)
+const contactsTemplate = `{{if .Contacts}}
+<div class="overflow-x-auto">
+    <table class="min-w-full divide-y divide-gray-200">
+        <thead class="bg-gray-50">
+            <tr>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Nome</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Cargo</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Empresa</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Localização</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Pontuação</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Queries</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Execuções</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Capturas</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Primeira vez</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Última vez</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Convite</th>
+            </tr>
+        </thead>
+        <tbody class="bg-white divide-y divide-gray-200">
+            {{range .Contacts}}
+            <tr>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{if .Contact.LinkedIn}}<a href="{{.Contact.LinkedIn}}" target="_blank" class="text-linkedin hover:underline">{{.Contact.Name}}</a>{{else}}{{.Contact.Name}}{{end}}</td>
+                <td class="px-6 py-4 text-sm text-gray-900">{{.Contact.Title}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Contact.Company}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Contact.Location}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{if or .Contact.Score .Contact.ScoreRules}}{{printf "%.1f" .Contact.Score}}{{else}}—{{end}}</td>
+                <td class="px-6 py-4 text-sm text-gray-900">{{range $i, $q := .Queries}}{{if $i}}, {{end}}{{$q}}{{end}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900" title="{{range $i, $r := .Runs}}{{if $i}}, {{end}}{{$r}}{{end}}">{{len .Runs}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Captures}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.FirstSeen.Format "02/01/2006 15:04"}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.LastSeen.Format "02/01/2006 15:04"}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm">
+                    {{if eq .InviteStatus "accepted"}}<span class="text-green-600 font-semibold">Aceito</span>
+                    {{else if eq .InviteStatus "withdrawn"}}<span class="text-gray-600 font-semibold">Retirado</span>
+                    {{else if eq .InviteStatus "expired"}}<span class="text-red-600 font-semibold">Expirado</span>
+                    {{else if .InviteStatus}}<span class="text-yellow-600 font-semibold">Pendente</span>
+                    {{else}}<span class="text-gray-400">—</span>{{end}}
+                    {{if .InviteStatus}}<span class="text-gray-500">{{.InvitedAt.Format "02/01/2006"}}</span>{{end}}
+                </td>
+            </tr>
+            {{end}}
+        </tbody>
+    </table>
+</div>
+
+<!-- Paginação -->
+<div class="mt-4 flex justify-between items-center">
+    <div class="text-sm text-gray-700">
+        Mostrando {{.First}} a {{.Last}} de {{.Total}} contatos
+    </div>
+    <div class="flex space-x-2">
+        {{if .HasPrev}}
+        <button hx-get="/contacts?page={{.Prev}}" hx-include="#contacts-filters" hx-target="#contacts-table"
+                class="px-3 py-2 border border-gray-300 rounded-md text-sm font-medium text-gray-700 bg-white hover:bg-gray-50">
+            Anterior
+        </button>
+        {{end}}
+        {{if .HasNext}}
+        <button hx-get="/contacts?page={{.Next}}" hx-include="#contacts-filters" hx-target="#contacts-table"
+                class="px-3 py-2 border border-gray-300 rounded-md text-sm font-medium text-gray-700 bg-white hover:bg-gray-50">
+            Próxima
+        </button>
+        {{end}}
+    </div>
+</div>
+
+{{else if .Filtered}}
+<div class="text-center py-8 text-gray-500">
+    <p>Nenhum contato encontrado com esses filtros.</p>
+</div>
+{{else}}
+<div class="text-center py-8 text-gray-500">
+    <p>Nenhum contato capturado ainda.</p>
+    <p class="text-sm">Todos os perfis encontrados pelo crawler aparecem aqui, convidados ou não.</p>
+</div>
+{{end}}`
+
+// Template do painel de execuções
+const jobsTemplate = `<div class="flex space-x-6 text-sm text-gray-600 mb-4">
+    <span>Navegadores ativos: <strong>{{.Running}} / {{.MaxChrome}}</strong></span>
+    <span>Na fila: <strong>{{.Queued}}</strong></span>
+</div>
+{{if .Jobs}}
+<div class="overflow-x-auto">
+    <table class="min-w-full divide-y divide-gray-200">
+        <thead class="bg-gray-50">
+            <tr>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Conta</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Execução</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Status</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Enfileirada</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Início</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Fim</th>
+            </tr>
+        </thead>
+        <tbody class="bg-white divide-y divide-gray-200">
+            {{range .Jobs}}
+            <tr>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Account}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Label}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm">
+                    {{if eq .Status "running"}}<span class="text-blue-600 font-semibold">Em execução</span>
+                    {{else if eq .Status "queued"}}<span class="text-yellow-600 font-semibold">Na fila</span>
+                    {{else if eq .Status "failed"}}<span class="text-red-600 font-semibold" title="{{.Error}}">Falhou</span>
+                    {{else}}<span class="text-green-600 font-semibold">Concluída</span>{{end}}
+                </td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.QueuedAt.Format "02/01 15:04:05"}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{if not .StartedAt.IsZero}}{{.StartedAt.Format "02/01 15:04:05"}}{{end}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{if not .FinishedAt.IsZero}}{{.FinishedAt.Format "02/01 15:04:05"}}{{end}}</td>
+            </tr>
+            {{end}}
+        </tbody>
+    </table>
+</div>
+{{else}}
+<div class="text-center py-8 text-gray-500">
+    <p>Nenhuma execução iniciada.</p>
+</div>
+{{end}}`
+
+// Template de agendamentos (formulário + tabela)
+const schedulesTemplate = `{{if .Error}}
+<div class="text-red-600 bg-red-50 p-3 rounded-md mb-4">{{.Error}}</div>
+{{end}}
+<form hx-post="/schedules" hx-target="#schedules-panel" hx-swap="innerHTML" class="grid grid-cols-1 md:grid-cols-4 gap-4 mb-6">
+    <div>
+        <label class="block text-sm font-medium text-gray-700">Nome</label>
+        <input type="text" name="name" placeholder="Prospecção diária"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+    </div>
+    <div>
+        <label class="block text-sm font-medium text-gray-700">Cron</label>
+        <input type="text" name="cron" required placeholder="0 9 * * 1-5"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+    </div>
+    <div>
+        <label class="block text-sm font-medium text-gray-700">Conta (email)</label>
+        <input type="email" name="account" required
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+    </div>
+    <div>
+        <label class="block text-sm font-medium text-gray-700">Conjunto de queries</label>
+        <select name="query_set" required
+                class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+            {{range .QuerySets}}<option value="{{.}}">{{.}}</option>{{else}}<option value="">Nenhum conjunto salvo</option>{{end}}
+        </select>
+    </div>
+    <div>
+        <label class="block text-sm font-medium text-gray-700">Max Cards por página</label>
+        <input type="number" name="max_cards" value="60" min="1" max="100"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+    </div>
+    <div>
+        <label class="block text-sm font-medium text-gray-700">Max Convites por página</label>
+        <input type="number" name="max_connects" value="3" min="1" max="10"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+    </div>
+    <div class="flex items-end">
+        <label class="flex items-center text-sm text-gray-700">
+            <input type="checkbox" name="headless_mode" class="h-4 w-4 text-linkedin focus:ring-linkedin border-gray-300 rounded mr-2">
+            Modo Headless
+        </label>
+    </div>
+    <div class="flex items-end">
+        <button type="submit"
+                class="w-full bg-linkedin text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-linkedin focus:ring-offset-2">
+            Criar agendamento
+        </button>
+    </div>
+</form>
+<p class="text-xs text-gray-500 mb-4">As credenciais da conta precisam estar ativas em alguma sessão (ou em LINKEDIN_EMAIL/LINKEDIN_PASSWORD). Execuções são puladas quando o limite semanal ou diário da conta foi atingido.</p>
+
+{{if .Schedules}}
+<div class="overflow-x-auto">
+    <table class="min-w-full divide-y divide-gray-200">
+        <thead class="bg-gray-50">
+            <tr>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Nome</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Cron</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Conta</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Queries</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Próxima</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Último resultado</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Ações</th>
+            </tr>
+        </thead>
+        <tbody class="bg-white divide-y divide-gray-200">
+            {{range .Schedules}}
+            <tr>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Name}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm font-mono text-gray-900">{{.Cron}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Account}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.QuerySet}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{if .Paused}}<span class="text-gray-500">Pausado</span>{{else}}{{.NextRunAt.Format "02/01 15:04"}}{{end}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">
+                    {{with .LastResult}}
+                    {{.At.Format "02/01 15:04"}} ·
+                    {{if eq .Outcome "done"}}<span class="text-green-600">Concluída ({{.Invites}} convites)</span>
+                    {{else if eq .Outcome "failed"}}<span class="text-red-600" title="{{.Message}}">Falhou</span>
+                    {{else if eq .Outcome "skipped_limit"}}<span class="text-yellow-600" title="{{.Message}}">Pulada: limite de convites</span>
+                    {{else}}<span class="text-yellow-600" title="{{.Message}}">Pulada: {{.Message}}</span>{{end}}
+                    {{else}}<span class="text-gray-500">—</span>{{end}}
+                </td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm space-x-2">
+                    {{if .Paused}}
+                    <button hx-post="/schedules/{{.ID}}/resume" hx-target="#schedules-panel" class="text-blue-600 hover:text-blue-800 underline">Retomar</button>
+                    {{else}}
+                    <button hx-post="/schedules/{{.ID}}/pause" hx-target="#schedules-panel" class="text-yellow-600 hover:text-yellow-800 underline">Pausar</button>
+                    {{end}}
+                    <button hx-delete="/schedules/{{.ID}}" hx-target="#schedules-panel" hx-confirm="Remover este agendamento?" class="text-red-600 hover:text-red-800 underline">Excluir</button>
+                </td>
+            </tr>
+            {{end}}
+        </tbody>
+    </table>
+</div>
+{{else}}
+<div class="text-center py-8 text-gray-500">
+    <p>Nenhum agendamento criado.</p>
+</div>
+{{end}}`
+
+// Template do editor do modelo de pontuação de leads
+const scoringTemplate = `{{if .Error}}
+<div class="text-red-600 bg-red-50 p-3 rounded-md mb-4">{{.Error}}</div>
+{{else if .Active}}
+<div class="text-green-600 bg-green-50 p-3 rounded-md mb-4">Pontuação ativa: cada página convida primeiro os contatos de maior pontuação.</div>
+{{else}}
+<div class="text-gray-600 bg-gray-50 p-3 rounded-md mb-4">Nenhum modelo salvo: os convites seguem a ordem da página. Abaixo, um exemplo para começar.</div>
+{{end}}
+<form hx-post="/scoring" hx-target="#scoring-panel" hx-swap="innerHTML">
+    <label class="block text-sm font-medium text-gray-700">Regras (JSON)</label>
+    <textarea name="model" rows="14" required
+              class="mt-1 block w-full rounded-md border-gray-300 shadow-sm font-mono text-sm focus:border-linkedin focus:ring-linkedin">{{.Model}}</textarea>
+    <p class="text-xs text-gray-500 mt-1">
+        Tipos: <code>title</code>, <code>company</code> e <code>location</code> (palavras em <code>match</code>),
+        <code>seniority</code> (<code>c-level</code>, <code>vp</code>, <code>director</code>, <code>manager</code>, <code>senior</code>, <code>entry</code>),
+        <code>degree</code> (1, 2 ou 3) e <code>mutual</code> (<code>min_mutual</code>). O peso (<code>weight</code>) pode ser negativo.
+        Contatos abaixo de <code>min_score</code> não são convidados; quando restarem <code>low_budget</code> convites na semana,
+        só contatos com pontuação ≥ <code>low_budget_min_score</code> são convidados.
+    </p>
+    <button type="submit"
+            class="mt-2 bg-linkedin text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-linkedin focus:ring-offset-2">
+        Salvar modelo
+    </button>
+</form>`
+
+// Template de sequências de follow-up (formulário, tabela e últimas mensagens)
+const sequencesTemplate = `{{if .Error}}
+<div class="text-red-600 bg-red-50 p-3 rounded-md mb-4">{{.Error}}</div>
+{{end}}
+<form hx-post="/sequences" hx-target="#sequences-panel" hx-swap="innerHTML" class="grid grid-cols-1 md:grid-cols-3 gap-4 mb-6">
+    <div>
+        <label class="block text-sm font-medium text-gray-700">Nome</label>
+        <input type="text" name="name" required placeholder="Boas-vindas"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+        <label class="block text-sm font-medium text-gray-700 mt-2">Conta (vazio = todas)</label>
+        <input type="email" name="account"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+    </div>
+    <div class="md:col-span-2">
+        <label class="block text-sm font-medium text-gray-700">Etapas (uma por linha: dias após a aceitação | mensagem)</label>
+        <textarea name="steps" rows="4" required placeholder="0 | Olá {{"{{"}}.FirstName{{"}}"}}, obrigado por aceitar o convite!&#10;3 | {{"{{"}}.FirstName{{"}}"}}, posso te mostrar como ajudamos a {{"{{"}}.Company{{"}}"}}?"
+                  class="mt-1 block w-full rounded-md border-gray-300 shadow-sm font-mono text-sm focus:border-linkedin focus:ring-linkedin"></textarea>
+        <p class="text-xs text-gray-500 mt-1">Campos: {{"{{"}}.FirstName{{"}}"}}, {{"{{"}}.LastName{{"}}"}}, {{"{{"}}.Name{{"}}"}}, {{"{{"}}.Title{{"}}"}}, {{"{{"}}.Company{{"}}"}}. Use \n para quebra de linha. A sequência para quando o contato responde.</p>
+        <button type="submit"
+                class="mt-2 bg-linkedin text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-linkedin focus:ring-offset-2">
+            Criar sequência
+        </button>
+    </div>
+</form>
+
+{{if .Sequences}}
+<div class="overflow-x-auto mb-6">
+    <table class="min-w-full divide-y divide-gray-200">
+        <thead class="bg-gray-50">
+            <tr>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Nome</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Conta</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Etapas</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Em andamento</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Responderam</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Concluídos</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Ações</th>
+            </tr>
+        </thead>
+        <tbody class="bg-white divide-y divide-gray-200">
+            {{range .Sequences}}
+            <tr>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Name}}{{if not .Active}} <span class="text-gray-500">(pausada)</span>{{end}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{if .Account}}{{.Account}}{{else}}Todas{{end}}</td>
+                <td class="px-6 py-4 text-sm text-gray-900">{{range $i, $s := .Steps}}<div title="{{$s.Template}}">Dia {{$s.DelayDays}}</div>{{end}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.InProgress}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Replied}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Finished}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm space-x-2">
+                    {{if .Active}}
+                    <button hx-post="/sequences/{{.ID}}/pause" hx-target="#sequences-panel" class="text-yellow-600 hover:text-yellow-800 underline">Pausar</button>
+                    {{else}}
+                    <button hx-post="/sequences/{{.ID}}/resume" hx-target="#sequences-panel" class="text-blue-600 hover:text-blue-800 underline">Retomar</button>
+                    {{end}}
+                    <button hx-delete="/sequences/{{.ID}}" hx-target="#sequences-panel" hx-confirm="Remover esta sequência e suas inscrições?" class="text-red-600 hover:text-red-800 underline">Excluir</button>
+                </td>
+            </tr>
+            {{end}}
+        </tbody>
+    </table>
+</div>
+{{else}}
+<div class="text-center py-8 text-gray-500">
+    <p>Nenhuma sequência criada.</p>
+</div>
+{{end}}
+
+{{if .Messages}}
+<h3 class="text-md font-semibold text-gray-900 mb-2">Últimas mensagens</h3>
+<div class="overflow-x-auto">
+    <table class="min-w-full divide-y divide-gray-200">
+        <thead class="bg-gray-50">
+            <tr>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Data/Hora</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Contato</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Sequência</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Etapa</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Status</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Mensagem</th>
+            </tr>
+        </thead>
+        <tbody class="bg-white divide-y divide-gray-200">
+            {{$names := .SequenceNames}}
+            {{range .Messages}}
+            <tr>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Timestamp.Format "02/01/2006 15:04"}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900"><a href="{{.LinkedInURL}}" target="_blank" class="text-linkedin underline">{{.ProfileName}}</a></td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{index $names .SequenceID}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Step}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm">
+                    {{if eq .Status "sent"}}<span class="text-green-600">Enviada</span>
+                    {{else if eq .Status "replied"}}<span class="text-blue-600">Respondeu</span>
+                    {{else}}<span class="text-red-600" title="{{.Error}}">Falhou</span>{{end}}
+                </td>
+                <td class="px-6 py-4 text-sm text-gray-900 max-w-md truncate" title="{{.Text}}">{{.Text}}</td>
+            </tr>
+            {{end}}
+        </tbody>
+    </table>
+</div>
+{{end}}`
+
+// Template de limites por conta (formulário, padrão e contas configuradas)
+const limitsTemplate = `{{if .Error}}
+<div class="text-red-600 bg-red-50 p-3 rounded-md mb-4">{{.Error}}</div>
+{{end}}
+<form hx-post="/limits" hx-target="#limits-panel" hx-swap="innerHTML" class="grid grid-cols-1 md:grid-cols-3 gap-4 mb-6">
+    <div>
+        <label class="block text-sm font-medium text-gray-700">Conta (vazio = padrão)</label>
+        <input type="email" name="account" placeholder="vendas@empresa.com"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+        <label class="block text-sm font-medium text-gray-700 mt-2">Fuso horário (IANA, vazio = servidor)</label>
+        <input type="text" name="timezone" placeholder="America/Sao_Paulo"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+    </div>
+    <div>
+        <label class="block text-sm font-medium text-gray-700">Convites por semana</label>
+        <input type="number" name="weekly" value="200" min="1" required
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+        <label class="block text-sm font-medium text-gray-700 mt-2">Convites por dia (0 = sem limite)</label>
+        <input type="number" name="daily" value="0" min="0"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+    </div>
+    <div class="flex flex-col justify-end">
+        <label class="block text-sm font-medium text-gray-700">Janela semanal</label>
+        <select name="window" class="mt-1 mb-4 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+            {{range .Windows}}<option value="{{.ID}}">{{.Title}}</option>{{end}}
+        </select>
+        <button type="submit"
+                class="bg-linkedin text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-linkedin focus:ring-offset-2">
+            Salvar limites
+        </button>
+    </div>
+</form>
+
+<div class="overflow-x-auto">
+    <table class="min-w-full divide-y divide-gray-200">
+        <thead class="bg-gray-50">
+            <tr>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Conta</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Semana</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Dia</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Janela</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Fuso</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Ações</th>
+            </tr>
+        </thead>
+        <tbody class="bg-white divide-y divide-gray-200">
+            {{range .Usages}}
+            <tr>
+                {{if .Limits.Account}}
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Limits.Account}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Week}} / {{.Limits.Weekly}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{if .Limits.Daily}}{{.Today}} / {{.Limits.Daily}}{{else}}{{.Today}} <span class="text-gray-500">(sem limite)</span>{{end}}</td>
+                {{else}}
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900 font-semibold">Padrão <span class="font-normal text-gray-500">(demais contas)</span></td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Limits.Weekly}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{if .Limits.Daily}}{{.Limits.Daily}}{{else}}<span class="text-gray-500">sem limite</span>{{end}}</td>
+                {{end}}
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Limits.WindowTitle}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{if .Limits.Timezone}}{{.Limits.Timezone}}{{else}}<span class="text-gray-500">servidor</span>{{end}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm">
+                    {{if .Limits.Account}}
+                    {{if not .CanSend}}<span class="text-red-600 mr-2" title="{{.Reason}}">Bloqueada</span>{{end}}
+                    <button hx-delete="/limits/{{.Limits.Account | urlquery}}" hx-target="#limits-panel" hx-confirm="Voltar esta conta ao limite padrão?" class="text-red-600 hover:text-red-800 underline">Usar padrão</button>
+                    {{end}}
+                </td>
+            </tr>
+            {{end}}
+        </tbody>
+    </table>
+</div>`
+
+// Template do histórico de execuções (um relatório por execução)
+const runsTemplate = `<form hx-get="/runs" hx-target="#runs-panel" hx-swap="innerHTML" class="flex flex-wrap items-end gap-4 mb-4">
+    <div>
+        <label class="block text-sm font-medium text-gray-700">Conta</label>
+        <input type="email" name="account" value="{{.Account}}" placeholder="todas"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+    </div>
+    <button type="submit"
+            class="bg-linkedin text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-linkedin focus:ring-offset-2">
+        Filtrar
+    </button>
+</form>
+{{if .Runs}}
+<div class="overflow-x-auto">
+    <table class="min-w-full divide-y divide-gray-200">
+        <thead class="bg-gray-50">
+            <tr>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Início</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Conta</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Execução</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Status</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Duração</th>
+                <th class="px-6 py-3 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Cards</th>
+                <th class="px-6 py-3 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Capturados</th>
+                <th class="px-6 py-3 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Convites</th>
+                <th class="px-6 py-3 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Avisos</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Relatório</th>
+            </tr>
+        </thead>
+        <tbody class="bg-white divide-y divide-gray-200">
+            {{range .Runs}}
+            <tr>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{if not .StartedAt.IsZero}}{{.StartedAt.Format "02/01 15:04:05"}}{{end}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Account}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Label}} <span class="text-gray-500">({{.Mode}})</span></td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm">
+                    {{if eq .Status "running"}}<span class="text-blue-600 font-semibold" title="Em execução ou interrompida">Em execução</span>
+                    {{else if eq .Status "failed"}}<span class="text-red-600 font-semibold" title="{{.Error}}">Falhou</span>
+                    {{else}}<span class="text-green-600 font-semibold">Concluída</span>{{end}}
+                </td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{if .Duration}}{{.Duration}}{{end}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900 text-right">{{if .Queries}}{{.Stats.Totals.Cards}}{{else}}-{{end}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900 text-right">{{.Captured}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900 text-right">{{.Invites}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-right {{if .Warnings}}text-yellow-600{{else}}text-gray-900{{end}}">{{len .Warnings}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm">
+                    <a href="/runs/{{.ID}}/report" target="_blank" class="text-linkedin underline">Ver</a>
+                    <a href="/runs/{{.ID}}/report.json" target="_blank" class="text-linkedin underline ml-2">JSON</a>
+                </td>
+            </tr>
+            {{end}}
+        </tbody>
+    </table>
+</div>
+{{else}}
+<div class="text-center py-8 text-gray-500">
+    <p>Nenhuma execução registrada.</p>
+</div>
+{{end}}`
+
+// Página do relatório de uma execução (configuração, contadores por query e avisos)
+const runReportTemplate = `<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Execução {{.Run.Label}} - LinkedIn Visible Crawler</title>
+    <script src="https://cdn.tailwindcss.com"></script>
+    <script>
+        tailwind.config = {
+            theme: {
+                extend: {
+                    colors: {
+                        'linkedin': '#0077B5'
+                    }
+                }
+            }
+        }
+    </script>
+</head>
+<body class="bg-gray-50 min-h-screen">
+    <nav class="bg-linkedin text-white shadow-lg">
+        <div class="max-w-7xl mx-auto px-4 sm:px-6 lg:px-8">
+            <div class="flex justify-between h-16">
+                <div class="flex items-center">
+                    <h1 class="text-xl font-bold">📜 Relatório da Execução</h1>
+                </div>
+                <div class="flex items-center space-x-4">
+                    <a href="/runs/{{.Run.ID}}/report.json" class="text-sm hover:underline">JSON</a>
+                    <a href="/" class="text-sm hover:underline">← Voltar ao crawler</a>
+                </div>
+            </div>
+        </div>
+    </nav>
+
+    <div class="max-w-7xl mx-auto px-4 sm:px-6 lg:px-8 py-8">
+        <!-- Resumo -->
+        {{with .Run}}
+        <div class="bg-white rounded-lg shadow-md p-6 mb-8">
+            <div class="flex justify-between items-center mb-4">
+                <h2 class="text-lg font-semibold text-gray-900">{{.Label}} <span class="text-gray-500 font-normal">({{.Mode}})</span></h2>
+                {{if eq .Status "running"}}<span class="text-blue-600 font-semibold">Em execução (ou interrompida)</span>
+                {{else if eq .Status "failed"}}<span class="text-red-600 font-semibold">Falhou</span>
+                {{else}}<span class="text-green-600 font-semibold">Concluída</span>{{end}}
+            </div>
+            <dl class="grid grid-cols-2 md:grid-cols-4 gap-4 text-sm">
+                <div><dt class="text-gray-500">Conta</dt><dd class="text-gray-900">{{.Account}}</dd></div>
+                <div><dt class="text-gray-500">Início</dt><dd class="text-gray-900">{{if not .StartedAt.IsZero}}{{.StartedAt.Format "02/01/2006 15:04:05"}}{{else}}-{{end}}</dd></div>
+                <div><dt class="text-gray-500">Fim</dt><dd class="text-gray-900">{{if not .FinishedAt.IsZero}}{{.FinishedAt.Format "02/01/2006 15:04:05"}}{{else}}-{{end}}</dd></div>
+                <div><dt class="text-gray-500">Duração</dt><dd class="text-gray-900">{{if .Duration}}{{.Duration}}{{else}}-{{end}}</dd></div>
+            </dl>
+            {{if .Error}}
+            <div class="text-red-600 bg-red-50 p-3 rounded-md mt-4"><strong>Erro final:</strong> {{.Error}}</div>
+            {{end}}
+        </div>
+        {{end}}
+
+        <!-- Totais -->
+        {{$outcomes := .Outcomes}}
+        {{with .Totals}}
+        <div class="grid grid-cols-2 md:grid-cols-4 gap-6 mb-8">
+            <div class="bg-white rounded-lg shadow-md p-6 text-center">
+                <div class="text-2xl font-bold text-gray-700">{{.Cards}}</div>
+                <div class="text-sm text-gray-600">Cards encontrados</div>
+            </div>
+            <div class="bg-white rounded-lg shadow-md p-6 text-center">
+                <div class="text-2xl font-bold text-blue-600">{{.Captured}}</div>
+                <div class="text-sm text-gray-600">Capturados</div>
+            </div>
+            <div class="bg-white rounded-lg shadow-md p-6 text-center">
+                <div class="text-2xl font-bold text-green-600">{{index .Attempts "sent"}}</div>
+                <div class="text-sm text-gray-600">Convites enviados</div>
+            </div>
+            <div class="bg-white rounded-lg shadow-md p-6 text-center">
+                <div class="text-2xl font-bold text-yellow-600">{{len $.Run.Warnings}}</div>
+                <div class="text-sm text-gray-600">Avisos</div>
+            </div>
+        </div>
+        {{end}}
+
+        <!-- Contadores por query -->
+        <div class="bg-white rounded-lg shadow-md p-6 mb-8">
+            <h2 class="text-lg font-semibold text-gray-900 mb-4">Por query</h2>
+            {{if .Run.Queries}}
+            <div class="overflow-x-auto">
+                <table class="min-w-full divide-y divide-gray-200 text-sm">
+                    <thead class="bg-gray-50">
+                        <tr>
+                            <th class="px-3 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Query</th>
+                            <th class="px-3 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Cards</th>
+                            <th class="px-3 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Capt.</th>
+                            {{range $outcomes}}<th class="px-3 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">{{.Title}}</th>{{end}}
+                        </tr>
+                    </thead>
+                    <tbody class="bg-white divide-y divide-gray-200">
+                        {{range .Run.Queries}}{{$q := .}}
+                        <tr>
+                            <td class="px-3 py-2 text-gray-900 max-w-xs truncate" title="{{.Query}}">
+                                {{if .Query}}{{.Query}}{{else}}<span class="text-gray-500">lista de perfis</span>{{end}}
+                                {{if .Error}}<div class="text-red-600 text-xs">{{.Error}}</div>{{end}}
+                            </td>
+                            <td class="px-3 py-2 text-right text-gray-900">{{.Cards}}</td>
+                            <td class="px-3 py-2 text-right text-gray-900">{{.Captured}}</td>
+                            {{range $outcomes}}<td class="px-3 py-2 text-right text-gray-900">{{index $q.Attempts .ID}}</td>{{end}}
+                        </tr>
+                        {{end}}
+                        {{with .Totals}}{{$t := .}}
+                        <tr class="bg-gray-50 font-semibold">
+                            <td class="px-3 py-2 text-gray-900">Total</td>
+                            <td class="px-3 py-2 text-right text-gray-900">{{.Cards}}</td>
+                            <td class="px-3 py-2 text-right text-gray-900">{{.Captured}}</td>
+                            {{range $outcomes}}<td class="px-3 py-2 text-right text-gray-900">{{index $t.Attempts .ID}}</td>{{end}}
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+            {{else}}
+            <p class="text-gray-500 text-sm">Sem contadores por query (execução em andamento, interrompida ou registrada antes dos relatórios).</p>
+            {{end}}
+        </div>
+
+        <div class="grid grid-cols-1 lg:grid-cols-2 gap-8">
+            <!-- Configuração -->
+            <div class="bg-white rounded-lg shadow-md p-6">
+                <h2 class="text-lg font-semibold text-gray-900 mb-4">Configuração</h2>
+                {{with .Run.Config}}
+                <dl class="grid grid-cols-2 gap-3 text-sm">
+                    <div><dt class="text-gray-500">Modo</dt><dd class="text-gray-900">{{.Mode}}</dd></div>
+                    <div><dt class="text-gray-500">Headless</dt><dd class="text-gray-900">{{if .Headless}}sim{{else}}não{{end}}</dd></div>
+                    <div><dt class="text-gray-500">Max cards por página</dt><dd class="text-gray-900">{{.MaxCardsRead}}</dd></div>
+                    <div><dt class="text-gray-500">Max conexões por página</dt><dd class="text-gray-900">{{.MaxConnectsPerPage}}</dd></div>
+                    <div><dt class="text-gray-500">Max páginas</dt><dd class="text-gray-900">{{.MaxPages}}</dd></div>
+                    <div><dt class="text-gray-500">Max convites na execução</dt><dd class="text-gray-900">{{if .MaxInvites}}{{.MaxInvites}}{{else}}sem limite{{end}}</dd></div>
+                    {{if .Company}}
+                    <div><dt class="text-gray-500">Empresa</dt><dd class="text-gray-900">{{.Company}}</dd></div>
+                    <div><dt class="text-gray-500">Palavras-chave / Localização</dt><dd class="text-gray-900">{{.CompanyKeywords}} / {{.CompanyLocation}}</dd></div>
+                    {{end}}
+                </dl>
+                {{if .Queries}}
+                <h3 class="text-sm font-medium text-gray-700 mt-4 mb-2">Queries ({{len .Queries}})</h3>
+                <ul class="text-sm text-gray-900 list-disc list-inside max-h-48 overflow-y-auto">
+                    {{range .Queries}}<li class="truncate">{{.}}</li>{{end}}
+                </ul>
+                {{end}}
+                {{if .Profiles}}
+                <h3 class="text-sm font-medium text-gray-700 mt-4 mb-2">Perfis ({{len .Profiles}})</h3>
+                <ul class="text-sm text-gray-900 list-disc list-inside max-h-48 overflow-y-auto">
+                    {{range .Profiles}}<li class="truncate">{{.}}</li>{{end}}
+                </ul>
+                {{end}}
+                {{else}}
+                <p class="text-gray-500 text-sm">Configuração não registrada.</p>
+                {{end}}
+            </div>
+
+            <!-- Avisos -->
+            <div class="bg-white rounded-lg shadow-md p-6">
+                <h2 class="text-lg font-semibold text-gray-900 mb-4">Avisos</h2>
+                {{if .Run.Warnings}}
+                <ul class="text-sm text-yellow-800 space-y-1 max-h-96 overflow-y-auto font-mono">
+                    {{range .Run.Warnings}}<li>{{.}}</li>{{end}}
+                </ul>
+                {{else}}
+                <p class="text-gray-500 text-sm">Nenhum aviso.</p>
+                {{end}}
+            </div>
+        </div>
+    </div>
+</body>
+</html>`
+
+// Template de webhooks (formulário, inscrições e últimas entregas)
+const webhooksTemplate = `{{if .Error}}
+<div class="text-red-600 bg-red-50 p-3 rounded-md mb-4">{{.Error}}</div>
+{{end}}
+{{with .Created}}
+<div class="text-green-800 bg-green-50 p-3 rounded-md mb-4">
+    Webhook <strong>{{.Name}}</strong> criado. Copie o segredo agora; ele não será exibido novamente:
+    <code class="block mt-1 text-xs break-all select-all">{{.Secret}}</code>
+</div>
+{{end}}
+<form hx-post="/webhooks" hx-target="#webhooks-panel" hx-swap="innerHTML" class="grid grid-cols-1 md:grid-cols-3 gap-4 mb-6">
+    <div>
+        <label class="block text-sm font-medium text-gray-700">Nome</label>
+        <input type="text" name="name" required placeholder="Slack vendas"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+        <label class="block text-sm font-medium text-gray-700 mt-2">URL</label>
+        <input type="url" name="url" required placeholder="https://n8n.exemplo.com/webhook/linkedin"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+    </div>
+    <div>
+        <label class="block text-sm font-medium text-gray-700">Segredo (vazio = gerar)</label>
+        <input type="text" name="secret"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm font-mono text-sm focus:border-linkedin focus:ring-linkedin">
+        <label class="block text-sm font-medium text-gray-700 mt-2">Eventos (nenhum = todos)</label>
+        {{range .Events}}
+        <label class="flex items-center text-sm text-gray-700">
+            <input type="checkbox" name="events" value="{{.ID}}" class="h-4 w-4 text-linkedin focus:ring-linkedin border-gray-300 rounded mr-2">
+            {{.Title}} <code class="ml-1 text-xs text-gray-500">{{.ID}}</code>
+        </label>
+        {{end}}
+    </div>
+    <div class="flex flex-col justify-end">
+        <p class="text-xs text-gray-500 mb-2">POST JSON assinado: <code>X-Webhook-Signature: sha256=HMAC(segredo, "&lt;X-Webhook-Timestamp&gt;.&lt;corpo&gt;")</code>. Falhas têm até 5 tentativas com espera crescente.</p>
+        <button type="submit"
+                class="bg-linkedin text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-linkedin focus:ring-offset-2">
+            Criar webhook
+        </button>
+    </div>
+</form>
+
+{{if .Subscriptions}}
+<div class="overflow-x-auto mb-6">
+    <table class="min-w-full divide-y divide-gray-200">
+        <thead class="bg-gray-50">
+            <tr>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Nome</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">URL</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Eventos</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Segredo</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Ações</th>
+            </tr>
+        </thead>
+        <tbody class="bg-white divide-y divide-gray-200">
+            {{range .Subscriptions}}
+            <tr>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Name}}{{if not .Active}} <span class="text-gray-500">(pausado)</span>{{end}}</td>
+                <td class="px-6 py-4 text-sm text-gray-900 max-w-xs truncate" title="{{.URL}}">{{.URL}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{if .Events}}{{range .Events}}<div>{{.}}</div>{{end}}{{else}}Todos{{end}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900"><code class="text-xs">{{.MaskedSecret}}</code></td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm space-x-2">
+                    {{if .Active}}
+                    <button hx-post="/webhooks/{{.ID}}/pause" hx-target="#webhooks-panel" class="text-yellow-600 hover:text-yellow-800 underline">Pausar</button>
+                    {{else}}
+                    <button hx-post="/webhooks/{{.ID}}/resume" hx-target="#webhooks-panel" class="text-blue-600 hover:text-blue-800 underline">Retomar</button>
+                    {{end}}
+                    <button hx-delete="/webhooks/{{.ID}}" hx-target="#webhooks-panel" hx-confirm="Remover este webhook?" class="text-red-600 hover:text-red-800 underline">Excluir</button>
+                </td>
+            </tr>
+            {{end}}
+        </tbody>
+    </table>
+</div>
+{{else}}
+<div class="text-center py-8 text-gray-500">
+    <p>Nenhum webhook configurado.</p>
+</div>
+{{end}}
+
+{{if .Deliveries}}
+<h3 class="text-md font-semibold text-gray-900 mb-2">Últimas entregas</h3>
+<div class="overflow-x-auto">
+    <table class="min-w-full divide-y divide-gray-200">
+        <thead class="bg-gray-50">
+            <tr>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Data/Hora</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Webhook</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Evento</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Tentativas</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Resultado</th>
+            </tr>
+        </thead>
+        <tbody class="bg-white divide-y divide-gray-200">
+            {{range .Deliveries}}
+            <tr>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Timestamp.Format "02/01/2006 15:04:05"}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Subscription}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900"><code>{{.Event}}</code></td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Attempts}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm">
+                    {{if .Delivered}}<span class="text-green-600">Entregue ({{.Status}})</span>
+                    {{else}}<span class="text-red-600" title="{{.Error}}">Falhou{{if .Status}} ({{.Status}}){{end}}</span>{{end}}
+                </td>
+            </tr>
+            {{end}}
+        </tbody>
+    </table>
+</div>
+{{end}}`
+
+// Template de privacidade (exportação/remoção por pessoa, retenção e auditoria)
+const privacyTemplate = `{{if .Error}}
+<div class="text-red-600 bg-red-50 p-3 rounded-md mb-4">{{.Error}}</div>
+{{end}}
+{{if .Message}}
+<div class="text-green-600 bg-green-50 p-3 rounded-md mb-4">{{.Message}}</div>
+{{end}}
+<form id="privacy-form" action="/privacy/export" method="get" class="grid grid-cols-1 md:grid-cols-3 gap-4 mb-2">
+    <div>
+        <label class="block text-sm font-medium text-gray-700">URL do perfil</label>
+        <input type="text" name="url" placeholder="https://www.linkedin.com/in/fulano"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+    </div>
+    <div>
+        <label class="block text-sm font-medium text-gray-700">Nome completo (opcional)</label>
+        <input type="text" name="name" placeholder="Fulano de Tal"
+               class="mt-1 block w-full rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+    </div>
+    <div class="flex items-end space-x-2">
+        <button type="submit"
+                class="bg-linkedin text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-linkedin focus:ring-offset-2">
+            Exportar dados (JSON)
+        </button>
+        <button type="button" hx-post="/privacy/erase" hx-include="#privacy-form" hx-target="#privacy-panel" hx-swap="innerHTML"
+                hx-confirm="Apagar todos os registros desta pessoa (convites, contatos, capturas, mensagens, inscrições e arquivos de diagnóstico)? Não é possível desfazer."
+                class="bg-red-600 text-white py-2 px-4 rounded-md hover:bg-red-700 focus:outline-none focus:ring-2 focus:ring-red-500 focus:ring-offset-2">
+            Apagar
+        </button>
+    </div>
+</form>
+<p class="text-xs text-gray-500 mb-6">O nome identifica todos os homônimos; prefira a URL do perfil. Exporte antes de apagar para responder ao pedido de acesso.</p>
+
+<div class="flex items-center justify-between mb-6 pb-6 border-b">
+    <p class="text-sm text-gray-700">
+        {{if .Retention}}Retenção: registros com mais de <strong>{{.Retention}} dias</strong> são removidos diariamente.
+        {{else}}Retenção desativada (defina <code>RETENTION_DAYS</code>).{{end}}
+    </p>
+    {{if .Retention}}
+    <button hx-post="/privacy/purge" hx-target="#privacy-panel" hx-swap="innerHTML"
+            hx-confirm="Remover agora os registros com mais de {{.Retention}} dias?"
+            class="px-3 py-2 border border-gray-300 rounded-md text-sm font-medium text-gray-700 bg-white hover:bg-gray-50">
+        Aplicar retenção agora
+    </button>
+    {{end}}
+</div>
+
+{{if .Audit}}
+<h3 class="text-md font-semibold text-gray-900 mb-2">Auditoria de remoções</h3>
+<div class="overflow-x-auto">
+    <table class="min-w-full divide-y divide-gray-200">
+        <thead class="bg-gray-50">
+            <tr>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Data/Hora</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Tipo</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Origem</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Critério</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Pessoa (hash)</th>
+                <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Removidos</th>
+            </tr>
+        </thead>
+        <tbody class="bg-white divide-y divide-gray-200">
+            {{range .Audit}}
+            <tr>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Timestamp.Format "02/01/2006 15:04:05"}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{if eq .Kind "retention"}}Retenção{{else}}Remoção{{end}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Source}}</td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900"><code>{{.Criteria}}</code></td>
+                <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900"><code>{{.SubjectHash}}</code></td>
+                <td class="px-6 py-4 text-sm text-gray-900">
+                    <strong>{{.Total}}</strong>
+                    <span class="text-gray-500">{{range $source, $n := .Removed}}{{if $n}} {{$source}}: {{$n}}{{end}}{{end}}</span>
+                    {{if .Errors}}<span class="text-red-600" title="{{range .Errors}}{{.}}; {{end}}">com erros</span>{{end}}
+                </td>
+            </tr>
+            {{end}}
+        </tbody>
+    </table>
+</div>
+{{end}}`
+
+// Página de analytics do funil
+const analyticsTemplate = `<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Analytics - LinkedIn Visible Crawler</title>
+    <script src="https://cdn.tailwindcss.com"></script>
+    <script>
+        tailwind.config = {
+            theme: {
+                extend: {
+                    colors: {
+                        'linkedin': '#0077B5'
+                    }
+                }
+            }
+        }
+    </script>
+</head>
+<body class="bg-gray-50 min-h-screen">
+    <nav class="bg-linkedin text-white shadow-lg">
+        <div class="max-w-7xl mx-auto px-4 sm:px-6 lg:px-8">
+            <div class="flex justify-between h-16">
+                <div class="flex items-center">
+                    <h1 class="text-xl font-bold">📈 Funil de Prospecção</h1>
+                </div>
+                <div class="flex items-center space-x-4">
+                    <a href="/" class="text-sm hover:underline">← Voltar ao crawler</a>
+                </div>
+            </div>
+        </div>
+    </nav>
+
+    <div class="max-w-7xl mx-auto px-4 sm:px-6 lg:px-8 py-8">
+        <!-- Filtro de período -->
+        <div class="bg-white rounded-lg shadow-md p-6 mb-8">
+            <form method="get" action="/analytics" class="flex flex-wrap items-end gap-4">
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">De</label>
+                    <input type="date" name="from" value="{{.From}}"
+                           class="mt-1 block rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700">Até</label>
+                    <input type="date" name="to" value="{{.To}}"
+                           class="mt-1 block rounded-md border-gray-300 shadow-sm focus:border-linkedin focus:ring-linkedin">
+                </div>
+                <button type="submit"
+                        class="bg-linkedin text-white py-2 px-4 rounded-md hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-linkedin focus:ring-offset-2">
+                    Aplicar
+                </button>
+                <div class="flex space-x-3 text-sm">
+                    <a href="/analytics?days=7" class="text-linkedin underline">7 dias</a>
+                    <a href="/analytics?days=30" class="text-linkedin underline">30 dias</a>
+                    <a href="/analytics?days=90" class="text-linkedin underline">90 dias</a>
+                    <a href="/analytics?from=&amp;to=" class="text-linkedin underline">Tudo</a>
+                </div>
+            </form>
+        </div>
+
+        <!-- Totais -->
+        {{with .Report.Totals}}
+        <div class="grid grid-cols-2 md:grid-cols-4 gap-6 mb-8">
+            <div class="bg-white rounded-lg shadow-md p-6 text-center">
+                <div class="text-2xl font-bold text-blue-600">{{.Captured}}</div>
+                <div class="text-sm text-gray-600">Capturados</div>
+            </div>
+            <div class="bg-white rounded-lg shadow-md p-6 text-center">
+                <div class="text-2xl font-bold text-indigo-600">{{.Invited}}</div>
+                <div class="text-sm text-gray-600">Convidados ({{printf "%.1f" .InviteRate}}%)</div>
+            </div>
+            <div class="bg-white rounded-lg shadow-md p-6 text-center">
+                <div class="text-2xl font-bold text-green-600">{{.Accepted}}</div>
+                <div class="text-sm text-gray-600">Aceitos ({{printf "%.1f" .AcceptRate}}%)</div>
+            </div>
+            <div class="bg-white rounded-lg shadow-md p-6 text-center">
+                <div class="text-2xl font-bold text-purple-600">{{.Replied}}</div>
+                <div class="text-sm text-gray-600">Responderam ({{printf "%.1f" .ReplyRate}}%)</div>
+            </div>
+        </div>
+        {{end}}
+
+        <!-- Tabelas por dimensão -->
+        {{$from := .From}}{{$to := .To}}
+        <div class="grid grid-cols-1 lg:grid-cols-2 gap-8">
+            {{range .Report.Tables}}
+            <div class="bg-white rounded-lg shadow-md p-6">
+                <div class="flex justify-between items-center mb-4">
+                    <h2 class="text-lg font-semibold text-gray-900">{{.Title}}</h2>
+                    <a href="/analytics/{{.ID}}.csv?from={{$from}}&amp;to={{$to}}" class="text-sm text-linkedin underline">Baixar CSV</a>
+                </div>
+                {{if .Rows}}
+                <div class="overflow-x-auto max-h-96 overflow-y-auto">
+                    <table class="min-w-full divide-y divide-gray-200 text-sm">
+                        <thead class="bg-gray-50">
+                            <tr>
+                                <th class="px-3 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider"></th>
+                                <th class="px-3 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Capt.</th>
+                                <th class="px-3 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Conv.</th>
+                                <th class="px-3 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Aceitos</th>
+                                <th class="px-3 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Resp.</th>
+                                <th class="px-3 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">% Aceite</th>
+                            </tr>
+                        </thead>
+                        <tbody class="bg-white divide-y divide-gray-200">
+                            {{range .Rows}}
+                            <tr>
+                                <td class="px-3 py-2 text-gray-900 max-w-xs truncate" title="{{.Key}}">{{.Key}}</td>
+                                <td class="px-3 py-2 text-right text-gray-900">{{.Captured}}</td>
+                                <td class="px-3 py-2 text-right text-gray-900">{{.Invited}}</td>
+                                <td class="px-3 py-2 text-right text-gray-900">{{.Accepted}}</td>
+                                <td class="px-3 py-2 text-right text-gray-900">{{.Replied}}</td>
+                                <td class="px-3 py-2 text-right text-gray-900">{{printf "%.1f" .AcceptRate}}%</td>
+                            </tr>
+                            {{end}}
+                        </tbody>
+                    </table>
+                </div>
+                {{else}}
+                <p class="text-center py-6 text-gray-500">Sem dados no período.</p>
+                {{end}}
+            </div>
+            {{end}}
+        </div>
+    </div>
+</body>
+</html>`
+
 // Partial da tabela de convites
 const invitesTablePartial = `{{template "invites-table" .}}`
 
@@ -0,0 +1,403 @@
+package webhooks
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de evento
+const (
+	EventInviteSent   = "invite.sent"   // convite enviado e registrado
+	EventRunFinished  = "run.finished"  // execução do crawler terminou (com ou sem erro)
+	EventLimitReached = "limit.reached" // limite de convites da conta atingido
+)
+
+// Events ordem e descrição dos eventos (formulário da UI)
+var Events = []struct {
+	ID    string
+	Title string
+}{
+	{EventInviteSent, "Convite enviado"},
+	{EventRunFinished, "Execução concluída"},
+	{EventLimitReached, "Limite atingido"},
+}
+
+// Cabeçalhos de cada entrega. A assinatura é HMAC-SHA256 (hex) do texto
+// "<X-Webhook-Timestamp>.<corpo>" com o segredo da inscrição.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderID        = "X-Webhook-Id"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature" // "sha256=<hex>"
+)
+
+// maxAttempts tentativas por entrega (a espera dobra a cada nova tentativa)
+const maxAttempts = 5
+
+// Subscription destino que recebe os eventos selecionados
+type Subscription struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	Events    []string  `json:"events,omitempty"` // vazio = todos
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MaskedSecret segredo mascarado para exibição (só os 4 últimos caracteres;
+// o segredo completo aparece apenas na criação)
+func (s Subscription) MaskedSecret() string {
+	if len(s.Secret) <= 8 {
+		return "••••••••"
+	}
+	return "••••••••" + s.Secret[len(s.Secret)-4:]
+}
+
+// Wants indica se a inscrição recebe o evento
+func (s Subscription) Wants(event string) bool {
+	if !s.Active {
+		return false
+	}
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, e := range s.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Payload corpo JSON de cada entrega
+type Payload struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// Delivery resultado de uma entrega (registrado após a última tentativa)
+type Delivery struct {
+	ID             string    `json:"id"`
+	SubscriptionID string    `json:"subscription_id"`
+	Subscription   string    `json:"subscription"`
+	Event          string    `json:"event"`
+	Timestamp      time.Time `json:"timestamp"`
+	Attempts       int       `json:"attempts"`
+	Status         int       `json:"status,omitempty"` // último status HTTP
+	Delivered      bool      `json:"delivered"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// state conteúdo de data/webhooks.json (pode ser editado à mão com o servidor parado)
+type state struct {
+	Subscriptions []*Subscription `json:"subscriptions"`
+}
+
+// Manager mantém as inscrições em data/webhooks.json e entrega os eventos
+// em background, registrando cada entrega em data/webhook_deliveries.jsonl
+type Manager struct {
+	mu         sync.Mutex
+	path       string
+	logPath    string
+	state      state
+	client     *http.Client
+	retryDelay time.Duration // espera antes da primeira nova tentativa
+}
+
+// New carrega as inscrições de data/webhooks.json
+func New() (*Manager, error) {
+	m := &Manager{
+		path:       filepath.Join("data", "webhooks.json"),
+		logPath:    filepath.Join("data", "webhook_deliveries.jsonl"),
+		client:     &http.Client{Timeout: 10 * time.Second},
+		retryDelay: 2 * time.Second,
+	}
+
+	content, err := os.ReadFile(m.path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("erro ao ler webhooks: %v", err)
+	}
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, &m.state); err != nil {
+			return nil, fmt.Errorf("erro ao interpretar webhooks: %v", err)
+		}
+	}
+	for _, sub := range m.state.Subscriptions {
+		if err := validate(sub.URL, sub.Events); err != nil {
+			return nil, fmt.Errorf("webhook %s: %v", sub.Name, err)
+		}
+	}
+	return m, nil
+}
+
+// validate confere a URL e os tipos de evento
+func validate(url string, events []string) error {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return fmt.Errorf("URL inválida: %q", url)
+	}
+	for _, e := range events {
+		known := false
+		for _, ev := range Events {
+			known = known || ev.ID == e
+		}
+		if !known {
+			return fmt.Errorf("evento desconhecido: %s", e)
+		}
+	}
+	return nil
+}
+
+// List retorna cópia das inscrições
+func (m *Manager) List() []Subscription {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]Subscription, 0, len(m.state.Subscriptions))
+	for _, sub := range m.state.Subscriptions {
+		cp := *sub
+		cp.Events = append([]string(nil), sub.Events...)
+		out = append(out, cp)
+	}
+	return out
+}
+
+// Create adiciona uma inscrição ativa; sem segredo, um aleatório é gerado
+func (m *Manager) Create(name, url, secret string, events []string) (Subscription, error) {
+	name, url, secret = strings.TrimSpace(name), strings.TrimSpace(url), strings.TrimSpace(secret)
+	if name == "" {
+		return Subscription{}, fmt.Errorf("nome é obrigatório")
+	}
+	if err := validate(url, events); err != nil {
+		return Subscription{}, err
+	}
+	if secret == "" {
+		buf := make([]byte, 24)
+		if _, err := rand.Read(buf); err != nil {
+			return Subscription{}, fmt.Errorf("erro ao gerar segredo: %v", err)
+		}
+		secret = hex.EncodeToString(buf)
+	}
+
+	sub := Subscription{
+		ID:        uuid.New().String(),
+		Name:      name,
+		URL:       url,
+		Secret:    secret,
+		Events:    events,
+		Active:    true,
+		CreatedAt: time.Now(),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.Subscriptions = append(m.state.Subscriptions, &sub)
+	if err := m.saveLocked(); err != nil {
+		return Subscription{}, err
+	}
+	return sub, nil
+}
+
+// SetActive ativa ou pausa uma inscrição
+func (m *Manager) SetActive(id string, active bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, sub := range m.state.Subscriptions {
+		if sub.ID == id {
+			sub.Active = active
+			return m.saveLocked()
+		}
+	}
+	return fmt.Errorf("webhook não encontrado: %s", id)
+}
+
+// Delete remove uma inscrição
+func (m *Manager) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, sub := range m.state.Subscriptions {
+		if sub.ID == id {
+			m.state.Subscriptions = append(m.state.Subscriptions[:i], m.state.Subscriptions[i+1:]...)
+			return m.saveLocked()
+		}
+	}
+	return fmt.Errorf("webhook não encontrado: %s", id)
+}
+
+// Emit entrega o evento, em background, a cada inscrição ativa que o recebe
+func (m *Manager) Emit(event string, data interface{}) {
+	if m == nil {
+		return
+	}
+	payload := Payload{ID: uuid.New().String(), Event: event, Timestamp: time.Now(), Data: data}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+
+	for _, sub := range m.List() {
+		if sub.Wants(event) {
+			go m.deliver(sub, payload, body)
+		}
+	}
+}
+
+// Sign assinatura enviada em HeaderSignature
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver envia o evento com novas tentativas (erros de rede, 429 e 5xx) e
+// registra o resultado
+func (m *Manager) deliver(sub Subscription, payload Payload, body []byte) {
+	d := Delivery{
+		ID:             payload.ID,
+		SubscriptionID: sub.ID,
+		Subscription:   sub.Name,
+		Event:          payload.Event,
+		Timestamp:      payload.Timestamp,
+	}
+
+	delay := m.retryDelay
+	for d.Attempts < maxAttempts {
+		if d.Attempts > 0 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+		d.Attempts++
+
+		status, err := m.post(sub, payload, body)
+		d.Status = status
+		if err == nil {
+			d.Delivered, d.Error = true, ""
+			break
+		}
+		d.Error = err.Error()
+		if status != 0 && status != http.StatusTooManyRequests && status < 500 {
+			break // erro definitivo (4xx)
+		}
+	}
+
+	m.record(d)
+}
+
+// post faz uma tentativa de entrega
+func (m *Manager) post(sub Subscription, payload Payload, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "linkedin-visible-crawler")
+	req.Header.Set(HeaderEvent, payload.Event)
+	req.Header.Set(HeaderID, payload.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, timestamp, body))
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// record acrescenta a entrega ao log
+func (m *Manager) record(d Delivery) {
+	line, err := json.Marshal(d)
+	if err != nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(m.logPath), 0755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(m.logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	f.Write(append(line, '\n'))
+}
+
+// Recent retorna as últimas n entregas, da mais recente para a mais antiga
+func (m *Manager) Recent(n int) ([]Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.Open(m.logPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir log de webhooks: %v", err)
+	}
+	defer f.Close()
+
+	var all []Delivery
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var d Delivery
+		if json.Unmarshal(scanner.Bytes(), &d) == nil {
+			all = append(all, d)
+		}
+	}
+
+	out := make([]Delivery, 0, n)
+	for i := len(all) - 1; i >= 0 && len(out) < n; i-- {
+		out = append(out, all[i])
+	}
+	return out, scanner.Err()
+}
+
+// saveLocked grava o estado de forma atômica (arquivo temporário + rename)
+func (m *Manager) saveLocked() error {
+	if err := os.MkdirAll(filepath.Dir(m.path), 0755); err != nil {
+		return fmt.Errorf("erro ao criar diretório de webhooks: %v", err)
+	}
+
+	content, err := json.MarshalIndent(m.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar webhooks: %v", err)
+	}
+
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return fmt.Errorf("erro ao gravar webhooks: %v", err)
+	}
+	return os.Rename(tmp, m.path)
+}
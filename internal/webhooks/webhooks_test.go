@@ -0,0 +1,137 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"invite.sent"}`)
+	mac := hmac.New(sha256.New, []byte("segredo"))
+	mac.Write([]byte("1760000000." + string(body)))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got := Sign("segredo", "1760000000", body); got != want {
+		t.Errorf("Sign = %s, esperado %s", got, want)
+	}
+	if Sign("segredo", "1760000001", body) == want {
+		t.Error("Sign com outro timestamp = mesma assinatura, esperado diferente")
+	}
+	if Sign("outro", "1760000000", body) == want {
+		t.Error("Sign com outro segredo = mesma assinatura, esperado diferente")
+	}
+}
+
+func TestWants(t *testing.T) {
+	tests := []struct {
+		name   string
+		sub    Subscription
+		event  string
+		wanted bool
+	}{
+		{"todos os eventos", Subscription{Active: true}, EventRunFinished, true},
+		{"evento selecionado", Subscription{Active: true, Events: []string{EventInviteSent, EventLimitReached}}, EventLimitReached, true},
+		{"evento não selecionado", Subscription{Active: true, Events: []string{EventInviteSent}}, EventRunFinished, false},
+		{"pausada", Subscription{Active: false}, EventInviteSent, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sub.Wants(tt.event); got != tt.wanted {
+				t.Errorf("Wants(%s) = %v, esperado %v", tt.event, got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestMaskedSecret(t *testing.T) {
+	tests := []struct {
+		secret string
+		want   string
+	}{
+		{"0123456789abcdef", "••••••••cdef"},
+		{"curto", "••••••••"},
+		{"", "••••••••"},
+	}
+
+	for _, tt := range tests {
+		if got := (Subscription{Secret: tt.secret}).MaskedSecret(); got != tt.want {
+			t.Errorf("MaskedSecret(%q) = %q, esperado %q", tt.secret, got, tt.want)
+		}
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int // o último se repete
+		attempts  int
+		delivered bool
+		status    int
+	}{
+		{"sucesso", []int{http.StatusNoContent}, 1, true, http.StatusNoContent},
+		{"503 e 429 depois sucesso", []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}, 3, true, http.StatusOK},
+		{"4xx é definitivo", []int{http.StatusNotFound, http.StatusOK}, 1, false, http.StatusNotFound},
+		{"5xx esgota as tentativas", []int{http.StatusBadGateway}, maxAttempts, false, http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			calls := 0
+			var badSignature []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				mu.Lock()
+				defer mu.Unlock()
+				if got := r.Header.Get(HeaderSignature); got != Sign("segredo", r.Header.Get(HeaderTimestamp), body) {
+					badSignature = append(badSignature, got)
+				}
+				status := tt.statuses[len(tt.statuses)-1]
+				if calls < len(tt.statuses) {
+					status = tt.statuses[calls]
+				}
+				calls++
+				w.WriteHeader(status)
+			}))
+			defer srv.Close()
+
+			m := &Manager{
+				logPath:    filepath.Join(t.TempDir(), "webhook_deliveries.jsonl"),
+				client:     srv.Client(),
+				retryDelay: time.Millisecond,
+			}
+			sub := Subscription{ID: "s1", Name: "teste", URL: srv.URL, Secret: "segredo", Active: true}
+			payload := Payload{ID: "p1", Event: EventInviteSent, Timestamp: time.Now()}
+			m.deliver(sub, payload, []byte(`{"id":"p1"}`))
+
+			recent, err := m.Recent(10)
+			if err != nil || len(recent) != 1 {
+				t.Fatalf("Recent = %v, %v; esperado uma entrega registrada", recent, err)
+			}
+			d := recent[0]
+			if d.Attempts != tt.attempts || d.Delivered != tt.delivered || d.Status != tt.status {
+				t.Errorf("entrega = %d tentativas, entregue %v, status %d; esperado %d, %v, %d",
+					d.Attempts, d.Delivered, d.Status, tt.attempts, tt.delivered, tt.status)
+			}
+			if tt.delivered != (d.Error == "") {
+				t.Errorf("Error = %q com entregue = %v", d.Error, d.Delivered)
+			}
+			if calls != tt.attempts {
+				t.Errorf("%d requisições, esperado %d", calls, tt.attempts)
+			}
+			if len(badSignature) > 0 {
+				t.Errorf("assinaturas inválidas: %s", strings.Join(badSignature, ", "))
+			}
+		})
+	}
+}